@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 	"time"
 
 	"gorm.io/gorm/schema"
@@ -27,22 +28,61 @@ func initializeCallbacks(db *DB) *callbacks {
 	}
 }
 
+// FieldDelta before and after values of a changed field, stored to `gorm:field_deltas` when updating with `gorm:record_field_deltas`, E.g:
+//
+//	db.Set("gorm:record_field_deltas", true).Model(&user).Updates(map[string]interface{}{"name": "hello"})
+//
+//	func (u *User) AfterUpdate(tx *gorm.DB) error {
+//		if deltas, ok := tx.Get("gorm:field_deltas"); ok {
+//			for _, delta := range deltas.([]gorm.FieldDelta) {
+//				// audit delta.Field.Name, delta.Before, delta.After
+//			}
+//		}
+//		return nil
+//	}
+//
+// it runs an extra query to load the current row, only updating a record with primary keys is supported
+type FieldDelta struct {
+	Field  *schema.Field
+	Before interface{}
+	After  interface{}
+}
+
+// AfterCreateHook global after create hook, called once per Create after the AfterCreate/AfterSave hooks of models,
+// records is the created db.Statement.ReflectValue, a slice or array when creating in batches,
+// primaryKeys are the primary key values of each record, ordered by the primary fields of the schema
+type AfterCreateHook func(tx *DB, records reflect.Value, primaryKeys [][]interface{}) error
+
+// ClauseRewriter rewrite clauses of the statement before executing the callbacks of an operation, e.g: add a default LIMIT,
+// it is not called for subqueries built into the statement
+type ClauseRewriter func(stmt *Statement)
+
 // callbacks gorm callbacks manager
 type callbacks struct {
 	processors map[string]*processor
+	rewriters  []ClauseRewriter
 }
 
 type processor struct {
 	db        *DB
 	Clauses   []string
 	fns       []func(*DB)
+	matches   []func(*DB) bool // match functions of fns evaluated when executing, nil if no callback declares match
+	names     []string         // names of the compiled callbacks in execution order
 	callbacks []*callback
+	err       error // error of the last compile, the callback chain is broken if not nil
 }
 
+// callbackLabelPrefix references all callbacks having the label in Before and After, e.g: After("label:tracing")
+const callbackLabelPrefix = "label:"
+
 type callback struct {
 	name      string
 	before    string
 	after     string
+	declared  [2]string // before and after declared when registering, sorting may change before and after
+	labels    []string
+	priority  int // tiebreaker of callbacks without before and after relationship, lower runs first
 	remove    bool
 	replace   bool
 	match     func(*DB) bool
@@ -80,8 +120,34 @@ func (cs *callbacks) Raw() *processor {
 	return cs.processors["raw"]
 }
 
+// 注册子句重写器，在执行各操作的回调之前调用，子查询不会调用。
+func (cs *callbacks) RegisterClauseRewriter(fc ClauseRewriter) {
+	cs.rewriters = append(cs.rewriters, fc)
+}
+
+// 注册全局的创建后钩子，适用于审计日志等横切关注点，不需要每个模型都实现 AfterCreate，E.g:
+//
+//	db.Callback().RegisterAfterCreate("audit", func(tx *gorm.DB, records reflect.Value, primaryKeys [][]interface{}) error {
+//		return audit(tx.Statement.Table, primaryKeys)
+//	})
+//
+// 等同于 db.Callback().Create().After("gorm:after_create").Before("gorm:commit_or_rollback_transaction").Register("audit", fn)，
+// 在 fn 中通过 db.Statement.PrimaryKeyValues() 获取主键，但只在创建成功且没有跳过钩子时执行，钩子返回的错误会回滚默认事务。
+func (cs *callbacks) RegisterAfterCreate(name string, hook AfterCreateHook) error {
+	return cs.Create().After("gorm:after_create").Before("gorm:commit_or_rollback_transaction").Register(name, func(db *DB) {
+		if db.Error == nil && !db.Statement.SkipHooks && db.Statement.ReflectValue.IsValid() {
+			db.AddError(hook(db, db.Statement.ReflectValue, db.Statement.PrimaryKeyValues()))
+		}
+	})
+}
+
 // 执行回调。
 func (p *processor) Execute(db *DB) *DB {
+	return p.execute(db, false)
+}
+
+// 执行回调，子查询不调用子句重写器。
+func (p *processor) execute(db *DB, subQuery bool) *DB {
 	// call scopes
 	for len(db.Statement.scopes) > 0 {
 		db = db.executeScopes()
@@ -135,23 +201,62 @@ func (p *processor) Execute(db *DB) *DB {
 		}
 	}
 
-	for _, f := range p.fns {
-		f(db)
+	if p.err != nil {
+		db.AddError(fmt.Errorf("invalid callbacks: %w", p.err))
+	}
+
+	if !subQuery {
+		for _, rewrite := range db.callbacks.rewriters {
+			rewrite(stmt)
+		}
+	}
+
+	beforeErr := db.Error
+	if p.matches == nil {
+		for _, f := range p.fns {
+			f(db)
+		}
+	} else {
+		for idx, f := range p.fns {
+			if match := p.matches[idx]; match == nil || match(db) {
+				f(db)
+			}
+		}
+	}
+
+	if db.Error == ErrConstraintHandled {
+		db.Error = nil
 	}
 
 	if stmt.SQL.Len() > 0 {
-		db.Logger.Trace(stmt.Context, curTime, func() (string, int64) {
+		explainSQL := func() string {
 			sql, vars := stmt.SQL.String(), stmt.Vars
 			if filter, ok := db.Logger.(ParamsFilter); ok {
 				sql, vars = filter.ParamsFilter(stmt.Context, stmt.SQL.String(), stmt.Vars...)
 			}
-			return db.Dialector.Explain(sql, vars...), db.RowsAffected
-		}, db.Error)
+			return stmt.explain(sql, vars...)
+		}
+
+		// 将执行错误与 SQL 一起返回，便于排查。
+		err := db.Error
+		if db.ErrorWithSQL && db.Error != nil && db.Error != beforeErr && !errors.Is(db.Error, ErrRecordNotFound) {
+			db.Error = fmt.Errorf("%w (SQL: %s)", db.Error, explainSQL())
+		}
+
+		db.Logger.Trace(stmt.Context, curTime, func() (string, int64) {
+			return explainSQL(), db.RowsAffected
+		}, err)
+
+		if db.CheckBindVars && (stmt.bindVars.count != len(stmt.Vars) || len(stmt.bindVars.mismatches) > 0) {
+			db.Logger.Error(stmt.Context, "bind vars mismatch, %d placeholders written for %d vars %v, SQL: %s",
+				stmt.bindVars.count, len(stmt.Vars), stmt.bindVars.mismatches, stmt.SQL.String())
+		}
 	}
 
 	if !stmt.DB.DryRun {
 		stmt.SQL.Reset()
 		stmt.Vars = nil
+		stmt.bindVars = bindVarStats{}
 	}
 
 	if resetBuildClauses {
@@ -181,7 +286,7 @@ func (p *processor) After(name string) *callback {
 	return &callback{after: name, processor: p}
 }
 
-// 匹配回调。
+// 匹配回调，每次执行时根据当前的 *DB 判断是否执行该回调。
 func (p *processor) Match(fc func(*DB) bool) *callback {
 	return &callback{match: fc, processor: p}
 }
@@ -191,6 +296,16 @@ func (p *processor) Register(name string, fn func(*DB)) error {
 	return (&callback{processor: p}).Register(name, fn)
 }
 
+// 注册带标签的回调，其他回调可以通过 Before("label:xxx")、After("label:xxx") 引用所有带该标签的回调。
+func (p *processor) RegisterWithLabels(name string, fn func(*DB), labels ...string) error {
+	return (&callback{processor: p}).RegisterWithLabels(name, fn, labels...)
+}
+
+// 注册带优先级的回调，没有 before、after 关系的回调按优先级从小到大执行，优先级相同时按注册顺序执行，默认优先级为 0。
+func (p *processor) RegisterWithPriority(name string, priority int, fn func(*DB)) error {
+	return (&callback{processor: p}).RegisterWithPriority(name, priority, fn)
+}
+
 // 删除回调。
 func (p *processor) Remove(name string) error {
 	return (&callback{processor: p}).Remove(name)
@@ -202,27 +317,46 @@ func (p *processor) Replace(name string, fn func(*DB)) error {
 }
 
 // 编译回调。
-func (p *processor) compile() (err error) {
-	var callbacks []*callback
+func (p *processor) compile() error {
 	removedMap := map[string]bool{}
 	for _, callback := range p.callbacks {
-		if callback.match == nil || callback.match(p.db) {
-			callbacks = append(callbacks, callback)
-		}
 		if callback.remove {
 			removedMap[callback.name] = true
 		}
 	}
 
 	if len(removedMap) > 0 {
-		callbacks = removeCallbacks(callbacks, removedMap)
+		p.callbacks = removeCallbacks(p.callbacks, removedMap)
 	}
-	p.callbacks = callbacks
 
-	if p.fns, err = sortCallbacks(p.callbacks); err != nil {
-		p.db.Logger.Error(context.Background(), "Got error when compile callbacks, got %v", err)
+	var sorted []*callback
+	if sorted, p.err = sortCallbacks(p.callbacks); p.err != nil {
+		p.db.Logger.Error(context.Background(), "Got error when compile callbacks, got %v", p.err)
 	}
-	return
+
+	p.fns, p.names, p.matches = make([]func(*DB), 0, len(sorted)), make([]string, 0, len(sorted)), nil
+	for idx, c := range sorted {
+		p.fns = append(p.fns, c.handler)
+		p.names = append(p.names, c.name)
+		// match 在执行时根据当前的 *DB 判断，只有声明了 match 的回调才需要判断
+		if c.match != nil {
+			if p.matches == nil {
+				p.matches = make([]func(*DB) bool, len(sorted))
+			}
+			p.matches[idx] = c.match
+		}
+	}
+	return p.err
+}
+
+// 获取编译后的回调名称，按执行顺序排列，不包含已删除的回调。
+func (p *processor) Callbacks() []string {
+	return append([]string(nil), p.names...)
+}
+
+// 判断编译后的回调中是否存在该名称的回调。
+func (p *processor) Exists(name string) bool {
+	return utils.Contains(p.names, name)
 }
 
 // 在回调之前执行。
@@ -241,10 +375,29 @@ func (c *callback) After(name string) *callback {
 func (c *callback) Register(name string, fn func(*DB)) error {
 	c.name = name
 	c.handler = fn
+	c.declared = [2]string{c.before, c.after}
 	c.processor.callbacks = append(c.processor.callbacks, c)
 	return c.processor.compile()
 }
 
+// 注册带标签的回调，E.g:
+//
+//	db.Callback().Create().Before("gorm:create").RegisterWithLabels("otel:before_create", beforeCreate, "tracing")
+//	db.Callback().Create().After("label:tracing").Register("audit:before_create", audit) // runs after all tracing callbacks
+func (c *callback) RegisterWithLabels(name string, fn func(*DB), labels ...string) error {
+	c.labels = labels
+	return c.Register(name, fn)
+}
+
+// 注册带优先级的回调，E.g:
+//
+//	db.Callback().Query().RegisterWithPriority("cache:query", -10, queryCache) // runs before gorm:query
+//	db.Callback().Query().RegisterWithPriority("audit:query", 10, audit)        // runs after callbacks with priority 0
+func (c *callback) RegisterWithPriority(name string, priority int, fn func(*DB)) error {
+	c.priority = priority
+	return c.Register(name, fn)
+}
+
 // 删除回调。
 func (c *callback) Remove(name string) error {
 	c.processor.db.Logger.Warn(context.Background(), "removing callback `%s` from %s\n", name, utils.FileWithLineNum())
@@ -259,7 +412,19 @@ func (c *callback) Replace(name string, fn func(*DB)) error {
 	c.processor.db.Logger.Info(context.Background(), "replacing callback `%s` from %s\n", name, utils.FileWithLineNum())
 	c.name = name
 	c.handler = fn
+	c.declared = [2]string{c.before, c.after}
 	c.replace = true
+	// 替换的回调沿用原回调的优先级，未声明 before、after 时沿用原回调的位置，保证排序后位于原回调之后
+	for i := len(c.processor.callbacks) - 1; i >= 0; i-- {
+		if v := c.processor.callbacks[i]; v.name == name && !v.remove {
+			c.priority = v.priority
+			if c.before == "" && c.after == "" {
+				c.before, c.after = v.declared[0], v.declared[1]
+				c.declared, c.labels = v.declared, v.labels
+			}
+			break
+		}
+	}
 	c.processor.callbacks = append(c.processor.callbacks, c)
 	return c.processor.compile()
 }
@@ -275,12 +440,25 @@ func getRIndex(strs []string, str string) int {
 }
 
 // 排序回调。
-func sortCallbacks(cs []*callback) (fns []func(*DB), err error) {
+func sortCallbacks(cs []*callback) (callbacks []*callback, err error) {
 	var (
 		names, sorted []string
 		sortCallback  func(*callback) error
 	)
+	// show warning message the callback being registered already exists, replacing or removing callback is not duplicated
+	if last := len(cs) - 1; last > 0 && !cs[last].replace && !cs[last].remove {
+		for _, c := range cs[:last] {
+			if c.name == cs[last].name && !c.remove {
+				c.processor.db.Logger.Warn(context.Background(), "duplicated callback `%s` from %s\n", c.name, utils.FileWithLineNum())
+				break
+			}
+		}
+	}
+
 	sort.SliceStable(cs, func(i, j int) bool {
+		if (cs[i].before == "*") == (cs[j].before == "*") && (cs[i].after == "*") == (cs[j].after == "*") {
+			return cs[i].priority < cs[j].priority
+		}
 		if cs[j].before == "*" && cs[i].before != "*" {
 			return true
 		}
@@ -291,10 +469,6 @@ func sortCallbacks(cs []*callback) (fns []func(*DB), err error) {
 	})
 
 	for _, c := range cs {
-		// show warning message the callback name already exists
-		if idx := getRIndex(names, c.name); idx > -1 && !c.replace && !c.remove && !cs[idx].remove {
-			c.processor.db.Logger.Warn(context.Background(), "duplicated callback `%s` from %s\n", c.name, utils.FileWithLineNum())
-		}
 		names = append(names, c.name)
 	}
 
@@ -362,15 +536,83 @@ func sortCallbacks(cs []*callback) (fns []func(*DB), err error) {
 		}
 	}
 
+	if sorted, err = sortLabeledCallbacks(cs, names, sorted); err != nil {
+		return
+	}
+
 	for _, name := range sorted {
 		if idx := getRIndex(names, name); !cs[idx].remove {
-			fns = append(fns, cs[idx].handler)
+			callbacks = append(callbacks, cs[idx])
 		}
 	}
 
 	return
 }
 
+// sortLabeledCallbacks moves the callbacks referencing labels in before or after next to the callbacks having the labels,
+// moving a callback might break the before or after declared by others, so all declared names and labels are checked again
+// until none of them is broken
+func sortLabeledCallbacks(cs []*callback, names, sorted []string) ([]string, error) {
+	var hasLabelRef bool
+	for _, c := range cs {
+		hasLabelRef = hasLabelRef || strings.HasPrefix(c.declared[0], callbackLabelPrefix) || strings.HasPrefix(c.declared[1], callbackLabelPrefix)
+	}
+	if !hasLabelRef {
+		return sorted, nil
+	}
+
+	// referenced returns the first and last sorted index of the callbacks referenced by the name or label
+	referenced := func(ref, exclude string) (first, last int) {
+		first, last = -1, -1
+		if ref == "" || ref == "*" {
+			return
+		}
+
+		label := strings.TrimPrefix(ref, callbackLabelPrefix)
+		for idx, name := range sorted {
+			if c := cs[getRIndex(names, name)]; name != exclude && !c.remove && ((label != ref && utils.Contains(c.labels, label)) || (label == ref && name == ref)) {
+				if first == -1 {
+					first = idx
+				}
+				last = idx
+			}
+		}
+		return
+	}
+
+	move := func(from, to int) {
+		name := sorted[from]
+		sorted = append(sorted[:from], sorted[from+1:]...)
+		sorted = append(sorted[:to], append([]string{name}, sorted[to:]...)...)
+	}
+
+	for round := 0; round <= len(sorted)*len(sorted); round++ {
+		moved := false
+		for _, name := range append([]string{}, sorted...) {
+			c := cs[getRIndex(names, name)]
+			if first, _ := referenced(c.declared[0], name); first != -1 {
+				if curIdx := getRIndex(sorted, name); curIdx > first {
+					move(curIdx, first)
+					moved = true
+				}
+			}
+
+			if _, last := referenced(c.declared[1], name); last != -1 {
+				if curIdx := getRIndex(sorted, name); curIdx < last {
+					move(curIdx, last)
+					moved = true
+				}
+			}
+		}
+
+		if !moved {
+			return sorted, nil
+		}
+	}
+
+	return nil, fmt.Errorf("conflicting callbacks %s", strings.Join(sorted, ", "))
+}
+
 // 删除回调。
 func removeCallbacks(cs []*callback, nameMap map[string]bool) []*callback {
 	callbacks := make([]*callback, 0, len(cs))
@@ -6,9 +6,9 @@ import (
 
 var (
 	createClauses = []string{"INSERT", "VALUES", "ON CONFLICT"}
-	queryClauses  = []string{"SELECT", "FROM", "WHERE", "GROUP BY", "ORDER BY", "LIMIT", "FOR"}
-	updateClauses = []string{"UPDATE", "SET", "WHERE"}
-	deleteClauses = []string{"DELETE", "FROM", "WHERE"}
+	queryClauses  = []string{"WITH", "SELECT", "FROM", "WHERE", "GROUP BY", "QUALIFY", "ORDER BY", "LIMIT", "FOR"}
+	updateClauses = []string{"WITH", "UPDATE", "SET", "WHERE"}
+	deleteClauses = []string{"WITH", "DELETE", "FROM", "WHERE"}
 )
 
 type Config struct {
@@ -38,9 +38,16 @@ func RegisterDefaultCallbacks(db *gorm.DB, config *Config) {
 		config.UpdateClauses = updateClauses
 	}
 
+	// 方言自定义的子句列表没有 WITH 时补充，避免 clause.With 被忽略。
+	config.QueryClauses = withCTEClause(config.QueryClauses)
+	config.UpdateClauses = withCTEClause(config.UpdateClauses)
+	config.DeleteClauses = withCTEClause(config.DeleteClauses)
+
 	createCallback := db.Callback().Create()
+	createCallback.Register("gorm:reject_read_only", RejectReadOnly)
 	createCallback.Match(enableTransaction).Register("gorm:begin_transaction", BeginTransaction)
 	createCallback.Register("gorm:before_create", BeforeCreate)
+	createCallback.Register("gorm:validate_field_size", ValidateFieldSize)
 	createCallback.Register("gorm:save_before_associations", SaveBeforeAssociations(true))
 	createCallback.Register("gorm:create", Create(config))
 	createCallback.Register("gorm:save_after_associations", SaveAfterAssociations(true))
@@ -55,6 +62,7 @@ func RegisterDefaultCallbacks(db *gorm.DB, config *Config) {
 	queryCallback.Clauses = config.QueryClauses
 
 	deleteCallback := db.Callback().Delete()
+	deleteCallback.Register("gorm:reject_read_only", RejectReadOnly)
 	deleteCallback.Match(enableTransaction).Register("gorm:begin_transaction", BeginTransaction)
 	deleteCallback.Register("gorm:before_delete", BeforeDelete)
 	deleteCallback.Register("gorm:delete_before_associations", DeleteBeforeAssociations)
@@ -64,9 +72,11 @@ func RegisterDefaultCallbacks(db *gorm.DB, config *Config) {
 	deleteCallback.Clauses = config.DeleteClauses
 
 	updateCallback := db.Callback().Update()
+	updateCallback.Register("gorm:reject_read_only", RejectReadOnly)
 	updateCallback.Match(enableTransaction).Register("gorm:begin_transaction", BeginTransaction)
 	updateCallback.Register("gorm:setup_reflect_value", SetupUpdateReflectValue)
 	updateCallback.Register("gorm:before_update", BeforeUpdate)
+	updateCallback.Register("gorm:validate_field_size", ValidateFieldSize)
 	updateCallback.Register("gorm:save_before_associations", SaveBeforeAssociations(false))
 	updateCallback.Register("gorm:update", Update(config))
 	updateCallback.Register("gorm:save_after_associations", SaveAfterAssociations(false))
@@ -82,3 +92,13 @@ func RegisterDefaultCallbacks(db *gorm.DB, config *Config) {
 	rawCallback.Register("gorm:raw", RawExec)
 	rawCallback.Clauses = config.QueryClauses
 }
+
+// withCTEClause 在子句列表最前面加上 WITH 子句。
+func withCTEClause(clauses []string) []string {
+	for _, name := range clauses {
+		if name == "WITH" {
+			return clauses
+		}
+	}
+	return append([]string{"WITH"}, clauses...)
+}
@@ -1,8 +1,11 @@
 package callbacks
 
 import (
+	"database/sql/driver"
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"gorm.io/gorm"
@@ -15,6 +18,10 @@ import (
 // 在创建之前执行的钩子函数。
 func BeforeCreate(db *gorm.DB) {
 	if db.Error == nil && db.Statement.Schema != nil && !db.Statement.SkipHooks && (db.Statement.Schema.BeforeSave || db.Statement.Schema.BeforeCreate) {
+		var (
+			skipped = map[int]bool{}
+			skipAll bool
+		)
 		callMethod(db, func(value interface{}, tx *gorm.DB) (called bool) {
 			if db.Statement.Schema.BeforeSave {
 				if i, ok := value.(BeforeSaveInterface); ok {
@@ -26,19 +33,53 @@ func BeforeCreate(db *gorm.DB) {
 			if db.Statement.Schema.BeforeCreate {
 				if i, ok := value.(BeforeCreateInterface); ok {
 					called = true
-					db.AddError(i.BeforeCreate(tx))
+					if err := i.BeforeCreate(tx); errors.Is(err, gorm.ErrSkipRow) {
+						if isRow := reflect.Indirect(reflect.ValueOf(value)).Kind() == reflect.Struct; isRow && db.Statement.ReflectValue.Kind() != reflect.Struct {
+							skipped[db.Statement.CurDestIndex] = true
+						} else {
+							skipAll = true
+						}
+					} else {
+						db.AddError(err)
+					}
 				}
 			}
 			return called
 		})
+
+		if db.Error == nil && (skipAll || len(skipped) > 0) {
+			excludeSkippedRows(db, skipped, skipAll)
+		}
 	}
 }
 
+// excludeSkippedRows 排除 BeforeCreate 返回 gorm.ErrSkipRow 的行，ReflectValue 被替换为指向未跳过元素的指针切片，
+// 之后的插入、主键回填、关联保存和 AfterCreate 都只处理未跳过的行；所有行都被跳过时不执行插入。
+func excludeSkippedRows(db *gorm.DB, skipped map[int]bool, skipAll bool) {
+	var (
+		reflectValue = db.Statement.ReflectValue
+		rows         = reflect.MakeSlice(reflect.SliceOf(reflect.PointerTo(db.Statement.Schema.ModelType)), 0, 0)
+	)
+
+	if !skipAll {
+		for i := 0; i < reflectValue.Len(); i++ {
+			if !skipped[i] {
+				rows = reflect.Append(rows, reflect.Indirect(reflectValue.Index(i)).Addr())
+			}
+		}
+	}
+
+	if rows.Len() == 0 {
+		db.Statement.Settings.Store("gorm:allow_empty_create", true)
+	}
+	db.Statement.ReflectValue = rows
+}
+
 // Create create hook
 // 创建钩子函数。
 func Create(config *Config) func(db *gorm.DB) {
 	// 支持返回
-	supportReturning := utils.Contains(config.CreateClauses, "RETURNING")
+	supportReturningClause := utils.Contains(config.CreateClauses, "RETURNING")
 
 	return func(db *gorm.DB) {
 		// 如果存在错误，则返回。
@@ -46,6 +87,24 @@ func Create(config *Config) func(db *gorm.DB) {
 			return
 		}
 
+		// 方言实现了 ReturningClauseSupporter 时，还需要方言支持 RETURNING；
+		// CreateClauses 不包含 RETURNING 或方言不支持时，移除手动添加的 RETURNING，使用 LastInsertId 回填主键。
+		supportReturning := supportReturningClause
+		if supporter, ok := db.Dialector.(gorm.ReturningClauseSupporter); ok && supportReturning {
+			supportReturning = supporter.SupportReturning()
+		}
+		if !supportReturning {
+			delete(db.Statement.Clauses, "RETURNING")
+		}
+
+		// 设置了 gorm:allow_empty_create 时，空切片不执行插入，也不返回错误。
+		if isEmptySlice(db.Statement.ReflectValue) {
+			if _, ok := db.Get("gorm:allow_empty_create"); ok {
+				db.RowsAffected = 0
+				return
+			}
+		}
+
 		// 如果存在模式，则添加模式。
 		if db.Statement.Schema != nil {
 			if !db.Statement.Unscoped {
@@ -54,25 +113,71 @@ func Create(config *Config) func(db *gorm.DB) {
 				}
 			}
 
-			// 如果支持返回，则添加返回。
-			if supportReturning && len(db.Statement.Schema.FieldsWithDefaultDBValue) > 0 {
+			// 如果支持返回，则添加返回，设置了 gorm:skip_returning 时跳过。
+			_, skipReturning := db.Get("gorm:skip_returning")
+			if supportReturning && !skipReturning && len(db.Statement.Schema.FieldsWithDefaultDBValue) > 0 {
 				if _, ok := db.Statement.Clauses["RETURNING"]; !ok {
-					fromColumns := make([]clause.Column, 0, len(db.Statement.Schema.FieldsWithDefaultDBValue))
+					defaultDBNames := make(map[string]bool, len(db.Statement.Schema.FieldsWithDefaultDBValue))
 					for _, field := range db.Statement.Schema.FieldsWithDefaultDBValue {
-						fromColumns = append(fromColumns, clause.Column{Name: field.DBName})
+						defaultDBNames[field.DBName] = true
+					}
+
+					// 按照 DBNames 的顺序生成返回列，保证 SQL 稳定。
+					fromColumns := make([]clause.Column, 0, len(defaultDBNames))
+					for _, dbName := range db.Statement.Schema.DBNames {
+						if defaultDBNames[dbName] {
+							fromColumns = append(fromColumns, clause.Column{Name: dbName})
+						}
 					}
 					db.Statement.AddClause(clause.Returning{Columns: fromColumns})
 				}
 			}
+
+			// 设置了 gorm:returning_relations 时，在 RETURNING 中通过子查询一并返回 belongs to 关联的字段。
+			if names, ok := db.Get("gorm:returning_relations"); ok {
+				if !supportReturning {
+					db.AddError(fmt.Errorf("%w: returning relations requires RETURNING support", gorm.ErrUnsupportedDriver))
+					return
+				}
+
+				if columns := returningRelationColumns(db, names); len(columns) > 0 {
+					if c, ok := db.Statement.Clauses["RETURNING"]; ok {
+						if returning, _ := c.Expression.(clause.Returning); len(returning.Columns) == 0 {
+							columns = append([]clause.Column{{Name: "*", Raw: true}}, columns...)
+						}
+					}
+					db.Statement.AddClause(clause.Returning{Columns: columns})
+				}
+			}
+
+			// 设置了 gorm:record_skipped_rows 时，RETURNING 需要包含冲突列，用于找出被 DO NOTHING 跳过的行。
+			if _, ok := db.Get("gorm:record_skipped_rows"); ok {
+				returningConflictColumns(db, supportReturning)
+			}
 		}
 
 		// 如果SQL长度为0，则添加SQL。
 		if db.Statement.SQL.Len() == 0 {
 			db.Statement.SQL.Grow(180)
-			db.Statement.AddClauseIfNotExists(clause.Insert{})
-			db.Statement.AddClause(ConvertToCreateValues(db.Statement))
+			if merge, ok := mergeClause(db); ok {
+				// MERGE 语句不支持通用的 RETURNING，使用执行结果。
+				delete(db.Statement.Clauses, "RETURNING")
+				db.Statement.AddClause(ConvertToMerge(db.Statement, merge))
+				db.Statement.Build("MERGE")
+			} else {
+				db.Statement.AddClauseIfNotExists(clause.Insert{})
+				resolveConflicts(db)
+				if precondition, ok := db.Get("gorm:insert_precondition"); ok {
+					// 设置了 gorm:insert_precondition 时，仅在前置条件成立时插入，RowsAffected 为 0 表示条件不成立。
+					values := ConvertToCreateValues(db.Statement)
+					castConditionalValues(db.Statement, values)
+					db.Statement.AddClause(clause.ConditionalValues{Values: values, Precondition: toPrecondition(db, precondition)})
+				} else {
+					db.Statement.AddClause(ConvertToCreateValues(db.Statement))
+				}
 
-			db.Statement.Build(db.Statement.BuildClauses...)
+				db.Statement.Build(db.Statement.BuildClauses...)
+			}
 		}
 
 		// 如果不是DryRun，则返回。
@@ -84,10 +189,16 @@ func Create(config *Config) func(db *gorm.DB) {
 		// 如果支持返回，则返回。
 		ok, mode := hasReturning(db, supportReturning)
 		if ok {
-			if c, ok := db.Statement.Clauses["ON CONFLICT"]; ok {
-				if onConflict, _ := c.Expression.(clause.OnConflict); onConflict.DoNothing {
-					mode |= gorm.ScanOnConflictDoNothing
-				}
+			onConflict, doNothing := doNothingOnConflict(db.Statement)
+			if doNothing {
+				mode |= gorm.ScanOnConflictDoNothing
+			}
+			_, recordSkipped := db.Get("gorm:record_skipped_rows")
+
+			// 设置了 gorm:ordered_returning 时，按自增主键的顺序把 RETURNING 的结果对应到输入的行，不依赖驱动返回的行顺序。
+			var orderedPrimaryField *schema.Field
+			if _, ok := db.Get("gorm:ordered_returning"); ok && !doNothing {
+				orderedPrimaryField = orderedReturningField(db)
 			}
 
 			// 执行SQL。
@@ -98,7 +209,15 @@ func Create(config *Config) func(db *gorm.DB) {
 				defer func() {
 					db.AddError(rows.Close())
 				}()
-				gorm.Scan(rows, db, mode)
+				if doNothing && recordSkipped && db.Statement.Schema != nil {
+					// 按冲突列匹配返回的行，没有返回的行被跳过，保存到 gorm:skipped_rows。
+					scanSkippedRows(rows, db, onConflict)
+				} else {
+					gorm.Scan(rows, db, mode)
+					if orderedPrimaryField != nil && db.Error == nil {
+						reorderReturningRows(db, orderedPrimaryField)
+					}
+				}
 
 				if db.Statement.Result != nil {
 					db.Statement.Result.RowsAffected = db.RowsAffected
@@ -150,11 +269,14 @@ func Create(config *Config) func(db *gorm.DB) {
 
 		// 如果存在模式，则添加模式。
 		if db.Statement.Schema != nil {
-			if db.Statement.Schema.PrioritizedPrimaryField == nil || !db.Statement.Schema.PrioritizedPrimaryField.HasDefaultValue {
+			if pkField = autoIncrementPrimaryField(db.Statement.Schema); pkField == nil || !pkField.HasDefaultValue {
+				return
+			}
+			// sqlite 只有单个 INTEGER PRIMARY KEY 才是 rowid 的别名，复合主键或 WITHOUT ROWID 表的插入ID不是主键值。
+			if db.Dialector.Name() == "sqlite" && (len(db.Statement.Schema.PrimaryFields) > 1 || isWithoutRowID(db)) {
 				return
 			}
-			pkField = db.Statement.Schema.PrioritizedPrimaryField
-			pkFieldName = db.Statement.Schema.PrioritizedPrimaryField.DBName
+			pkFieldName = pkField.DBName
 		}
 
 		// append @id column with value for auto-increment primary key
@@ -229,6 +351,126 @@ func Create(config *Config) func(db *gorm.DB) {
 	}
 }
 
+// autoIncrementPrimaryField returns the primary field back-filled with the last insert id, for composite primary keys,
+// only the auto-increment member is back-filled, the member with `autoIncrement` tag is preferred to the implicit one
+func autoIncrementPrimaryField(sch *schema.Schema) *schema.Field {
+	if len(sch.PrimaryFields) <= 1 {
+		return sch.PrioritizedPrimaryField
+	}
+
+	var implicit *schema.Field
+	for _, field := range sch.PrimaryFields {
+		if !field.AutoIncrement {
+			continue
+		}
+
+		if utils.CheckTruth(field.TagSettings["AUTOINCREMENT"]) {
+			return field
+		} else if implicit == nil {
+			implicit = field
+		}
+	}
+	return implicit
+}
+
+// orderedReturningField returns the auto-increment primary field used to map the RETURNING rows back in insertion order,
+// the primary values must be generated by the database for all inserting rows, as the generated values increase with the
+// order of the inserting rows
+func orderedReturningField(db *gorm.DB) *schema.Field {
+	reflectValue := db.Statement.ReflectValue
+	if db.Statement.Schema == nil || (reflectValue.Kind() != reflect.Slice && reflectValue.Kind() != reflect.Array) || reflectValue.Len() < 2 {
+		return nil
+	}
+
+	pkField := autoIncrementPrimaryField(db.Statement.Schema)
+	if pkField == nil || !pkField.HasDefaultValue {
+		return nil
+	}
+
+	switch pkField.IndirectFieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return nil
+	}
+
+	for i := 0; i < reflectValue.Len(); i++ {
+		if _, isZero := pkField.ValueOf(db.Statement.Context, reflect.Indirect(reflectValue.Index(i))); !isZero {
+			return nil
+		}
+	}
+	return pkField
+}
+
+// reorderReturningRows sort the scanned RETURNING rows by the primary values, and assign the returned fields of
+// the n-th smallest primary value to the n-th inserting row
+func reorderReturningRows(db *gorm.DB, pkField *schema.Field) {
+	var (
+		ctx          = db.Statement.Context
+		reflectValue = db.Statement.ReflectValue
+		fields       = returningFields(db.Statement)
+		rows         = make([]reflect.Value, reflectValue.Len())
+		returned     = make([][]interface{}, len(rows))
+		keys         = make([]uint64, len(rows))
+		order        = make([]int, len(rows))
+	)
+
+	for i := range rows {
+		rows[i] = reflect.Indirect(reflectValue.Index(i))
+		order[i] = i
+
+		pv := reflect.Indirect(pkField.ReflectValueOf(ctx, rows[i]))
+		switch pv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			keys[i] = uint64(pv.Int())
+		default:
+			keys[i] = pv.Uint()
+		}
+
+		returned[i] = make([]interface{}, len(fields))
+		for idx, field := range fields {
+			returned[i][idx] = field.ReflectValueOf(ctx, rows[i]).Interface()
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool { return keys[order[i]] < keys[order[j]] })
+	for i, idx := range order {
+		if i == idx {
+			continue
+		}
+		for fieldIdx, field := range fields {
+			db.AddError(field.Set(ctx, rows[i], returned[idx][fieldIdx]))
+		}
+	}
+}
+
+// returningFields returns the fields of the RETURNING columns, all fields are returned for `RETURNING *`
+func returningFields(stmt *gorm.Statement) []*schema.Field {
+	if c, ok := stmt.Clauses["RETURNING"]; ok {
+		if returning, _ := c.Expression.(clause.Returning); len(returning.Columns) > 0 {
+			fields := make([]*schema.Field, 0, len(returning.Columns))
+			for _, column := range returning.Columns {
+				if column.Name == "*" {
+					return schemaDBFields(stmt.Schema)
+				}
+				if field := stmt.Schema.LookUpField(column.Name); field != nil {
+					fields = append(fields, field)
+				}
+			}
+			return fields
+		}
+	}
+	return schemaDBFields(stmt.Schema)
+}
+
+func schemaDBFields(sch *schema.Schema) []*schema.Field {
+	fields := make([]*schema.Field, 0, len(sch.DBNames))
+	for _, dbName := range sch.DBNames {
+		fields = append(fields, sch.FieldsByDBName[dbName])
+	}
+	return fields
+}
+
 // AfterCreate after create hooks
 func AfterCreate(db *gorm.DB) {
 	if db.Error == nil && db.Statement.Schema != nil && !db.Statement.SkipHooks && (db.Statement.Schema.AfterSave || db.Statement.Schema.AfterCreate) {
@@ -275,13 +517,15 @@ func ConvertToCreateValues(stmt *gorm.Statement) (values clause.Values) {
 		values = clause.Values{Columns: make([]clause.Column, 0, len(stmt.Schema.DBNames))}
 
 		for _, db := range stmt.Schema.DBNames {
-			if field := stmt.Schema.FieldsByDBName[db]; !field.HasDefaultValue || field.DefaultValueInterface != nil {
-				if v, ok := selectColumns[db]; (ok && v) || (!ok && (!restricted || field.AutoCreateTime > 0 || field.AutoUpdateTime > 0)) {
+			if field := stmt.Schema.FieldsByDBName[db]; !field.HasDefaultValue || field.DefaultValueInterface != nil || field.DefaultValueFunc != nil {
+				if v, ok := selectColumns[db]; (ok && v) || (!ok && !restricted) {
 					values.Columns = append(values.Columns, clause.Column{Name: db})
 				}
 			}
 		}
 
+		// zero values use the default value, sql.Null* with Valid false is zero value, so it's treated as unset,
+		// use a pointer like *sql.NullString{Valid: false} to insert NULL explicitly
 		switch stmt.ReflectValue.Kind() {
 		case reflect.Slice, reflect.Array:
 			rValLen := stmt.ReflectValue.Len()
@@ -302,13 +546,19 @@ func ConvertToCreateValues(stmt *gorm.Statement) (values clause.Values) {
 					return
 				}
 
+				deriveFieldValues(stmt, stmt.Schema, rv, false)
 				values.Values[i] = make([]interface{}, len(values.Columns))
 				for idx, column := range values.Columns {
 					field := stmt.Schema.FieldsByDBName[column.Name]
 					if values.Values[i][idx], isZero = field.ValueOf(stmt.Context, rv); isZero {
-						if field.DefaultValueInterface != nil {
+						if field.DefaultExpr != "" {
+							values.Values[i][idx] = defaultExprOf(stmt, field, rv)
+						} else if field.DefaultValueInterface != nil {
 							values.Values[i][idx] = field.DefaultValueInterface
 							stmt.AddError(field.Set(stmt.Context, rv, field.DefaultValueInterface))
+						} else if field.DefaultValueFunc != nil {
+							stmt.AddError(field.Set(stmt.Context, rv, field.DefaultValueFunc(stmt.Context)))
+							values.Values[i][idx], _ = field.ValueOf(stmt.Context, rv)
 						} else if field.AutoCreateTime > 0 || field.AutoUpdateTime > 0 {
 							stmt.AddError(field.Set(stmt.Context, rv, curTime))
 							values.Values[i][idx], _ = field.ValueOf(stmt.Context, rv)
@@ -320,6 +570,10 @@ func ConvertToCreateValues(stmt *gorm.Statement) (values clause.Values) {
 				}
 
 				for _, field := range stmt.Schema.FieldsWithDefaultDBValue {
+					if field.DefaultExpr != "" {
+						continue
+					}
+
 					if v, ok := selectColumns[field.DBName]; (ok && v) || (!ok && !restricted) {
 						if rvOfvalue, isZero := field.ValueOf(stmt.Context, rv); !isZero {
 							if len(defaultValueFieldsHavingValue[field]) == 0 {
@@ -344,13 +598,19 @@ func ConvertToCreateValues(stmt *gorm.Statement) (values clause.Values) {
 				}
 			}
 		case reflect.Struct:
+			deriveFieldValues(stmt, stmt.Schema, stmt.ReflectValue, false)
 			values.Values = [][]interface{}{make([]interface{}, len(values.Columns))}
 			for idx, column := range values.Columns {
 				field := stmt.Schema.FieldsByDBName[column.Name]
 				if values.Values[0][idx], isZero = field.ValueOf(stmt.Context, stmt.ReflectValue); isZero {
-					if field.DefaultValueInterface != nil {
+					if field.DefaultExpr != "" {
+						values.Values[0][idx] = defaultExprOf(stmt, field, stmt.ReflectValue)
+					} else if field.DefaultValueInterface != nil {
 						values.Values[0][idx] = field.DefaultValueInterface
 						stmt.AddError(field.Set(stmt.Context, stmt.ReflectValue, field.DefaultValueInterface))
+					} else if field.DefaultValueFunc != nil {
+						stmt.AddError(field.Set(stmt.Context, stmt.ReflectValue, field.DefaultValueFunc(stmt.Context)))
+						values.Values[0][idx], _ = field.ValueOf(stmt.Context, stmt.ReflectValue)
 					} else if field.AutoCreateTime > 0 || field.AutoUpdateTime > 0 {
 						stmt.AddError(field.Set(stmt.Context, stmt.ReflectValue, curTime))
 						values.Values[0][idx], _ = field.ValueOf(stmt.Context, stmt.ReflectValue)
@@ -362,7 +622,7 @@ func ConvertToCreateValues(stmt *gorm.Statement) (values clause.Values) {
 			}
 
 			for _, field := range stmt.Schema.FieldsWithDefaultDBValue {
-				if v, ok := selectColumns[field.DBName]; (ok && v) || (!ok && !restricted) && field.DefaultValueInterface == nil {
+				if v, ok := selectColumns[field.DBName]; ((ok && v) || (!ok && !restricted) && field.DefaultValueInterface == nil) && field.DefaultExpr == "" {
 					if rvOfvalue, isZero := field.ValueOf(stmt.Context, stmt.ReflectValue); !isZero {
 						values.Columns = append(values.Columns, clause.Column{Name: field.DBName})
 						values.Values[0] = append(values.Values[0], rvOfvalue)
@@ -372,15 +632,37 @@ func ConvertToCreateValues(stmt *gorm.Statement) (values clause.Values) {
 		default:
 			stmt.AddError(gorm.ErrInvalidData)
 		}
+
+		if stmt.Error == nil {
+			transformCreateValues(stmt, values)
+		}
 	}
 
 	if c, ok := stmt.Clauses["ON CONFLICT"]; ok {
-		if onConflict, _ := c.Expression.(clause.OnConflict); onConflict.UpdateAll {
+		if onConflict, _ := c.Expression.(clause.OnConflict); onConflict.UpdateAll || onConflict.UpdateProvided {
 			if stmt.Schema != nil && len(values.Columns) >= 1 {
 				selectColumns, restricted := stmt.SelectAndOmitColumns(true, true)
 
+				var provided map[string]bool
+				if onConflict.UpdateProvided && !onConflict.UpdateAll {
+					provided = providedColumns(stmt, values)
+				}
+
+				omitted := make(map[string]bool, len(onConflict.Omit))
+				for _, name := range onConflict.Omit {
+					if field := stmt.Schema.LookUpField(name); field != nil {
+						omitted[field.DBName] = true
+					} else {
+						omitted[name] = true
+					}
+				}
+
 				columns := make([]string, 0, len(values.Columns)-1)
 				for _, column := range values.Columns {
+					if (provided != nil && !provided[column.Name]) || omitted[column.Name] {
+						continue
+					}
+
 					if field := stmt.Schema.LookUpField(column.Name); field != nil {
 						if v, ok := selectColumns[field.DBName]; (ok && v) || (!ok && !restricted) {
 							if !field.PrimaryKey && (!field.HasDefaultValue || field.DefaultValueInterface != nil ||
@@ -405,7 +687,15 @@ func ConvertToCreateValues(stmt *gorm.Statement) (values clause.Values) {
 					}
 				}
 
-				onConflict.DoUpdates = append(onConflict.DoUpdates, clause.AssignmentColumns(columns)...)
+				updates := clause.AssignmentColumns(columns)
+				if onConflict.KeepExistingOnNull {
+					for idx, column := range columns {
+						if field := stmt.Schema.LookUpField(column); field != nil && !field.NotNull {
+							updates[idx] = clause.AssignmentKeepOnNull(column)
+						}
+					}
+				}
+				onConflict.DoUpdates = append(onConflict.DoUpdates, updates...)
 				if len(onConflict.DoUpdates) == 0 {
 					onConflict.DoNothing = true
 				}
@@ -421,5 +711,643 @@ func ConvertToCreateValues(stmt *gorm.Statement) (values clause.Values) {
 		}
 	}
 
+	castNullValues(stmt, values)
 	return values
 }
+
+// castNullValues cast the columns having only NULL values to the data type of the field, as the type of the
+// parameters can't be inferred from NULL, e.g: `could not determine data type of parameter` on postgres, it's
+// enabled for bulk inserts of dialectors implementing gorm.TypeCastDialectorInterface and postgres, or enabled
+// with `gorm:cast_null_values`
+func castNullValues(stmt *gorm.Statement, values clause.Values) {
+	if stmt.Schema == nil || stmt.Dialector == nil || len(values.Values) == 0 {
+		return
+	}
+
+	caster, isCaster := stmt.Dialector.(gorm.TypeCastDialectorInterface)
+	isPostgres := !isCaster && stmt.Dialector.Name() == "postgres"
+	if _, ok := stmt.Get("gorm:cast_null_values"); !ok && !((isCaster || isPostgres) && len(values.Values) > 1) {
+		return
+	}
+
+	for idx, column := range values.Columns {
+		field := stmt.Schema.LookUpField(column.Name)
+		if field == nil || field.AutoIncrement {
+			continue
+		}
+
+		allNull := true
+		for _, row := range values.Values {
+			if !isNullValue(row[idx]) {
+				allNull = false
+				break
+			}
+		}
+
+		dataType := stmt.Dialector.DataTypeOf(field)
+		if !allNull || dataType == "" {
+			continue
+		}
+
+		cast := clause.Expr{SQL: "CAST(NULL AS " + dataType + ")"}
+		if isCaster {
+			cast.SQL = caster.CastTo("NULL", dataType)
+		} else if isPostgres {
+			cast.SQL = "NULL::" + dataType
+		}
+		for _, row := range values.Values {
+			row[idx] = cast
+		}
+	}
+}
+
+// castConditionalValues cast the values of conditional batch inserts to the data type of the field for dialectors
+// implementing gorm.TypeCastDialectorInterface and postgres, the rows are selected with UNION ALL, whose untyped
+// parameters are resolved as text instead of the column type
+func castConditionalValues(stmt *gorm.Statement, values clause.Values) {
+	if stmt.Schema == nil || stmt.Dialector == nil || len(values.Values) < 2 {
+		return
+	}
+
+	caster, ok := stmt.Dialector.(gorm.TypeCastDialectorInterface)
+	if !ok && stmt.Dialector.Name() != "postgres" {
+		return
+	}
+
+	for idx, column := range values.Columns {
+		field := stmt.Schema.LookUpField(column.Name)
+		if field == nil {
+			continue
+		}
+
+		// serial types can't be used in casts, use the data type of the underlying integer
+		typeField := *field
+		typeField.AutoIncrement = false
+		dataType := stmt.Dialector.DataTypeOf(&typeField)
+		if dataType == "" {
+			continue
+		}
+
+		cast := "?::" + dataType
+		if caster != nil {
+			cast = caster.CastTo("?", dataType)
+		}
+		for _, row := range values.Values {
+			if _, ok := row[idx].(clause.Expression); !ok {
+				row[idx] = clause.Expr{SQL: cast, Vars: []interface{}{row[idx]}}
+			}
+		}
+	}
+}
+
+// isNullValue whether the value is inserted as NULL
+func isNullValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return true
+	}
+
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		return err == nil && v == nil
+	}
+	return false
+}
+
+var valueTransformerType = reflect.TypeOf((*ValueTransformer)(nil)).Elem()
+
+// transformCreateValues rewrite the values with ValueTransformer if the model implements it
+func transformCreateValues(stmt *gorm.Statement, values clause.Values) {
+	if !reflect.PointerTo(stmt.Schema.ModelType).Implements(valueTransformerType) {
+		return
+	}
+
+	rows := []reflect.Value{stmt.ReflectValue}
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		rows = make([]reflect.Value, stmt.ReflectValue.Len())
+		for i := range rows {
+			rows[i] = reflect.Indirect(stmt.ReflectValue.Index(i))
+		}
+	}
+
+	for i, rv := range rows {
+		var transformer ValueTransformer
+		if rv.CanAddr() {
+			transformer, _ = rv.Addr().Interface().(ValueTransformer)
+		} else if rv.CanInterface() {
+			transformer, _ = rv.Interface().(ValueTransformer)
+		}
+
+		if transformer == nil || i >= len(values.Values) {
+			continue
+		}
+
+		for idx, column := range values.Columns {
+			if _, ok := values.Values[i][idx].(clause.Expression); ok {
+				continue
+			}
+
+			value, err := transformer.TransformCreateValue(stmt.Context, stmt.Schema.FieldsByDBName[column.Name], values.Values[i][idx])
+			if err != nil {
+				stmt.AddError(err)
+				return
+			}
+			values.Values[i][idx] = value
+		}
+	}
+}
+
+// resolveConflicts query the existing conflicting rows, and call OnConflict.Resolve to merge them into the incoming rows
+func resolveConflicts(db *gorm.DB) {
+	c, ok := db.Statement.Clauses["ON CONFLICT"]
+	if !ok || db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+
+	onConflict, _ := c.Expression.(clause.OnConflict)
+	if onConflict.Resolve == nil {
+		return
+	}
+
+	var (
+		sch        = db.Statement.Schema
+		conflicts  []*schema.Field
+		dbNames    = make([]string, 0, len(onConflict.Columns))
+		incomings  = map[string][]reflect.Value{}
+		keyValues  [][]interface{}
+		reflectKey = func(rv reflect.Value) (string, []interface{}, bool) {
+			values := make([]interface{}, len(conflicts))
+			for idx, field := range conflicts {
+				v, isZero := field.ValueOf(db.Statement.Context, rv)
+				if isZero {
+					return "", nil, false
+				}
+				values[idx] = v
+			}
+			return utils.ToStringKey(values...), values, true
+		}
+	)
+
+	conflicts = conflictFields(sch, onConflict)
+	if len(conflicts) == 0 {
+		db.AddError(fmt.Errorf("%w: conflict columns are required to resolve conflicts", gorm.ErrInvalidData))
+		return
+	}
+
+	for _, field := range conflicts {
+		dbNames = append(dbNames, field.DBName)
+	}
+
+	appendIncoming := func(rv reflect.Value) {
+		if key, values, ok := reflectKey(rv); ok {
+			if _, exists := incomings[key]; !exists {
+				keyValues = append(keyValues, values)
+			}
+			incomings[key] = append(incomings[key], rv)
+		}
+	}
+
+	switch db.Statement.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < db.Statement.ReflectValue.Len(); i++ {
+			if rv := reflect.Indirect(db.Statement.ReflectValue.Index(i)); rv.Kind() == reflect.Struct {
+				appendIncoming(rv)
+			}
+		}
+	case reflect.Struct:
+		appendIncoming(db.Statement.ReflectValue)
+	default:
+		return
+	}
+
+	if len(keyValues) > 0 {
+		column, values := schema.ToQueryValues(clause.CurrentTable, dbNames, keyValues)
+		existings := reflect.New(reflect.SliceOf(sch.ModelType))
+		if err := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Unscoped().Table(db.Statement.Table).
+			Where(clause.IN{Column: column, Values: values}).Find(existings.Interface()).Error; db.AddError(err) != nil {
+			return
+		}
+
+		existings = existings.Elem()
+		for i := 0; i < existings.Len(); i++ {
+			existing := existings.Index(i)
+			if key, _, ok := reflectKey(existing); ok {
+				for _, incoming := range incomings[key] {
+					if !incoming.CanAddr() {
+						db.AddError(fmt.Errorf("%w: value should be addressable to resolve conflicts", gorm.ErrInvalidValue))
+						return
+					}
+
+					if db.AddError(onConflict.Resolve(incoming.Addr().Interface(), existing.Addr().Interface())) != nil {
+						return
+					}
+				}
+			}
+		}
+	}
+
+	// write the merged rows
+	if len(onConflict.DoUpdates) == 0 && !onConflict.DoNothing {
+		onConflict.UpdateAll = true
+	}
+	db.Statement.AddClause(onConflict)
+}
+
+// conflictFields returns the fields of the conflict target, primary fields are used if no columns specified
+func conflictFields(sch *schema.Schema, onConflict clause.OnConflict) []*schema.Field {
+	if len(onConflict.Columns) == 0 {
+		return sch.PrimaryFields
+	}
+
+	fields := make([]*schema.Field, 0, len(onConflict.Columns))
+	for _, column := range onConflict.Columns {
+		if field := sch.LookUpField(column.Name); field != nil {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// doNothingOnConflict returns the ON CONFLICT clause if it skips the conflicting rows
+func doNothingOnConflict(stmt *gorm.Statement) (clause.OnConflict, bool) {
+	if c, ok := stmt.Clauses["ON CONFLICT"]; ok {
+		if onConflict, _ := c.Expression.(clause.OnConflict); onConflict.DoNothing {
+			return onConflict, true
+		}
+	}
+	return clause.OnConflict{}, false
+}
+
+// returningConflictColumns make sure the conflict columns are returned, which are used to find out the skipped rows
+func returningConflictColumns(db *gorm.DB, supportReturning bool) {
+	onConflict, ok := doNothingOnConflict(db.Statement)
+	if !ok {
+		return
+	}
+
+	if !supportReturning {
+		db.AddError(fmt.Errorf("%w: recording skipped rows requires RETURNING support", gorm.ErrUnsupportedDriver))
+		return
+	}
+
+	conflicts := conflictFields(db.Statement.Schema, onConflict)
+	if len(conflicts) == 0 {
+		db.AddError(fmt.Errorf("%w: conflict columns are required to record skipped rows", gorm.ErrInvalidData))
+		return
+	}
+
+	returned := map[string]bool{}
+	if c, ok := db.Statement.Clauses["RETURNING"]; ok {
+		returning, _ := c.Expression.(clause.Returning)
+		for _, column := range returning.Columns {
+			if column.Name == "*" {
+				return
+			}
+			returned[column.Name] = true
+		}
+		if len(returning.Columns) == 0 {
+			return
+		}
+	}
+
+	columns := make([]clause.Column, 0, len(conflicts))
+	for _, field := range conflicts {
+		if !returned[field.DBName] {
+			columns = append(columns, clause.Column{Name: field.DBName})
+		}
+	}
+	if len(columns) > 0 {
+		db.Statement.AddClause(clause.Returning{Columns: columns})
+	}
+}
+
+// scanSkippedRows scan the rows returned by ON CONFLICT DO NOTHING, the returned rows are matched with the
+// inserting rows by the conflict columns, the inserting rows without returned row were skipped, and stored
+// to `gorm:skipped_rows` as a slice of the inserting element type
+func scanSkippedRows(rows gorm.Rows, db *gorm.DB, onConflict clause.OnConflict) {
+	var (
+		stmt         = db.Statement
+		sch          = stmt.Schema
+		conflicts    = conflictFields(sch, onConflict)
+		reflectValue = stmt.ReflectValue
+		columns, _   = rows.Columns()
+		returned     = reflect.New(reflect.SliceOf(sch.ModelType)).Elem()
+		keyOf        = func(rv reflect.Value) string {
+			values := make([]interface{}, len(conflicts))
+			for idx, field := range conflicts {
+				values[idx], _ = field.ValueOf(stmt.Context, rv)
+			}
+			return utils.ToStringKey(values...)
+		}
+	)
+
+	// scan into new values, the returned rows might be less than the inserting rows
+	dest := stmt.Dest
+	stmt.Dest, stmt.ReflectValue = returned.Addr().Interface(), returned
+	gorm.Scan(rows, db, 0)
+	stmt.Dest, stmt.ReflectValue = dest, reflectValue
+	if db.Error != nil {
+		return
+	}
+
+	lookUpField := sch.LookUpField
+	if folder, ok := db.Dialector.(gorm.IdentifierFolder); ok {
+		lookUpField = func(name string) *schema.Field {
+			return sch.LookUpFieldFold(name, folder.FoldIdentifier)
+		}
+	}
+
+	fields := make([]*schema.Field, 0, len(columns))
+	for _, column := range columns {
+		if mapping, ok := stmt.ColumnMapping[column]; ok {
+			column = mapping
+		}
+		if field := lookUpField(column); field != nil && field.Readable {
+			fields = append(fields, field)
+		}
+	}
+
+	returnedRows := make(map[string][]reflect.Value, returned.Len())
+	for i := 0; i < returned.Len(); i++ {
+		key := keyOf(returned.Index(i))
+		returnedRows[key] = append(returnedRows[key], returned.Index(i))
+	}
+
+	var skipped reflect.Value
+	matchRow := func(elem reflect.Value) {
+		rv := reflect.Indirect(elem)
+		key := keyOf(rv)
+		if matched := returnedRows[key]; len(matched) > 0 {
+			returnedRows[key] = matched[1:]
+			if rv.CanAddr() {
+				for _, field := range fields {
+					value, _ := field.ValueOf(stmt.Context, matched[0])
+					db.AddError(field.Set(stmt.Context, rv, value))
+				}
+			}
+			return
+		}
+		skipped = reflect.Append(skipped, elem)
+	}
+
+	switch reflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		skipped = reflect.MakeSlice(reflect.SliceOf(reflectValue.Type().Elem()), 0, 0)
+		for i := 0; i < reflectValue.Len(); i++ {
+			if elem := reflectValue.Index(i); reflect.Indirect(elem).Kind() == reflect.Struct {
+				matchRow(elem)
+			}
+		}
+	case reflect.Struct:
+		skipped = reflect.MakeSlice(reflect.SliceOf(reflectValue.Type()), 0, 0)
+		matchRow(reflectValue)
+	default:
+		db.AddError(fmt.Errorf("%w: recording skipped rows requires struct values", gorm.ErrInvalidValue))
+		return
+	}
+
+	stmt.Settings.Store("gorm:skipped_rows", skipped.Interface())
+}
+
+// providedColumns returns columns that have non-zero value in any of the rows,
+// all columns of maps are treated as provided
+func providedColumns(stmt *gorm.Statement, values clause.Values) map[string]bool {
+	provided := make(map[string]bool, len(values.Columns))
+	switch stmt.Dest.(type) {
+	case map[string]interface{}, *map[string]interface{}, []map[string]interface{}, *[]map[string]interface{}:
+		for _, column := range values.Columns {
+			provided[column.Name] = true
+		}
+		return provided
+	}
+
+	for idx, column := range values.Columns {
+		for _, row := range values.Values {
+			if idx < len(row) && row[idx] != nil {
+				if rv := reflect.ValueOf(row[idx]); !rv.IsZero() {
+					provided[column.Name] = true
+					break
+				}
+			}
+		}
+	}
+	return provided
+}
+
+// supportMergeDoNothing whether the DO NOTHING action of MERGE is supported, sqlserver and oracle have no DO NOTHING
+// action, the rows are left as is if no WHEN branch matched
+func supportMergeDoNothing(dialector gorm.Dialector) bool {
+	if d, ok := dialector.(gorm.MergeDoNothingDialectorInterface); ok {
+		return d.SupportMergeDoNothing()
+	}
+	name := dialector.Name()
+	return name != "sqlserver" && name != "oracle"
+}
+
+// mergeClause returns the MERGE clause if the dialector supports it,
+// otherwise the MERGE clause will be converted to ON CONFLICT
+func mergeClause(db *gorm.DB) (clause.Merge, bool) {
+	c, ok := db.Statement.Clauses["MERGE"]
+	if !ok {
+		return clause.Merge{}, false
+	}
+
+	merge, ok := c.Expression.(clause.Merge)
+	if !ok {
+		return clause.Merge{}, false
+	}
+
+	if dialector, ok := db.Dialector.(gorm.MergeDialectorInterface); ok && dialector.SupportMerge() {
+		if !supportMergeDoNothing(db.Dialector) {
+			for _, when := range merge.When {
+				if when.DoNothing {
+					db.AddError(fmt.Errorf("%w: MERGE with DO NOTHING action on %s", gorm.ErrUnsupportedDriver, db.Dialector.Name()))
+					break
+				}
+			}
+		}
+		return merge, true
+	}
+
+	delete(db.Statement.Clauses, "MERGE")
+	onConflict := clause.OnConflict{Columns: merge.On, DoNothing: true}
+	for _, when := range merge.When {
+		if !when.Matched || when.DoNothing {
+			continue
+		}
+
+		if when.Delete {
+			db.AddError(fmt.Errorf("%w: MERGE with DELETE action", gorm.ErrUnsupportedDriver))
+			break
+		}
+
+		onConflict.DoNothing = false
+		onConflict.Where = clause.Where{Exprs: make([]clause.Expression, len(when.Where.Exprs))}
+		for idx, expr := range when.Where.Exprs {
+			onConflict.Where.Exprs[idx] = toExcludedExpression(expr, merge.SourceAlias())
+		}
+		if len(when.DoUpdates) > 0 {
+			onConflict.DoUpdates = make(clause.Set, len(when.DoUpdates))
+			for idx, assignment := range when.DoUpdates {
+				onConflict.DoUpdates[idx] = clause.Assignment{Column: assignment.Column, Value: toExcludedValue(assignment.Value, merge.SourceAlias())}
+			}
+		} else {
+			onConflict.UpdateAll = true
+		}
+		break
+	}
+
+	if len(merge.When) == 0 {
+		onConflict.DoNothing = false
+		onConflict.UpdateAll = true
+	}
+
+	if _, ok := db.Statement.Clauses["ON CONFLICT"]; !ok {
+		db.Statement.AddClause(onConflict)
+	}
+	return clause.Merge{}, false
+}
+
+// toExcludedValue rewrite the column referencing the MERGE source alias to `excluded` of ON CONFLICT
+func toExcludedValue(value interface{}, alias string) interface{} {
+	if column, ok := value.(clause.Column); ok && column.Table == alias {
+		column.Table = "excluded"
+		return column
+	}
+	return value
+}
+
+// toExcludedExpression rewrite the columns of the expression referencing the MERGE source alias to `excluded`
+func toExcludedExpression(expr clause.Expression, alias string) clause.Expression {
+	if e, ok := expr.(clause.Expr); ok {
+		vars := make([]interface{}, len(e.Vars))
+		for idx, v := range e.Vars {
+			vars[idx] = toExcludedValue(v, alias)
+		}
+		e.Vars = vars
+		return e
+	}
+	return expr
+}
+
+// ConvertToMerge convert to merge clause, use the create values as the source rows
+func ConvertToMerge(stmt *gorm.Statement, merge clause.Merge) clause.Merge {
+	values := ConvertToCreateValues(stmt)
+	if stmt.Error != nil {
+		return merge
+	}
+
+	if merge.Using == nil {
+		merge.Values = values
+	}
+
+	if len(merge.On) == 0 && stmt.Schema != nil {
+		for _, field := range stmt.Schema.PrimaryFields {
+			merge.On = append(merge.On, clause.Column{Name: field.DBName})
+		}
+	}
+
+	if len(merge.When) == 0 {
+		alias := merge.SourceAlias()
+		var updates clause.Set
+		for _, column := range values.Columns {
+			isOnColumn := false
+			for _, on := range merge.On {
+				if on.Name == column.Name {
+					isOnColumn = true
+					break
+				}
+			}
+
+			if !isOnColumn {
+				updates = append(updates, clause.Assignment{
+					Column: clause.Column{Name: column.Name},
+					Value:  clause.Column{Table: alias, Name: column.Name},
+				})
+			}
+		}
+
+		if len(updates) > 0 {
+			merge.When = append(merge.When, clause.MergeWhen{Matched: true, DoUpdates: updates})
+		}
+		merge.When = append(merge.When, clause.MergeWhen{Columns: values.Columns})
+	}
+	return merge
+}
+
+// returningRelationColumns builds RETURNING columns for belongs to relations, E.g: "Company" returns all fields of Company,
+// "Company.Name" returns its Name only, fields are selected with sub queries and scanned back by the alias `Company__name`
+func returningRelationColumns(db *gorm.DB, names interface{}) (columns []clause.Column) {
+	var (
+		stmt      = db.Statement
+		relations []string
+		aliases   = map[string]bool{}
+	)
+
+	switch v := names.(type) {
+	case string:
+		relations = []string{v}
+	case []string:
+		relations = v
+	default:
+		db.AddError(fmt.Errorf("%w: unsupported returning relations %T", gorm.ErrInvalidData, names))
+		return nil
+	}
+
+	for _, name := range relations {
+		relName, fieldName, _ := strings.Cut(name, ".")
+		rel, ok := stmt.Schema.Relationships.Relations[relName]
+		if !ok || rel.Type != schema.BelongsTo || len(rel.References) == 0 || strings.Contains(fieldName, ".") {
+			db.AddError(fmt.Errorf("%w: returning relation %s should be a belongs to relation of %s", gorm.ErrInvalidField, name, stmt.Schema.Name))
+			return nil
+		}
+
+		fields := make([]*schema.Field, 0, len(rel.FieldSchema.Fields))
+		if fieldName != "" {
+			field := rel.FieldSchema.LookUpField(fieldName)
+			if field == nil || field.DBName == "" {
+				db.AddError(fmt.Errorf("%w: returning relation %s has no field %s", gorm.ErrInvalidField, relName, fieldName))
+				return nil
+			}
+			fields = append(fields, field)
+		} else {
+			for _, field := range rel.FieldSchema.Fields {
+				if field.DBName != "" && field.Readable {
+					fields = append(fields, field)
+				}
+			}
+		}
+
+		// the related table is aliased with the relation name, so self referencing relations are not ambiguous
+		var conds strings.Builder
+		for idx, ref := range rel.References {
+			if idx > 0 {
+				conds.WriteString(" AND ")
+			}
+			conds.WriteString(stmt.Quote(clause.Column{Table: rel.Name, Name: ref.PrimaryKey.DBName}))
+			conds.WriteString(" = ")
+			conds.WriteString(stmt.Quote(clause.Column{Table: stmt.Table, Name: ref.ForeignKey.DBName}))
+		}
+
+		for _, field := range fields {
+			alias := utils.JoinNestedRelationNames([]string{rel.Name, field.DBName})
+			if aliases[alias] {
+				db.AddError(fmt.Errorf("%w: returning relation column %s is ambiguous", gorm.ErrInvalidField, alias))
+				return nil
+			}
+			aliases[alias] = true
+
+			columns = append(columns, clause.Column{
+				Name: fmt.Sprintf("(SELECT %s FROM %s %s WHERE %s) AS %s",
+					stmt.Quote(clause.Column{Table: rel.Name, Name: field.DBName}), stmt.Quote(rel.FieldSchema.Table),
+					stmt.Quote(rel.Name), conds.String(), stmt.Quote(alias)),
+				Raw: true,
+			})
+		}
+	}
+	return columns
+}
@@ -1,11 +1,16 @@
 package callbacks
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"gorm.io/gorm/utils"
 )
 
 // ConvertMapToValuesForCreate convert map to values
@@ -150,3 +155,111 @@ func loadOrStoreVisitMap(visitMap *visitMap, v reflect.Value) (loaded bool) {
 
 	return
 }
+
+// isEmptySlice check if the value is a zero-length slice or array
+func isEmptySlice(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		return v.Len() == 0
+	}
+	return false
+}
+
+// deriveFieldValues set derived fields having zero value from their source fields
+func deriveFieldValues(stmt *gorm.Statement, s *schema.Schema, rv reflect.Value, onUpdate bool) {
+	for _, field := range s.DerivedFields {
+		if onUpdate && !field.DeriveOnUpdate {
+			continue
+		}
+
+		if _, isZero := field.ValueOf(stmt.Context, rv); isZero {
+			if source, isZero := field.DerivedFrom.ValueOf(stmt.Context, rv); !isZero {
+				value, err := field.Deriver(stmt.Context, source)
+				if stmt.AddError(err) == nil {
+					stmt.AddError(field.Set(stmt.Context, rv, value))
+				}
+			}
+		}
+	}
+}
+
+// isWithoutRowID 判断表是否为 sqlite 的 WITHOUT ROWID 表，表选项通过 gorm:table_options 设置，
+// 未设置时从 sqlite_master 中查询建表语句，查询结果按 schema 和表名缓存，每张表只查询一次。
+func isWithoutRowID(db *gorm.DB) bool {
+	if options, ok := db.Get("gorm:table_options"); ok {
+		return containsWithoutRowID(fmt.Sprint(options))
+	}
+
+	if db.Statement.Schema == nil {
+		return false
+	}
+
+	withoutRowID, _ := db.Statement.Schema.TableSetting(db.Statement.Table, "sqlite:without_rowid", func() interface{} {
+		var createSQL string
+		row := db.Statement.ConnPool.QueryRowContext(db.Statement.Context, "SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", db.Statement.Table)
+		if row == nil || row.Scan(&createSQL) != nil {
+			return false
+		}
+		return containsWithoutRowID(createSQL)
+	}).(bool)
+	return withoutRowID
+}
+
+// containsWithoutRowID 判断表选项或建表语句是否包含 WITHOUT ROWID。
+func containsWithoutRowID(options string) bool {
+	return strings.Contains(strings.Join(strings.Fields(strings.ToUpper(options)), " "), "WITHOUT ROWID")
+}
+
+// defaultExprOf 构建字段的默认值表达式，表达式中的 `@column` 引用当前插入行中该列的值，
+// 例如物化路径 `(SELECT path FROM nodes WHERE id = @parent_id) || '/' || @name`。
+func defaultExprOf(stmt *gorm.Statement, field *schema.Field, rv reflect.Value) clause.Expression {
+	if !strings.Contains(field.DefaultExpr, "@") {
+		return clause.Expr{SQL: field.DefaultExpr}
+	}
+
+	values := make(map[string]interface{}, len(stmt.Schema.DBNames))
+	for _, dbName := range stmt.Schema.DBNames {
+		if f := stmt.Schema.FieldsByDBName[dbName]; f != field {
+			values[dbName], _ = f.ValueOf(stmt.Context, rv)
+		}
+	}
+	return clause.NamedExpr{SQL: field.DefaultExpr, Vars: []interface{}{values}}
+}
+
+// isFindEach 判断当前查询是否由 FindEach 逐行处理。
+func isFindEach(db *gorm.DB) bool {
+	_, ok := db.Get("gorm:find_each")
+	return ok
+}
+
+// equalFieldValue 比较字段值是否相等，时间按时刻比较，忽略时区差异。
+func equalFieldValue(x, y interface{}) bool {
+	if t, ok := x.(*time.Time); ok && t != nil {
+		x = *t
+	}
+	if t, ok := y.(*time.Time); ok && t != nil {
+		y = *t
+	}
+
+	if xt, ok := x.(time.Time); ok {
+		if yt, ok := y.(time.Time); ok {
+			return xt.Equal(yt)
+		}
+	}
+	return utils.AssertEqual(x, y)
+}
+
+// toPrecondition 将 gorm:insert_precondition 的值转换为表达式，子查询作为 EXISTS 条件。
+func toPrecondition(db *gorm.DB, precondition interface{}) clause.Expression {
+	switch v := precondition.(type) {
+	case clause.Expression:
+		return v
+	case *gorm.DB:
+		return clause.Expr{SQL: "EXISTS(?)", Vars: []interface{}{v}}
+	case string:
+		return clause.Expr{SQL: v}
+	default:
+		db.AddError(fmt.Errorf("%w: unsupported insert precondition %T", gorm.ErrInvalidData, precondition))
+		return nil
+	}
+}
@@ -1,7 +1,14 @@
 package callbacks
 
-import "gorm.io/gorm"
+import (
+	"context"
 
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// BeforeCreateInterface BeforeCreate 返回 gorm.ErrSkipRow 时，该行不会被插入，也不会设置 db.Error；
+// 切片插入时只排除对应的元素，其余元素照常插入，RowsAffected 只统计实际插入的行数。
 type BeforeCreateInterface interface {
 	BeforeCreate(*gorm.DB) error
 }
@@ -37,3 +44,9 @@ type AfterDeleteInterface interface {
 type AfterFindInterface interface {
 	AfterFind(*gorm.DB) error
 }
+
+// ValueTransformer rewrite the values bound to INSERT right before they are placed into clause.Values, e.g: encrypt columns,
+// it's called for each column of each row, SQL expressions like `default_expr` are not passed to it
+type ValueTransformer interface {
+	TransformCreateValue(ctx context.Context, field *schema.Field, value interface{}) (interface{}, error)
+}
@@ -169,7 +169,10 @@ func preloadEntryPoint(db *gorm.DB, joins []string, relationships *schema.Relati
 func preloadDB(db *gorm.DB, reflectValue reflect.Value, dest interface{}) *gorm.DB {
 	tx := db.Session(&gorm.Session{Context: db.Statement.Context, NewDB: true, SkipHooks: db.Statement.SkipHooks, Initialized: true})
 	db.Statement.Settings.Range(func(k, v interface{}) bool {
-		tx.Statement.Settings.Store(k, v)
+		// preload queries find all related records at once
+		if k != "gorm:find_each" {
+			tx.Statement.Settings.Store(k, v)
+		}
 		return true
 	})
 
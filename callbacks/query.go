@@ -24,7 +24,11 @@ func Query(db *gorm.DB) {
 			defer func() {
 				db.AddError(rows.Close())
 			}()
-			gorm.Scan(rows, db, 0)
+			if fc, ok := db.Get("gorm:find_each"); ok {
+				findEach(db, rows, fc.(func(tx *gorm.DB) error))
+			} else {
+				gorm.Scan(rows, db, 0)
+			}
 
 			if db.Statement.Result != nil {
 				db.Statement.Result.RowsAffected = db.RowsAffected
@@ -33,6 +37,35 @@ func Query(db *gorm.DB) {
 	}
 }
 
+// findEach scan rows into the reused dest one by one, preload and call AfterFind of each record before calling fc
+func findEach(db *gorm.DB, rows gorm.Rows, fc func(tx *gorm.DB) error) {
+	if db.Statement.ReflectValue.Kind() != reflect.Struct {
+		db.AddError(gorm.ErrInvalidValue)
+		return
+	}
+
+	var (
+		count int64
+		tx    = db.Session(&gorm.Session{NewDB: true})
+	)
+
+	for db.Error == nil && rows.Next() {
+		gorm.Scan(rows, db, gorm.ScanInitialized)
+		count++
+
+		preloadAll(db)
+		afterFind(db)
+		if db.Error == nil {
+			db.AddError(fc(tx))
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		db.AddError(err)
+	}
+	db.RowsAffected = count
+}
+
 func BuildQuerySQL(db *gorm.DB) {
 	if db.Statement.Schema != nil {
 		for _, c := range db.Statement.Schema.QueryClauses {
@@ -72,7 +105,7 @@ func BuildQuerySQL(db *gorm.DB) {
 			selectColumns, _ := db.Statement.SelectAndOmitColumns(false, false)
 			clauseSelect.Columns = make([]clause.Column, 0, len(db.Statement.Schema.DBNames))
 			for _, dbName := range db.Statement.Schema.DBNames {
-				if v, ok := selectColumns[dbName]; (ok && v) || !ok {
+				if v, ok := selectColumns[dbName]; (ok && v) || (!ok && !isLazyColumn(db.Statement.Schema, dbName)) {
 					clauseSelect.Columns = append(clauseSelect.Columns, clause.Column{Table: db.Statement.Table, Name: dbName})
 				}
 			}
@@ -91,15 +124,26 @@ func BuildQuerySQL(db *gorm.DB) {
 				stmt := gorm.Statement{DB: db}
 				// smaller struct
 				if err := stmt.Parse(db.Statement.Dest); err == nil && (db.QueryFields || stmt.Schema.ModelType != db.Statement.Schema.ModelType) {
-					clauseSelect.Columns = make([]clause.Column, len(stmt.Schema.DBNames))
+					clauseSelect.Columns = make([]clause.Column, 0, len(stmt.Schema.DBNames))
 
-					for idx, dbName := range stmt.Schema.DBNames {
-						clauseSelect.Columns[idx] = clause.Column{Table: db.Statement.Table, Name: dbName}
+					for _, dbName := range stmt.Schema.DBNames {
+						if !isLazyColumn(stmt.Schema, dbName) {
+							clauseSelect.Columns = append(clauseSelect.Columns, clause.Column{Table: db.Statement.Table, Name: dbName})
+						}
 					}
 				}
 			}
 		}
 
+		// lazy columns are not selected by default, list the other columns instead of *
+		if len(clauseSelect.Columns) == 0 && len(db.Statement.Selects) == 0 && db.Statement.Schema != nil && len(db.Statement.Schema.LazyFields) > 0 {
+			for _, dbName := range db.Statement.Schema.DBNames {
+				if !isLazyColumn(db.Statement.Schema, dbName) {
+					clauseSelect.Columns = append(clauseSelect.Columns, clause.Column{Table: db.Statement.Table, Name: dbName})
+				}
+			}
+		}
+
 		// inline joins
 		fromClause := clause.From{}
 		if v, ok := db.Statement.Clauses["FROM"].Expression.(clause.From); ok {
@@ -108,9 +152,11 @@ func BuildQuerySQL(db *gorm.DB) {
 
 		if len(db.Statement.Joins) != 0 || len(fromClause.Joins) != 0 {
 			if len(db.Statement.Selects) == 0 && len(db.Statement.Omits) == 0 && db.Statement.Schema != nil {
-				clauseSelect.Columns = make([]clause.Column, len(db.Statement.Schema.DBNames))
-				for idx, dbName := range db.Statement.Schema.DBNames {
-					clauseSelect.Columns[idx] = clause.Column{Table: db.Statement.Table, Name: dbName}
+				clauseSelect.Columns = make([]clause.Column, 0, len(db.Statement.Schema.DBNames))
+				for _, dbName := range db.Statement.Schema.DBNames {
+					if !isLazyColumn(db.Statement.Schema, dbName) {
+						clauseSelect.Columns = append(clauseSelect.Columns, clause.Column{Table: db.Statement.Table, Name: dbName})
+					}
 				}
 			}
 
@@ -157,7 +203,7 @@ func BuildQuerySQL(db *gorm.DB) {
 
 							selectColumns, restricted := columnStmt.SelectAndOmitColumns(false, false)
 							for _, s := range relation.FieldSchema.DBNames {
-								if v, ok := selectColumns[s]; (ok && v) || (!ok && !restricted) {
+								if v, ok := selectColumns[s]; (ok && v) || (!ok && !restricted && !isLazyColumn(relation.FieldSchema, s)) {
 									clauseSelect.Columns = append(clauseSelect.Columns, clause.Column{
 										Table: tableAliasName,
 										Name:  s,
@@ -270,11 +316,91 @@ func BuildQuerySQL(db *gorm.DB) {
 
 		db.Statement.AddClauseIfNotExists(clauseSelect)
 
-		db.Statement.Build(db.Statement.BuildClauses...)
+		if c, ok := db.Statement.Clauses["LIMIT"]; ok {
+			if expr := rewriteLimit(db, c); expr != nil {
+				db.Statement.Clauses["LIMIT"] = clause.Clause{Expression: expr}
+				defer func() { db.Statement.Clauses["LIMIT"] = c }()
+			}
+		}
+
+		if c, ok := db.Statement.Clauses["QUALIFY"]; ok && !supportQualify(db) && utils.Contains(db.Statement.BuildClauses, "QUALIFY") {
+			buildQualifySubquery(db, c)
+		} else {
+			db.Statement.Build(db.Statement.BuildClauses...)
+		}
+	}
+}
+
+// rewriteLimit 方言实现 LimitRewriter 时，返回方言的分页表达式，没有分页或方言使用标准的 LIMIT 子句时返回 nil。
+func rewriteLimit(db *gorm.DB, c clause.Clause) clause.Expression {
+	rewriter, ok := db.Dialector.(gorm.LimitRewriter)
+	if !ok {
+		return nil
+	}
+
+	limit, ok := c.Expression.(clause.Limit)
+	if !ok {
+		return nil
+	}
+
+	var limitCount, offset *int
+	if limit.Limit != nil && *limit.Limit >= 0 {
+		limitCount = limit.Limit
+	}
+	if limit.Offset > 0 {
+		offset = &limit.Offset
+	}
+
+	if limitCount == nil && offset == nil {
+		return nil
+	}
+	return rewriter.RewriteLimit(db.Statement, limitCount, offset)
+}
+
+func supportQualify(db *gorm.DB) bool {
+	dialector, ok := db.Dialector.(gorm.QualifyDialectorInterface)
+	return ok && dialector.SupportQualify()
+}
+
+// buildQualifySubquery 方言不支持 QUALIFY 时，将查询包装为子查询，在外层 WHERE 中过滤窗口函数的结果，
+// QUALIFY 之后的 ORDER BY、LIMIT 等子句作用于外层查询，子查询使用当前表名作为别名。
+func buildQualifySubquery(db *gorm.DB, qualify clause.Clause) {
+	selectClause := db.Statement.Clauses["SELECT"]
+	defer func() {
+		db.Statement.Clauses["SELECT"] = selectClause
+		db.Statement.Clauses["QUALIFY"] = qualify
+	}()
+
+	wrappedSelect := selectClause
+	wrappedSelect.BeforeExpression = clause.Expr{SQL: "SELECT * FROM ("}
+	db.Statement.Clauses["SELECT"] = wrappedSelect
+
+	exprs, _ := qualify.Expression.(clause.Qualify)
+	db.Statement.Clauses["QUALIFY"] = clause.Clause{Expression: qualifySubquery(exprs)}
+
+	db.Statement.Build(db.Statement.BuildClauses...)
+}
+
+// qualifySubquery 关闭子查询，并把 QUALIFY 的条件写入外层 WHERE。
+type qualifySubquery clause.Qualify
+
+func (qualify qualifySubquery) Build(builder clause.Builder) {
+	builder.WriteString(") AS ")
+	builder.WriteQuoted(clause.Table{Name: clause.CurrentTable})
+	if len(qualify.Exprs) > 0 {
+		builder.WriteString(" WHERE ")
+		clause.Where{Exprs: qualify.Exprs}.Build(builder)
 	}
 }
 
 func Preload(db *gorm.DB) {
+	// records found by FindEach are preloaded one by one before calling fc
+	if !isFindEach(db) {
+		preloadAll(db)
+	}
+}
+
+func preloadAll(db *gorm.DB) {
 	if db.Error == nil && len(db.Statement.Preloads) > 0 {
 		if db.Statement.Schema == nil {
 			db.AddError(fmt.Errorf("%w when using preload", gorm.ErrModelValueRequired))
@@ -302,7 +428,14 @@ func AfterQuery(db *gorm.DB) {
 		fromClause.Expression = clause.From{Tables: v.Tables, Joins: utils.RTrimSlice(v.Joins, len(db.Statement.Joins))} // keep the original From Joins
 		db.Statement.Clauses["FROM"] = fromClause
 	}
-	if db.Error == nil && db.Statement.Schema != nil && !db.Statement.SkipHooks && db.Statement.Schema.AfterFind && db.RowsAffected > 0 {
+	// records found by FindEach are handled one by one before calling fc
+	if !isFindEach(db) && db.RowsAffected > 0 {
+		afterFind(db)
+	}
+}
+
+func afterFind(db *gorm.DB) {
+	if db.Error == nil && db.Statement.Schema != nil && !db.Statement.SkipHooks && db.Statement.Schema.AfterFind {
 		callMethod(db, func(value interface{}, tx *gorm.DB) bool {
 			if i, ok := value.(AfterFindInterface); ok {
 				db.AddError(i.AfterFind(tx))
@@ -312,3 +445,9 @@ func AfterQuery(db *gorm.DB) {
 		})
 	}
 }
+
+// isLazyColumn whether the column is not selected by default
+func isLazyColumn(sch *schema.Schema, dbName string) bool {
+	field := sch.FieldsByDBName[dbName]
+	return field != nil && field.Lazy
+}
@@ -4,6 +4,13 @@ import (
 	"gorm.io/gorm"
 )
 
+// RejectReadOnly rejects writes in the transaction begun by ReadOnlyTransaction
+func RejectReadOnly(db *gorm.DB) {
+	if _, ok := db.Get("gorm:read_only_transaction"); ok && db.Error == nil {
+		db.AddError(gorm.ErrReadOnlyTransaction)
+	}
+}
+
 func BeginTransaction(db *gorm.DB) {
 	if !db.Config.SkipDefaultTransaction && db.Error == nil {
 		if tx := db.Begin(); tx.Error == nil {
@@ -20,7 +27,7 @@ func BeginTransaction(db *gorm.DB) {
 func CommitOrRollbackTransaction(db *gorm.DB) {
 	if !db.Config.SkipDefaultTransaction {
 		if _, ok := db.InstanceGet("gorm:started_transaction"); ok {
-			if db.Error != nil {
+			if db.Error != nil && db.Error != gorm.ErrConstraintHandled {
 				db.Rollback()
 			} else {
 				db.Commit()
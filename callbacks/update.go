@@ -1,6 +1,7 @@
 package callbacks
 
 import (
+	"errors"
 	"reflect"
 	"sort"
 
@@ -85,6 +86,10 @@ func Update(config *Config) func(db *gorm.DB) {
 		checkMissingWhereConditions(db)
 
 		if !db.DryRun && db.Error == nil {
+			if v, ok := db.Get("gorm:record_field_deltas"); ok && v == true {
+				recordFieldDeltas(db)
+			}
+
 			if ok, mode := hasReturning(db, supportReturning); ok {
 				if rows, err := db.Statement.ConnPool.QueryContext(db.Statement.Context, db.Statement.SQL.String(), db.Statement.Vars...); db.AddError(err) == nil {
 					dest := db.Statement.Dest
@@ -113,6 +118,68 @@ func Update(config *Config) func(db *gorm.DB) {
 	}
 }
 
+// recordFieldDeltas loads the current row of the updating record, stores before and after values of changed fields to `gorm:field_deltas`
+func recordFieldDeltas(db *gorm.DB) {
+	stmt := db.Statement
+	stmt.Settings.Delete("gorm:field_deltas")
+
+	c, ok := stmt.Clauses["SET"]
+	set, _ := c.Expression.(clause.Set)
+	if !ok || len(set) == 0 || stmt.Schema == nil || len(stmt.Schema.PrimaryFields) == 0 || stmt.ReflectValue.Kind() != reflect.Struct {
+		return
+	}
+
+	conds := make([]clause.Expression, 0, len(stmt.Schema.PrimaryFields))
+	for _, field := range stmt.Schema.PrimaryFields {
+		value, isZero := field.ValueOf(stmt.Context, stmt.ReflectValue)
+		if isZero {
+			return
+		}
+		conds = append(conds, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: value})
+	}
+
+	fields := make([]*schema.Field, len(set))
+	columns := make([]string, 0, len(set))
+	for idx, assignment := range set {
+		if field := stmt.Schema.LookUpField(assignment.Column.Name); field != nil && field.Readable {
+			fields[idx] = field
+			columns = append(columns, field.DBName)
+		}
+	}
+
+	if len(columns) == 0 {
+		return
+	}
+
+	current := reflect.New(stmt.Schema.ModelType)
+	if err := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Unscoped().Table(stmt.Table).Select(columns).Where(clause.And(conds...)).Take(current.Interface()).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			db.AddError(err)
+		}
+		return
+	}
+
+	deltas := make([]gorm.FieldDelta, 0, len(columns))
+	for idx, assignment := range set {
+		field := fields[idx]
+		if field == nil {
+			continue
+		}
+
+		before, _ := field.ValueOf(stmt.Context, current.Elem())
+		after := assignment.Value
+		if _, ok := after.(clause.Expression); !ok && stmt.ReflectValue.CanAddr() {
+			after, _ = field.ValueOf(stmt.Context, stmt.ReflectValue)
+		}
+
+		if !equalFieldValue(before, after) {
+			deltas = append(deltas, gorm.FieldDelta{Field: field, Before: before, After: after})
+		}
+	}
+
+	stmt.Settings.Store("gorm:field_deltas", deltas)
+}
+
 // AfterUpdate after update hooks
 func AfterUpdate(db *gorm.DB) {
 	if db.Error == nil && db.Statement.Schema != nil && !db.Statement.SkipHooks && (db.Statement.Schema.AfterSave || db.Statement.Schema.AfterUpdate) {
@@ -197,7 +264,16 @@ func ConvertToAssignments(stmt *gorm.Statement) (set clause.Set) {
 		}
 	}
 
-	switch value := updatingValue.Interface().(type) {
+	updatingInterface := updatingValue.Interface()
+	if assignments, ok := updatingInterface.(clause.Set); ok {
+		values := make(map[string]interface{}, len(assignments))
+		for _, assignment := range assignments {
+			values[assignment.Column.Name] = assignment.Value
+		}
+		updatingInterface = values
+	}
+
+	switch value := updatingInterface.(type) {
 	case map[string]interface{}:
 		set = make([]clause.Assignment, 0, len(value))
 
@@ -233,6 +309,25 @@ func ConvertToAssignments(stmt *gorm.Statement) (set clause.Set) {
 		}
 
 		if !stmt.SkipHooks && stmt.Schema != nil {
+			for _, field := range stmt.Schema.DerivedFields {
+				if !field.DeriveOnUpdate || value[field.Name] != nil || value[field.DBName] != nil {
+					continue
+				}
+
+				source, ok := value[field.DerivedFrom.Name]
+				if !ok {
+					source, ok = value[field.DerivedFrom.DBName]
+				}
+
+				if v, selected := selectColumns[field.DBName]; ok && source != nil && ((selected && v) || (!selected && !restricted)) {
+					derived, err := field.Deriver(stmt.Context, source)
+					if stmt.AddError(err) == nil {
+						set = append(set, clause.Assignment{Column: clause.Column{Name: field.DBName}, Value: derived})
+						assignValue(field, derived)
+					}
+				}
+			}
+
 			for _, dbName := range stmt.Schema.DBNames {
 				field := stmt.Schema.LookUpField(dbName)
 				if field.AutoUpdateTime > 0 && value[field.Name] == nil && value[field.DBName] == nil {
@@ -267,6 +362,10 @@ func ConvertToAssignments(stmt *gorm.Statement) (set clause.Set) {
 
 		switch updatingValue.Kind() {
 		case reflect.Struct:
+			if !stmt.SkipHooks && updatingValue.CanAddr() {
+				deriveFieldValues(stmt, updatingSchema, updatingValue, true)
+			}
+
 			set = make([]clause.Assignment, 0, len(stmt.Schema.FieldsByDBName))
 			for _, dbName := range stmt.Schema.DBNames {
 				if field := updatingSchema.LookUpField(dbName); field != nil {
@@ -309,5 +408,32 @@ func ConvertToAssignments(stmt *gorm.Statement) (set clause.Set) {
 		}
 	}
 
+	// 追加字段的 onUpdate 表达式，显式通过 map 更新的字段除外。
+	if !stmt.SkipHooks && stmt.Schema != nil {
+		_, isMap := updatingValue.Interface().(map[string]interface{})
+		for _, dbName := range stmt.Schema.DBNames {
+			field := stmt.Schema.FieldsByDBName[dbName]
+			if field.OnUpdate == "" || !field.Updatable {
+				continue
+			}
+
+			if v, ok := selectColumns[field.DBName]; ok && !v {
+				continue
+			}
+
+			assignment := clause.Assignment{Column: clause.Column{Name: field.DBName}, Value: clause.Expr{SQL: field.OnUpdate}}
+			idx := 0
+			for idx < len(set) && set[idx].Column.Name != field.DBName {
+				idx++
+			}
+
+			if idx == len(set) {
+				set = append(set, assignment)
+			} else if !isMap {
+				set[idx] = assignment
+			}
+		}
+	}
+
 	return
 }
@@ -0,0 +1,101 @@
+package callbacks
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// ValidateFieldSize checks string and bytes values against the field's `size` tag, enabled with Config.ValidateFieldSize
+func ValidateFieldSize(db *gorm.DB) {
+	if db.Error != nil || !db.Config.ValidateFieldSize || db.Statement.Schema == nil {
+		return
+	}
+
+	switch value := db.Statement.Dest.(type) {
+	case map[string]interface{}:
+		validateMapFieldSize(db, value)
+	case *map[string]interface{}:
+		validateMapFieldSize(db, *value)
+	case []map[string]interface{}:
+		for _, v := range value {
+			validateMapFieldSize(db, v)
+		}
+	case *[]map[string]interface{}:
+		for _, v := range *value {
+			validateMapFieldSize(db, v)
+		}
+	default:
+		reflectValue := reflect.Indirect(reflect.ValueOf(db.Statement.Dest))
+		switch reflectValue.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < reflectValue.Len() && db.Error == nil; i++ {
+				validateStructFieldSize(db, reflect.Indirect(reflectValue.Index(i)))
+			}
+		case reflect.Struct:
+			validateStructFieldSize(db, reflectValue)
+		}
+	}
+}
+
+func validateMapFieldSize(db *gorm.DB, mapValue map[string]interface{}) {
+	for k, v := range mapValue {
+		if field := db.Statement.Schema.LookUpField(k); field != nil {
+			if err := checkFieldSize(field, v); err != nil {
+				db.AddError(err)
+				return
+			}
+		}
+	}
+}
+
+func validateStructFieldSize(db *gorm.DB, reflectValue reflect.Value) {
+	if !reflectValue.IsValid() || reflectValue.Type() != db.Statement.Schema.ModelType {
+		return
+	}
+
+	for _, field := range db.Statement.Schema.Fields {
+		if field.DBName == "" || field.Serializer != nil {
+			continue
+		}
+
+		if v, isZero := field.ValueOf(db.Statement.Context, reflectValue); !isZero {
+			if err := checkFieldSize(field, v); err != nil {
+				db.AddError(err)
+				return
+			}
+		}
+	}
+}
+
+func checkFieldSize(field *schema.Field, value interface{}) error {
+	if field.Size <= 0 || (field.GORMDataType != schema.String && field.GORMDataType != schema.Bytes) {
+		return nil
+	}
+
+	if valuer, ok := value.(driver.Valuer); ok {
+		value, _ = valuer.Value()
+	}
+
+	var length int
+	switch rv := reflect.Indirect(reflect.ValueOf(value)); rv.Kind() {
+	case reflect.String:
+		length = utf8.RuneCountInString(rv.String())
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem() != schema.ByteReflectType {
+			return nil
+		}
+		length = rv.Len()
+	default:
+		return nil
+	}
+
+	if length > field.Size {
+		return fmt.Errorf("%w: field %s allows %d, got %d", gorm.ErrFieldValueTooLong, field.Name, field.Size, length)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package gorm
+
+import (
+	"strconv"
+	"strings"
+)
+
+type callbackEdge struct {
+	from, to string
+	kind     string
+	cyclic   bool
+}
+
+// ExportDOT exports the before/after constraints of registered callbacks as a Graphviz DOT graph, E.g:
+//
+//	fmt.Println(db.Callback().Create().ExportDOT())
+//
+// an edge `a -> b` means a runs before b, callbacks and edges involved in a cycle are colored red,
+// callbacks referenced but not registered are dashed, callbacks registered with `*` are labeled first or last
+func (p *processor) ExportDOT() string {
+	var (
+		names    []string
+		edges    []callbackEdge
+		nodes    = map[string]*callback{}
+		edgeSet  = map[[2]string]bool{}
+		adjacent = map[string][]string{}
+	)
+
+	addNode := func(name string) {
+		if _, ok := nodes[name]; !ok {
+			nodes[name] = nil
+			names = append(names, name)
+		}
+	}
+
+	addEdge := func(from, to, kind string) {
+		addNode(from)
+		addNode(to)
+		if !edgeSet[[2]string{from, to}] {
+			edgeSet[[2]string{from, to}] = true
+			edges = append(edges, callbackEdge{from: from, to: to, kind: kind})
+			adjacent[from] = append(adjacent[from], to)
+		}
+	}
+
+	labeled := map[string][]string{}
+	for _, c := range p.callbacks {
+		if !c.remove {
+			for _, label := range c.labels {
+				labeled[label] = append(labeled[label], c.name)
+			}
+		}
+	}
+
+	// targets resolves label references to the callbacks having the label
+	targets := func(name, ref string) []string {
+		if label := strings.TrimPrefix(ref, callbackLabelPrefix); label != ref {
+			var names []string
+			for _, target := range labeled[label] {
+				if target != name {
+					names = append(names, target)
+				}
+			}
+			return names
+		}
+		return []string{ref}
+	}
+
+	for _, c := range p.callbacks {
+		if c.remove {
+			continue
+		}
+
+		addNode(c.name)
+		nodes[c.name] = c
+		if before := c.declared[0]; before != "" && before != "*" {
+			for _, target := range targets(c.name, before) {
+				addEdge(c.name, target, "before")
+			}
+		}
+		if after := c.declared[1]; after != "" && after != "*" {
+			for _, target := range targets(c.name, after) {
+				addEdge(target, c.name, "after")
+			}
+		}
+	}
+
+	// reachable reports whether to can be reached from from
+	reachable := func(from, to string) bool {
+		visited := map[string]bool{from: true}
+		queue := []string{from}
+		for len(queue) > 0 {
+			cur := queue[0]
+			queue = queue[1:]
+			for _, next := range adjacent[cur] {
+				if next == to {
+					return true
+				}
+				if !visited[next] {
+					visited[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		return false
+	}
+
+	cyclic := map[string]bool{}
+	for idx, edge := range edges {
+		if edge.from == edge.to || reachable(edge.to, edge.from) {
+			edges[idx].cyclic = true
+			cyclic[edge.from], cyclic[edge.to] = true, true
+		}
+	}
+
+	var dot strings.Builder
+	dot.WriteString("digraph callbacks {\n\trankdir=LR;\n")
+	for _, name := range names {
+		var attrs []string
+		if c := nodes[name]; c == nil {
+			attrs = append(attrs, "style=dashed")
+		} else if c.declared[0] == "*" {
+			attrs = append(attrs, "xlabel=first")
+		} else if c.declared[1] == "*" {
+			attrs = append(attrs, "xlabel=last")
+		}
+
+		if cyclic[name] {
+			attrs = append(attrs, "color=red")
+		}
+
+		dot.WriteString("\t" + strconv.Quote(name))
+		if len(attrs) > 0 {
+			dot.WriteString(" [" + strings.Join(attrs, ", ") + "]")
+		}
+		dot.WriteString(";\n")
+	}
+
+	for _, edge := range edges {
+		dot.WriteString("\t" + strconv.Quote(edge.from) + " -> " + strconv.Quote(edge.to) + " [label=" + edge.kind)
+		if edge.cyclic {
+			dot.WriteString(", color=red")
+		}
+		dot.WriteString("];\n")
+	}
+	dot.WriteString("}\n")
+	return dot.String()
+}
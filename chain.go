@@ -0,0 +1,115 @@
+package gorm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ReturnedValue reference of a field of the created value, which is filled by RETURNING or the last insert id,
+// it is resolved when building the statement, so it could be used as a parameter of the following statements
+type ReturnedValue struct {
+	Value interface{}
+	Field string
+}
+
+// Returned returns the reference of value's field, E.g:
+//
+//	db.Chain().Create(&order).Create(map[string]interface{}{"order_id": gorm.Returned(&order, "ID")}, &OrderItem{})
+func Returned(value interface{}, field string) ReturnedValue {
+	return ReturnedValue{Value: value, Field: field}
+}
+
+// GormValue resolve the returned value
+func (returned ReturnedValue) GormValue(ctx context.Context, db *DB) clause.Expr {
+	s, err := schema.Parse(returned.Value, db.cacheStore, db.NamingStrategy)
+	if err != nil {
+		db.AddError(err)
+		return clause.Expr{SQL: "NULL"}
+	}
+
+	field := s.LookUpField(returned.Field)
+	if field == nil {
+		db.AddError(fmt.Errorf("%w: %s", ErrInvalidField, returned.Field))
+		return clause.Expr{SQL: "NULL"}
+	}
+
+	value, isZero := field.ValueOf(ctx, reflect.ValueOf(returned.Value))
+	if isZero {
+		db.AddError(fmt.Errorf("%w: %s.%s is not returned yet", ErrInvalidValue, s.Name, field.Name))
+	}
+	return clause.Expr{SQL: "?", Vars: []interface{}{value}}
+}
+
+// Chain runs dependent statements in one transaction, the values returned by a statement could be referenced
+// by the following statements with Returned or a lazy value function
+type Chain struct {
+	db    *DB
+	steps []func(tx *DB) *DB
+}
+
+// Chain returns a chain of statements running in one transaction, E.g:
+//
+//	db.Chain().
+//		Create(&order).
+//		Create(func() interface{} { return &OrderItem{OrderID: order.ID} }).
+//		Updates(&Customer{ID: order.CustomerID}, map[string]interface{}{"last_order_id": gorm.Returned(&order, "ID")}).
+//		Exec()
+func (db *DB) Chain() *Chain {
+	return &Chain{db: db.Session(&Session{})}
+}
+
+// lazyValue resolve the value of function, which is called after the previous statements were executed
+func lazyValue(value interface{}) interface{} {
+	if fc, ok := value.(func() interface{}); ok {
+		return fc()
+	}
+	return value
+}
+
+// Create appends a create statement, value could be a func() interface{} to build the value after previous statements,
+// model is used when value is a map
+func (c *Chain) Create(value interface{}, model ...interface{}) *Chain {
+	c.steps = append(c.steps, func(tx *DB) *DB {
+		if len(model) > 0 {
+			tx = tx.Model(model[0])
+		}
+		return tx.Create(lazyValue(value))
+	})
+	return c
+}
+
+// Updates appends an update statement of the model, values could be a func() interface{}
+func (c *Chain) Updates(model interface{}, values interface{}) *Chain {
+	c.steps = append(c.steps, func(tx *DB) *DB {
+		return tx.Model(lazyValue(model)).Updates(lazyValue(values))
+	})
+	return c
+}
+
+// Then appends a custom statement
+func (c *Chain) Then(fc func(tx *DB) *DB) *Chain {
+	c.steps = append(c.steps, fc)
+	return c
+}
+
+// Exec executes the statements in one transaction, rolls back if any of them failed
+func (c *Chain) Exec() (tx *DB) {
+	var rowsAffected int64
+	tx = c.db.getInstance()
+	tx.AddError(tx.Transaction(func(tx *DB) error {
+		for _, step := range c.steps {
+			result := step(tx.Session(&Session{}))
+			if result.Error != nil {
+				return result.Error
+			}
+			rowsAffected += result.RowsAffected
+		}
+		return nil
+	}))
+	tx.RowsAffected = rowsAffected
+	return
+}
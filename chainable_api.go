@@ -1,6 +1,7 @@
 package gorm
 
 import (
+	"database/sql"
 	"fmt"
 	"regexp"
 	"strings"
@@ -458,6 +459,21 @@ func (db *DB) Unscoped() (tx *DB) {
 	return
 }
 
+// Isolation specify the isolation level used when beginning a transaction
+//
+//	db.Isolation(sql.LevelSerializable).Transaction(func(tx *gorm.DB) error {
+//		// ...
+//	})
+func (db *DB) Isolation(level sql.IsolationLevel) (tx *DB) {
+	tx = db.getInstance()
+	if checker, ok := tx.Dialector.(IsolationLevelChecker); ok && !checker.SupportIsolationLevel(level) {
+		tx.AddError(fmt.Errorf("%w: %s for %s", ErrUnsupportedIsolationLevel, level, tx.Dialector.Name()))
+		return
+	}
+	tx.Statement.Settings.Store("gorm:isolation_level", level)
+	return
+}
+
 func (db *DB) Raw(sql string, values ...interface{}) (tx *DB) {
 	tx = db.getInstance()
 	tx.Statement.SQL = strings.Builder{}
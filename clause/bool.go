@@ -0,0 +1,100 @@
+package clause
+
+import (
+	"strings"
+	"unicode"
+)
+
+// numericBoolDialects dialects without boolean literals, use 1/0 instead, used if the dialector doesn't implement gorm.BoolLiteralDialectorInterface
+var numericBoolDialects = map[string]bool{
+	"sqlserver": true,
+	"oracle":    true,
+}
+
+// boolLiteralSupporter *gorm.Statement exposes whether the dialector supports TRUE/FALSE literals
+type boolLiteralSupporter interface {
+	SupportBoolLiteral() (supported bool, ok bool)
+}
+
+// Bool boolean literal, rendered as TRUE/FALSE, or 1/0 on dialects without boolean literals
+type Bool bool
+
+// Build build boolean literal
+func (b Bool) Build(builder Builder) {
+	numeric := false
+	if namer, ok := builder.(dialectNamer); ok {
+		numeric = numericBoolDialects[namer.Name()]
+	}
+	if supporter, ok := builder.(boolLiteralSupporter); ok {
+		if supported, ok := supporter.SupportBoolLiteral(); ok {
+			numeric = !supported
+		}
+	}
+
+	switch {
+	case numeric && bool(b):
+		builder.WriteByte('1')
+	case numeric:
+		builder.WriteByte('0')
+	case bool(b):
+		builder.WriteString("TRUE")
+	default:
+		builder.WriteString("FALSE")
+	}
+}
+
+// BoolExpr raw SQL whose TRUE/FALSE keywords are rendered as dialect aware boolean literals,
+// keywords in quoted strings or identifiers are kept
+//
+//	clause.BoolExpr{SQL: "flag IN (TRUE, FALSE)"}
+//	// sqlserver: flag IN (1, 0)
+type BoolExpr struct {
+	SQL string
+}
+
+// Build build boolean expression
+func (expr BoolExpr) Build(builder Builder) {
+	var (
+		sql   = expr.SQL
+		quote rune
+		start = -1
+	)
+
+	flush := func(end int) {
+		if start >= 0 {
+			switch word := sql[start:end]; strings.ToUpper(word) {
+			case "TRUE":
+				Bool(true).Build(builder)
+			case "FALSE":
+				Bool(false).Build(builder)
+			default:
+				builder.WriteString(word)
+			}
+			start = -1
+		}
+	}
+
+	for idx, r := range sql {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			flush(idx)
+			quote = r
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			if start < 0 {
+				start = idx
+			}
+			continue
+		default:
+			flush(idx)
+		}
+
+		if start < 0 {
+			builder.WriteString(string(r))
+		}
+	}
+	flush(len(sql))
+}
@@ -0,0 +1,39 @@
+package clause_test
+
+import (
+	"fmt"
+	"testing"
+
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/utils/tests"
+)
+
+func TestBoolLiteral(t *testing.T) {
+	results := []struct {
+		Dialect string
+		Expr    clause.Expression
+		Result  string
+	}{
+		{"postgres", clause.Bool(true), "TRUE"},
+		{"mysql", clause.Bool(false), "FALSE"},
+		{"sqlserver", clause.Bool(true), "1"},
+		{"oracle", clause.Bool(false), "0"},
+		{"sqlite", clause.BoolExpr{SQL: "flag IN (TRUE, FALSE)"}, "flag IN (TRUE, FALSE)"},
+		{"sqlserver", clause.BoolExpr{SQL: "flag IN (true, False)"}, "flag IN (1, 0)"},
+		{"sqlserver", clause.BoolExpr{SQL: "is_true = TRUE AND name <> 'TRUE' AND \"false\" = FALSE"}, "is_true = 1 AND name <> 'TRUE' AND \"false\" = 0"},
+		{"sqlserver", clause.Expr{SQL: "active = ?", Vars: []interface{}{clause.Bool(true)}}, "active = 1"},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			sql, vars, err := tests.BuildExpression(tests.MockDialector{DialectName: result.Dialect}, result.Expr)
+			if err != nil || len(vars) != 0 {
+				t.Fatalf("failed to build, got error %v, vars %v", err, vars)
+			}
+
+			if sql != result.Result {
+				t.Errorf("%v: SQL expects %v got %v", result.Dialect, result.Result, sql)
+			}
+		})
+	}
+}
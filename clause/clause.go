@@ -25,6 +25,21 @@ type Builder interface {
 	AddError(error) error
 }
 
+// dialectNamer 接口，*gorm.Statement 通过它暴露方言名称。
+type dialectNamer interface {
+	Name() string
+}
+
+// settingGetter 接口，*gorm.Statement 通过它暴露 db.Set 设置的参数。
+type settingGetter interface {
+	Setting(key string) (interface{}, bool)
+}
+
+// warner 接口，*gorm.Statement 通过它使用 DB 的日志输出警告。
+type warner interface {
+	Warn(msg string, data ...interface{})
+}
+
 // Clause
 type Clause struct {
 	Name                string // WHERE
@@ -55,6 +70,11 @@ func (c Clause) Build(builder Builder) {
 			builder.WriteByte(' ')
 		}
 
+		// Select writes TOP n by itself after DISTINCT
+		if _, ok := c.Expression.(Select); !ok && c.Name == "SELECT" {
+			writeTop(builder)
+		}
+
 		c.Expression.Build(builder)
 
 		if c.AfterExpression != nil {
@@ -0,0 +1,61 @@
+package clause
+
+import "fmt"
+
+// collateDialects dialects support inline COLLATE on comparisons, used if the dialector doesn't implement gorm.CollateDialectorInterface
+var collateDialects = map[string]bool{
+	"postgres":  true,
+	"mysql":     true,
+	"sqlite":    true,
+	"sqlserver": true,
+}
+
+// collateSupporter *gorm.Statement exposes whether inline COLLATE is supported by the dialector
+type collateSupporter interface {
+	SupportCollate() (supported bool, ok bool)
+}
+
+// Collate attach a collation to the comparison expression, emits `expression COLLATE collation`
+//
+//	db.Where(clause.Eq{Column: "name", Value: "jinzhu"}.Collate("en_US")).Find(&users)
+//	// SELECT * FROM "users" WHERE "name" = $1 COLLATE "en_US"
+type Collate struct {
+	Expression Expression
+	Collation  string
+}
+
+// Build build collate expression
+func (collate Collate) Build(builder Builder) {
+	if namer, ok := builder.(dialectNamer); ok && !supportCollate(builder, namer.Name()) {
+		builder.AddError(fmt.Errorf("inline collation is not supported by %s", namer.Name()))
+		return
+	}
+
+	collate.Expression.Build(builder)
+	builder.WriteString(" COLLATE ")
+	builder.WriteQuoted(collate.Collation)
+}
+
+func supportCollate(builder Builder, name string) bool {
+	if supporter, ok := builder.(collateSupporter); ok {
+		if supported, ok := supporter.SupportCollate(); ok {
+			return supported
+		}
+	}
+	return collateDialects[name]
+}
+
+// Collate compare with the collation, the value should be a single value
+func (eq Eq) Collate(collation string) Collate {
+	return Collate{Expression: eq, Collation: collation}
+}
+
+// Collate compare with the collation, the value should be a single value
+func (neq Neq) Collate(collation string) Collate {
+	return Collate{Expression: neq, Collation: collation}
+}
+
+// Collate match with the collation
+func (like Like) Collate(collation string) Collate {
+	return Collate{Expression: like, Collation: collation}
+}
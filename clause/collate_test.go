@@ -0,0 +1,73 @@
+package clause_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/utils/tests"
+)
+
+func TestCollate(t *testing.T) {
+	pgDialector := tests.MockDialector{DialectName: "postgres", QuoteToFunc: tests.DoubleQuoteTo, BindVarToFunc: tests.DollarBindVarTo}
+	results := []struct {
+		Expr   clause.Expression
+		Result string
+		Vars   []interface{}
+	}{
+		{
+			Expr:   clause.Eq{Column: "name", Value: "jinzhu"}.Collate("en_US"),
+			Result: `"name" = $1 COLLATE "en_US"`,
+			Vars:   []interface{}{"jinzhu"},
+		},
+		{
+			Expr:   clause.Neq{Column: clause.Column{Table: "users", Name: "name"}, Value: "jinzhu"}.Collate("C"),
+			Result: `"users"."name" <> $1 COLLATE "C"`,
+			Vars:   []interface{}{"jinzhu"},
+		},
+		{
+			Expr:   clause.Like{Column: "name", Value: "%jinzhu%"}.Collate("und-x-icu"),
+			Result: `"name" LIKE $1 COLLATE "und-x-icu"`,
+			Vars:   []interface{}{"%jinzhu%"},
+		},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			sql, vars, err := tests.BuildExpression(pgDialector, result.Expr)
+			if err != nil {
+				t.Fatalf("failed to build, got error %v", err)
+			}
+
+			if sql != result.Result {
+				t.Errorf("SQL expects %v got %v", result.Result, sql)
+			}
+
+			if fmt.Sprint(vars) != fmt.Sprint(result.Vars) {
+				t.Errorf("Vars expects %+v got %+v", result.Vars, vars)
+			}
+		})
+	}
+
+	stmt := db.Session(&gorm.Session{DryRun: true}).Model(&tests.User{}).Where(clause.Eq{Column: "name", Value: "jinzhu"}.Collate("en_US")).Find(&[]tests.User{}).Statement
+	if stmt.Error == nil || !strings.Contains(stmt.Error.Error(), "not supported") {
+		t.Errorf("expects unsupported dialect error, got %v", stmt.Error)
+	}
+
+	collateDB, _ := gorm.Open(collateDialector{DummyDialector: tests.DummyDialector{}}, &gorm.Config{})
+	stmt = collateDB.Session(&gorm.Session{DryRun: true}).Model(&tests.User{}).Where(clause.Eq{Column: "name", Value: "jinzhu"}.Collate("en_US")).Find(&[]tests.User{}).Statement
+	if stmt.Error != nil || !strings.Contains(stmt.SQL.String(), "COLLATE `en_US`") {
+		t.Errorf("expects collation for dialector supports it, got %v, error %v", stmt.SQL.String(), stmt.Error)
+	}
+}
+
+// collateDialector dialector tells inline COLLATE is supported without a known dialect name
+type collateDialector struct {
+	tests.DummyDialector
+}
+
+func (collateDialector) SupportCollate() bool {
+	return true
+}
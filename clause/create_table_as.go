@@ -0,0 +1,109 @@
+package clause
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CreateTableAsSyntax the syntax of the dialect to materialize a query into a new table
+type CreateTableAsSyntax int
+
+const (
+	// CreateTableAsUnsupported materializing a query is not supported
+	CreateTableAsUnsupported CreateTableAsSyntax = iota
+	// CreateTableAsSelect CREATE TABLE target AS query
+	CreateTableAsSelect
+	// SelectIntoSyntax SELECT * INTO target FROM (query) AS source, e.g: sqlserver
+	SelectIntoSyntax
+)
+
+// createTableAsDialects syntax of known dialects, used if the dialector doesn't implement gorm.CreateTableAsDialectorInterface
+var createTableAsDialects = map[string]CreateTableAsSyntax{
+	"postgres":  CreateTableAsSelect,
+	"mysql":     CreateTableAsSelect,
+	"sqlite":    CreateTableAsSelect,
+	"sqlserver": SelectIntoSyntax,
+}
+
+// createTableAsStyler *gorm.Statement exposes the create table as syntax of the dialector
+type createTableAsStyler interface {
+	CreateTableAsSyntax() (syntax CreateTableAsSyntax, ok bool)
+}
+
+// CreateTableAs materialize the result of a query into a new table, e.g: reporting snapshots
+//
+//	db.Exec("?", clause.CreateTableAs{Table: clause.Table{Name: "user_snapshots"}, Query: db.Model(&User{}).Where("active = ?", true)})
+//	// CREATE TABLE "user_snapshots" AS SELECT * FROM "users" WHERE active = true
+//
+// sqlserver uses SELECT * INTO target FROM (query) AS source instead, Query could be an Expression or a *gorm.DB
+type CreateTableAs struct {
+	Table      Table
+	Query      interface{}
+	Temporary  bool // CREATE TEMPORARY TABLE, not supported by sqlserver
+	WithData   bool // WITH DATA, postgres only
+	WithNoData bool // WITH NO DATA, only create the table structure, postgres only
+}
+
+// Build build create table as statement
+func (ctas CreateTableAs) Build(builder Builder) {
+	var dialect string
+	syntax := CreateTableAsSelect
+	if namer, ok := builder.(dialectNamer); ok {
+		dialect = namer.Name()
+		syntax = createTableAsDialects[dialect]
+		if styler, ok := builder.(createTableAsStyler); ok {
+			if s, ok := styler.CreateTableAsSyntax(); ok {
+				syntax = s
+			}
+		}
+
+		if syntax == CreateTableAsUnsupported {
+			builder.AddError(fmt.Errorf("create table as is not supported by %s", dialect))
+			return
+		}
+	}
+
+	if ctas.Table.Name == "" || ctas.Query == nil {
+		builder.AddError(errors.New("create table as requires table and query"))
+		return
+	}
+
+	if ctas.WithData && ctas.WithNoData {
+		builder.AddError(errors.New("create table as could not use WITH DATA and WITH NO DATA at the same time"))
+		return
+	}
+
+	if (ctas.WithData || ctas.WithNoData) && !requirePostgres(builder, "create table as WITH [NO] DATA") {
+		return
+	}
+
+	if syntax == SelectIntoSyntax {
+		if ctas.Temporary {
+			builder.AddError(fmt.Errorf("temporary create table as is not supported by %s", dialect))
+			return
+		}
+
+		builder.WriteString("SELECT * INTO ")
+		builder.WriteQuoted(ctas.Table)
+		builder.WriteString(" FROM (")
+		builder.AddVar(builder, ctas.Query)
+		builder.WriteString(") AS ")
+		builder.WriteQuoted("source")
+		return
+	}
+
+	builder.WriteString("CREATE ")
+	if ctas.Temporary {
+		builder.WriteString("TEMPORARY ")
+	}
+	builder.WriteString("TABLE ")
+	builder.WriteQuoted(ctas.Table)
+	builder.WriteString(" AS ")
+	builder.AddVar(builder, ctas.Query)
+
+	if ctas.WithData {
+		builder.WriteString(" WITH DATA")
+	} else if ctas.WithNoData {
+		builder.WriteString(" WITH NO DATA")
+	}
+}
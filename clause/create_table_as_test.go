@@ -0,0 +1,122 @@
+package clause_test
+
+import (
+	"fmt"
+	"testing"
+
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/utils/tests"
+)
+
+func TestCreateTableAs(t *testing.T) {
+	var (
+		pgDialector        = tests.MockDialector{DialectName: "postgres", QuoteToFunc: tests.DoubleQuoteTo, BindVarToFunc: tests.DollarBindVarTo}
+		mysqlDialector     = tests.MockDialector{DialectName: "mysql"}
+		sqliteDialector    = tests.MockDialector{DialectName: "sqlite"}
+		sqlserverDialector = tests.MockDialector{DialectName: "sqlserver"}
+		query              = clause.Expr{SQL: "SELECT * FROM users WHERE active = ?", Vars: []interface{}{true}}
+		table              = clause.Table{Name: "user_snapshots"}
+	)
+
+	results := []struct {
+		Dialector tests.MockDialector
+		Expr      clause.Expression
+		Result    string
+		Vars      []interface{}
+	}{
+		{
+			Dialector: pgDialector,
+			Expr:      clause.CreateTableAs{Table: table, Query: query},
+			Result:    `CREATE TABLE "user_snapshots" AS SELECT * FROM users WHERE active = $1`,
+			Vars:      []interface{}{true},
+		},
+		{
+			Dialector: pgDialector,
+			Expr:      clause.CreateTableAs{Table: table, Query: query, Temporary: true, WithNoData: true},
+			Result:    `CREATE TEMPORARY TABLE "user_snapshots" AS SELECT * FROM users WHERE active = $1 WITH NO DATA`,
+			Vars:      []interface{}{true},
+		},
+		{
+			Dialector: pgDialector,
+			Expr:      clause.CreateTableAs{Table: table, Query: query, WithData: true},
+			Result:    `CREATE TABLE "user_snapshots" AS SELECT * FROM users WHERE active = $1 WITH DATA`,
+			Vars:      []interface{}{true},
+		},
+		{
+			Dialector: mysqlDialector,
+			Expr:      clause.CreateTableAs{Table: table, Query: query, Temporary: true},
+			Result:    "CREATE TEMPORARY TABLE `user_snapshots` AS SELECT * FROM users WHERE active = ?",
+			Vars:      []interface{}{true},
+		},
+		{
+			Dialector: sqliteDialector,
+			Expr:      clause.CreateTableAs{Table: table, Query: query},
+			Result:    "CREATE TABLE `user_snapshots` AS SELECT * FROM users WHERE active = ?",
+			Vars:      []interface{}{true},
+		},
+		{
+			Dialector: sqlserverDialector,
+			Expr:      clause.CreateTableAs{Table: table, Query: query},
+			Result:    "SELECT * INTO `user_snapshots` FROM (SELECT * FROM users WHERE active = ?) AS `source`",
+			Vars:      []interface{}{true},
+		},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			sql, vars, err := tests.BuildExpression(result.Dialector, result.Expr)
+			if err != nil {
+				t.Fatalf("failed to build, got error %v", err)
+			}
+
+			if sql != result.Result {
+				t.Errorf("SQL expects %v got %v", result.Result, sql)
+			}
+
+			if fmt.Sprint(vars) != fmt.Sprint(result.Vars) {
+				t.Errorf("Vars expects %+v got %v", result.Vars, vars)
+			}
+		})
+	}
+}
+
+// selectIntoDialector dialector materializes queries with SELECT ... INTO without a known dialect name
+type selectIntoDialector struct {
+	tests.MockDialector
+}
+
+func (selectIntoDialector) CreateTableAsSyntax() clause.CreateTableAsSyntax {
+	return clause.SelectIntoSyntax
+}
+
+func TestCreateTableAsSyntax(t *testing.T) {
+	dialector := selectIntoDialector{MockDialector: tests.MockDialector{DialectName: "oracle"}}
+	sql, _, err := tests.BuildExpression(dialector, clause.CreateTableAs{Table: clause.Table{Name: "snapshots"}, Query: clause.Expr{SQL: "SELECT * FROM users"}})
+	if err != nil {
+		t.Fatalf("failed to build, got error %v", err)
+	}
+
+	if expected := "SELECT * INTO `snapshots` FROM (SELECT * FROM users) AS `source`"; sql != expected {
+		t.Errorf("SQL expects %v got %v", expected, sql)
+	}
+}
+
+func TestCreateTableAsUnsupported(t *testing.T) {
+	query := clause.Expr{SQL: "SELECT * FROM users"}
+	results := []struct {
+		Dialector tests.MockDialector
+		Expr      clause.Expression
+	}{
+		{tests.MockDialector{DialectName: "oracle"}, clause.CreateTableAs{Table: clause.Table{Name: "snapshots"}, Query: query}},
+		{tests.MockDialector{DialectName: "mysql"}, clause.CreateTableAs{Table: clause.Table{Name: "snapshots"}, Query: query, WithNoData: true}},
+		{tests.MockDialector{DialectName: "sqlserver"}, clause.CreateTableAs{Table: clause.Table{Name: "snapshots"}, Query: query, Temporary: true}},
+		{tests.MockDialector{DialectName: "postgres"}, clause.CreateTableAs{Table: clause.Table{Name: "snapshots"}, Query: query, WithData: true, WithNoData: true}},
+		{tests.MockDialector{DialectName: "postgres"}, clause.CreateTableAs{Query: query}},
+	}
+
+	for idx, result := range results {
+		if _, _, err := tests.BuildExpression(result.Dialector, result.Expr); err == nil {
+			t.Errorf("case #%v should return error for unsupported create table as", idx)
+		}
+	}
+}
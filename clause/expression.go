@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"go/ast"
 	"reflect"
+	"strings"
 )
 
 // Expression expression interface
@@ -201,7 +202,7 @@ func (in IN) Build(builder Builder) {
 	case 0:
 		builder.WriteString(" IN (NULL)")
 	case 1:
-		if _, ok := in.Values[0].([]interface{}); !ok {
+		if _, ok := in.Values[0].([]interface{}); !ok && !isSubQuery(builder, in.Values[0]) {
 			builder.WriteString(" = ")
 			builder.AddVar(builder, in.Values[0])
 			break
@@ -221,7 +222,7 @@ func (in IN) NegationBuild(builder Builder) {
 	case 0:
 		builder.WriteString(" IS NOT NULL")
 	case 1:
-		if _, ok := in.Values[0].([]interface{}); !ok {
+		if _, ok := in.Values[0].([]interface{}); !ok && !isSubQuery(builder, in.Values[0]) {
 			builder.WriteString(" <> ")
 			builder.AddVar(builder, in.Values[0])
 			break
@@ -235,6 +236,32 @@ func (in IN) NegationBuild(builder Builder) {
 	}
 }
 
+// subQuerier *gorm.Statement reports whether a value is a subquery, e.g: *gorm.DB
+type subQuerier interface {
+	IsSubQuery(value interface{}) bool
+}
+
+// isSubQuery whether the value is a subquery, e.g: Expr{SQL: "SELECT ..."} or *gorm.DB
+func isSubQuery(builder Builder, value interface{}) bool {
+	switch v := value.(type) {
+	case Expr:
+		return isSelectSQL(v.SQL)
+	case NamedExpr:
+		return isSelectSQL(v.SQL)
+	}
+
+	if querier, ok := builder.(subQuerier); ok {
+		return querier.IsSubQuery(value)
+	}
+	return false
+}
+
+// isSelectSQL whether the sql is a SELECT statement
+func isSelectSQL(sql string) bool {
+	sql = strings.TrimSpace(sql)
+	return len(sql) > 6 && strings.EqualFold(sql[:6], "SELECT") && (sql[6] == ' ' || sql[6] == '\n' || sql[6] == '\t')
+}
+
 // Eq equal to for where
 type Eq struct {
 	Column interface{}
@@ -273,6 +300,21 @@ func (eq Eq) NegationBuild(builder Builder) {
 	Neq(eq).Build(builder)
 }
 
+// EqAny equal to any of the values, builds Eq for a single value, IN for multiple values,
+// and an always false condition for empty values
+//
+//	db.Where(clause.EqAny("name", names...)).Find(&users)
+func EqAny(column interface{}, values ...interface{}) Expression {
+	switch len(values) {
+	case 0:
+		return Expr{SQL: "1 = 0"}
+	case 1:
+		return Eq{Column: column, Value: values[0]}
+	default:
+		return IN{Column: column, Values: values}
+	}
+}
+
 // Neq not equal to for where
 type Neq Eq
 
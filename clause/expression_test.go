@@ -187,6 +187,23 @@ func TestExpression(t *testing.T) {
 			clause.Neq{Column: column, Value: (interface{})(nil)},
 		},
 		Result: "`column-name` IS NOT NULL",
+	}, {
+		Expressions: []clause.Expression{
+			clause.EqAny(column, "column-value"),
+		},
+		ExpectedVars: []interface{}{"column-value"},
+		Result:       "`column-name` = ?",
+	}, {
+		Expressions: []clause.Expression{
+			clause.EqAny(column, "a", "b"),
+		},
+		ExpectedVars: []interface{}{"a", "b"},
+		Result:       "`column-name` IN (?,?)",
+	}, {
+		Expressions: []clause.Expression{
+			clause.EqAny(column),
+		},
+		Result: "1 = 0",
 	}, {
 		Expressions: []clause.Expression{
 			clause.Eq{Column: column, Value: []string{"a", "b"}},
@@ -13,14 +13,22 @@ func (groupBy GroupBy) Name() string {
 
 // Build build group by clause
 func (groupBy GroupBy) Build(builder Builder) {
+	var hasOrdinal bool
 	for idx, column := range groupBy.Columns {
 		if idx > 0 {
 			builder.WriteByte(',')
 		}
 
+		if checkOrdinal(builder, column) {
+			hasOrdinal = true
+		}
 		builder.WriteQuoted(column)
 	}
 
+	if hasOrdinal {
+		warnOrdinalGroupBy(builder)
+	}
+
 	if len(groupBy.Having) > 0 {
 		builder.WriteString(" HAVING ")
 		Where{Exprs: groupBy.Having}.Build(builder)
@@ -32,6 +32,12 @@ func TestGroupBy(t *testing.T) {
 			"SELECT * FROM `users` GROUP BY `role`,`gender` HAVING `role` = ? AND `gender` <> ?",
 			[]interface{}{"admin", "U"},
 		},
+		{
+			[]clause.Interface{clause.Select{}, clause.From{}, clause.GroupBy{
+				Columns: []clause.Column{clause.Ordinal(1), clause.Ordinal(2)},
+			}},
+			"SELECT * FROM `users` GROUP BY 1,2", nil,
+		},
 	}
 
 	for idx, result := range results {
@@ -1,5 +1,51 @@
 package clause
 
+import (
+	"errors"
+	"strconv"
+)
+
+// LimitStyle the pagination syntax of the dialect
+type LimitStyle int
+
+const (
+	// LimitOffsetStyle LIMIT ? OFFSET ?
+	LimitOffsetStyle LimitStyle = iota
+	// FetchFirstStyle OFFSET ? ROWS FETCH FIRST ? ROWS ONLY, e.g: oracle 12c+, db2
+	FetchFirstStyle
+	// TopStyle SELECT TOP n, offset is not supported, e.g: older sqlserver
+	TopStyle
+)
+
+// limitStyler *gorm.Statement exposes the pagination syntax of the dialect and whether the count could be a bind var
+type limitStyler interface {
+	LimitStyle() (style LimitStyle, bindVar bool)
+}
+
+// topLimiter *gorm.Statement exposes the limit written as TOP n by the SELECT clause
+type topLimiter interface {
+	TopLimit() *int
+}
+
+// writeTop write `TOP n ` if the dialect uses TopStyle
+func writeTop(builder Builder) {
+	if top, ok := builder.(topLimiter); ok {
+		if limit := top.TopLimit(); limit != nil {
+			builder.WriteString("TOP ")
+			builder.WriteString(strconv.Itoa(*limit))
+			builder.WriteByte(' ')
+		}
+	}
+}
+
+func writeLimitCount(builder Builder, count int, bindVar bool) {
+	if bindVar {
+		builder.AddVar(builder, count)
+	} else {
+		builder.WriteString(strconv.Itoa(count))
+	}
+}
+
 // Limit limit clause
 type Limit struct {
 	Limit  *int
@@ -13,16 +59,48 @@ func (limit Limit) Name() string {
 
 // Build build where clause
 func (limit Limit) Build(builder Builder) {
-	if limit.Limit != nil && *limit.Limit >= 0 {
-		builder.WriteString("LIMIT ")
-		builder.AddVar(builder, *limit.Limit)
+	style, bindVar := LimitOffsetStyle, true
+	if styler, ok := builder.(limitStyler); ok {
+		style, bindVar = styler.LimitStyle()
 	}
-	if limit.Offset > 0 {
-		if limit.Limit != nil && *limit.Limit >= 0 {
-			builder.WriteByte(' ')
+
+	hasLimit := limit.Limit != nil && *limit.Limit >= 0
+	switch style {
+	case FetchFirstStyle:
+		if limit.Offset > 0 {
+			builder.WriteString("OFFSET ")
+			writeLimitCount(builder, limit.Offset, bindVar)
+			builder.WriteString(" ROWS")
+		}
+		if hasLimit {
+			if limit.Offset > 0 {
+				builder.WriteByte(' ')
+			}
+			builder.WriteString("FETCH FIRST ")
+			writeLimitCount(builder, *limit.Limit, bindVar)
+			builder.WriteString(" ROWS ONLY")
+		}
+	case TopStyle:
+		// the limit is written as TOP n by the SELECT clause
+		if limit.Offset > 0 {
+			builder.AddError(errors.New("OFFSET is not supported with TOP"))
+		} else if hasLimit {
+			if top, ok := builder.(topLimiter); !ok || top.TopLimit() == nil {
+				builder.AddError(errors.New("LIMIT is only supported by SELECT with TOP"))
+			}
+		}
+	default:
+		if hasLimit {
+			builder.WriteString("LIMIT ")
+			writeLimitCount(builder, *limit.Limit, bindVar)
+		}
+		if limit.Offset > 0 {
+			if hasLimit {
+				builder.WriteByte(' ')
+			}
+			builder.WriteString("OFFSET ")
+			writeLimitCount(builder, limit.Offset, bindVar)
 		}
-		builder.WriteString("OFFSET ")
-		builder.AddVar(builder, limit.Offset)
 	}
 }
 
@@ -2,9 +2,13 @@ package clause_test
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/utils/tests"
 )
 
 func TestLimit(t *testing.T) {
@@ -78,3 +82,95 @@ func TestLimit(t *testing.T) {
 		})
 	}
 }
+
+type limitStyleDialector struct {
+	tests.MockDialector
+	style   clause.LimitStyle
+	bindVar bool
+}
+
+func (d limitStyleDialector) LimitStyle() clause.LimitStyle {
+	return d.style
+}
+
+func (d limitStyleDialector) LimitBindVar() bool {
+	return d.bindVar
+}
+
+func TestLimitStyles(t *testing.T) {
+	results := []struct {
+		Name     string
+		Style    clause.LimitStyle
+		BindVar  bool
+		Query    func(tx *gorm.DB) *gorm.DB
+		Result   string
+		Vars     []interface{}
+		HasError bool
+	}{
+		{
+			Name: "fetch first", Style: clause.FetchFirstStyle, BindVar: true,
+			Query:  func(tx *gorm.DB) *gorm.DB { return tx.Limit(10).Offset(20) },
+			Result: "SELECT * FROM `users` WHERE `users`.`deleted_at` IS NULL OFFSET ? ROWS FETCH FIRST ? ROWS ONLY",
+			Vars:   []interface{}{20, 10},
+		},
+		{
+			Name: "fetch first without offset", Style: clause.FetchFirstStyle, BindVar: true,
+			Query:  func(tx *gorm.DB) *gorm.DB { return tx.Limit(10) },
+			Result: "SELECT * FROM `users` WHERE `users`.`deleted_at` IS NULL FETCH FIRST ? ROWS ONLY",
+			Vars:   []interface{}{10},
+		},
+		{
+			Name: "fetch first inline", Style: clause.FetchFirstStyle,
+			Query:  func(tx *gorm.DB) *gorm.DB { return tx.Offset(20) },
+			Result: "SELECT * FROM `users` WHERE `users`.`deleted_at` IS NULL OFFSET 20 ROWS",
+		},
+		{
+			Name: "limit offset inline", Style: clause.LimitOffsetStyle,
+			Query:  func(tx *gorm.DB) *gorm.DB { return tx.Limit(10).Offset(20) },
+			Result: "SELECT * FROM `users` WHERE `users`.`deleted_at` IS NULL LIMIT 10 OFFSET 20",
+		},
+		{
+			Name: "top", Style: clause.TopStyle,
+			Query:  func(tx *gorm.DB) *gorm.DB { return tx.Limit(10) },
+			Result: "SELECT TOP 10 * FROM `users` WHERE `users`.`deleted_at` IS NULL",
+		},
+		{
+			Name: "top with distinct columns", Style: clause.TopStyle,
+			Query:  func(tx *gorm.DB) *gorm.DB { return tx.Distinct("name").Limit(10) },
+			Result: "SELECT DISTINCT TOP 10 `name` FROM `users` WHERE `users`.`deleted_at` IS NULL",
+		},
+		{
+			Name: "top with offset", Style: clause.TopStyle,
+			Query:    func(tx *gorm.DB) *gorm.DB { return tx.Limit(10).Offset(20) },
+			HasError: true,
+		},
+	}
+
+	for _, result := range results {
+		t.Run(result.Name, func(t *testing.T) {
+			db, err := gorm.Open(limitStyleDialector{style: result.Style, bindVar: result.BindVar}, &gorm.Config{DryRun: true})
+			if err != nil {
+				t.Fatalf("failed to open db, got %v", err)
+			}
+
+			var users []tests.User
+			stmt := result.Query(db.Model(&tests.User{})).Find(&users).Statement
+			if result.HasError {
+				if stmt.Error == nil {
+					t.Fatalf("expects error, got sql %v", stmt.SQL.String())
+				}
+				return
+			}
+
+			if stmt.Error != nil {
+				t.Fatalf("failed to build sql, got %v", stmt.Error)
+			}
+			if sql := strings.TrimSpace(stmt.SQL.String()); sql != result.Result {
+				t.Errorf("SQL expects %v got %v", result.Result, sql)
+			}
+			if len(stmt.Vars) != len(result.Vars) || (len(result.Vars) > 0 && !reflect.DeepEqual(stmt.Vars, result.Vars)) {
+				t.Errorf("Vars expects %+v got %v", result.Vars, stmt.Vars)
+			}
+		})
+	}
+}
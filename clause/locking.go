@@ -1,5 +1,7 @@
 package clause
 
+import "fmt"
+
 const (
 	LockingStrengthUpdate    = "UPDATE"
 	LockingStrengthShare     = "SHARE"
@@ -7,6 +9,19 @@ const (
 	LockingOptionsNoWait     = "NOWAIT"
 )
 
+// lockingSupporter *gorm.Statement exposes whether the locking strength is supported by the dialector
+type lockingSupporter interface {
+	SupportLockingStrength(strength string) bool
+}
+
+// Locking row locking clause, it is built at the end of the query, e.g: the job queue pattern
+//
+//	db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+//		Where("status = ?", "pending").Order("id").Limit(10).Find(&jobs)
+//	// SELECT * FROM jobs WHERE status = 'pending' ORDER BY id LIMIT 10 FOR UPDATE SKIP LOCKED
+//
+// dialectors declare the unsupported locking strengths with gorm.RowLockingDialectorInterface, the errors are
+// reported instead of building the malformed SQL
 type Locking struct {
 	Strength string
 	Table    Table
@@ -20,6 +35,15 @@ func (locking Locking) Name() string {
 
 // Build build where clause
 func (locking Locking) Build(builder Builder) {
+	if supporter, ok := builder.(lockingSupporter); ok && !supporter.SupportLockingStrength(locking.Strength) {
+		var name string
+		if namer, ok := builder.(dialectNamer); ok {
+			name = namer.Name()
+		}
+		builder.AddError(fmt.Errorf("row locking FOR %s is not supported by %s", locking.Strength, name))
+		return
+	}
+
 	builder.WriteString(locking.Strength)
 	if locking.Table.Name != "" {
 		builder.WriteString(" OF ")
@@ -2,9 +2,13 @@ package clause_test
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/utils/tests"
 )
 
 func TestLocking(t *testing.T) {
@@ -37,3 +41,58 @@ func TestLocking(t *testing.T) {
 		})
 	}
 }
+
+func TestLockingBuildOrder(t *testing.T) {
+	stmt := db.Session(&gorm.Session{DryRun: true}).Model(&tests.User{}).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+		Where("name = ?", "jinzhu").Order("id").Limit(10).Find(&[]tests.User{}).Statement
+	if !regexp.MustCompile(`^SELECT \* FROM .users. WHERE name = .+ ORDER BY id LIMIT .+ FOR UPDATE SKIP LOCKED$`).MatchString(stmt.SQL.String()) {
+		t.Errorf("locking should be built at the end of the statement, got %v", stmt.SQL.String())
+	}
+}
+
+// lockingDialector dialector only supports the locking strengths
+type lockingDialector struct {
+	tests.MockDialector
+	strengths []string
+}
+
+func (d lockingDialector) SupportLockingStrength(strength string) bool {
+	for _, s := range d.strengths {
+		if s == strength {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLockingUnsupportedDialects(t *testing.T) {
+	var (
+		sqlserver = lockingDialector{MockDialector: tests.MockDialector{DialectName: "sqlserver"}}
+		oracle    = lockingDialector{MockDialector: tests.MockDialector{DialectName: "oracle"}, strengths: []string{clause.LockingStrengthUpdate}}
+	)
+
+	results := []struct {
+		Dialector gorm.Dialector
+		Locking   clause.Locking
+		Error     string
+	}{
+		{sqlserver, clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}, "row locking FOR UPDATE is not supported by sqlserver"},
+		{oracle, clause.Locking{Strength: clause.LockingStrengthShare}, "row locking FOR SHARE is not supported by oracle"},
+		{oracle, clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}, ""},
+		{tests.MockDialector{DialectName: "postgres"}, clause.Locking{Strength: clause.LockingStrengthShare, Options: clause.LockingOptionsSkipLocked}, ""},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			sql, _, err := tests.BuildExpression(result.Dialector, result.Locking)
+			if result.Error == "" {
+				if err != nil || !strings.HasPrefix(sql, result.Locking.Strength) {
+					t.Errorf("should build locking, got %v, error %v", sql, err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), result.Error) || sql != "" {
+				t.Errorf("should return error %v, got %v, sql %v", result.Error, err, sql)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package clause
+
+// Merge MERGE INTO target USING source ON match WHEN [NOT] MATCHED THEN action
+type Merge struct {
+	Table  Table      // target table, defaults to current table
+	Using  Expression // source rows, use Values when it is blank
+	Values Values     // source rows built as `(VALUES ...) AS alias (columns)`
+	Alias  string     // source alias, defaults to `excluded`
+	On     []Column   // columns used to match target and source rows
+	When   []MergeWhen
+}
+
+// MergeWhen WHEN [NOT] MATCHED [AND condition] THEN action
+//
+// a matched action updates DoUpdates or deletes the row, a not matched action inserts
+// Columns from the source, all source columns will be inserted if Columns is blank
+type MergeWhen struct {
+	Matched   bool
+	Where     Where
+	Delete    bool
+	DoNothing bool
+	DoUpdates Set
+	Columns   []Column
+}
+
+// Name merge clause name
+func (Merge) Name() string {
+	return "MERGE"
+}
+
+// SourceAlias returns the alias of source rows
+func (merge Merge) SourceAlias() string {
+	if merge.Alias == "" {
+		return "excluded"
+	}
+	return merge.Alias
+}
+
+// Build build merge clause
+func (merge Merge) Build(builder Builder) {
+	alias := merge.SourceAlias()
+	target := merge.Table.Alias
+	if target == "" {
+		target = merge.Table.Name
+	}
+
+	builder.WriteString("INTO ")
+	if merge.Table.Name == "" {
+		builder.WriteQuoted(currentTable)
+		target = CurrentTable
+	} else {
+		builder.WriteQuoted(merge.Table)
+	}
+
+	builder.WriteString(" USING ")
+	if merge.Using != nil {
+		merge.Using.Build(builder)
+	} else {
+		builder.WriteString("(VALUES ")
+		for idx, value := range merge.Values.Values {
+			if idx > 0 {
+				builder.WriteByte(',')
+			}
+
+			builder.WriteByte('(')
+			builder.AddVar(builder, value...)
+			builder.WriteByte(')')
+		}
+		builder.WriteByte(')')
+	}
+
+	builder.WriteString(" AS ")
+	builder.WriteQuoted(alias)
+	if merge.Using == nil {
+		builder.WriteString(" (")
+		for idx, column := range merge.Values.Columns {
+			if idx > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteQuoted(Column{Name: column.Name})
+		}
+		builder.WriteByte(')')
+	}
+
+	builder.WriteString(" ON ")
+	for idx, column := range merge.On {
+		if idx > 0 {
+			builder.WriteString(" AND ")
+		}
+		builder.WriteQuoted(Column{Table: target, Name: column.Name})
+		builder.WriteString(" = ")
+		builder.WriteQuoted(Column{Table: alias, Name: column.Name})
+	}
+
+	for _, when := range merge.When {
+		builder.WriteString(" WHEN ")
+		if !when.Matched {
+			builder.WriteString("NOT ")
+		}
+		builder.WriteString("MATCHED")
+
+		if len(when.Where.Exprs) > 0 {
+			builder.WriteString(" AND ")
+			when.Where.Build(builder)
+		}
+
+		builder.WriteString(" THEN ")
+		switch {
+		case when.DoNothing:
+			builder.WriteString("DO NOTHING")
+		case when.Matched && when.Delete:
+			builder.WriteString("DELETE")
+		case when.Matched:
+			builder.WriteString("UPDATE SET ")
+			when.DoUpdates.Build(builder)
+		default:
+			columns := when.Columns
+			if len(columns) == 0 {
+				columns = merge.Values.Columns
+			}
+
+			builder.WriteString("INSERT (")
+			for idx, column := range columns {
+				if idx > 0 {
+					builder.WriteByte(',')
+				}
+				builder.WriteQuoted(Column{Name: column.Name})
+			}
+			builder.WriteString(") VALUES (")
+			for idx, column := range columns {
+				if idx > 0 {
+					builder.WriteByte(',')
+				}
+				builder.WriteQuoted(Column{Table: alias, Name: column.Name})
+			}
+			builder.WriteByte(')')
+		}
+	}
+}
+
+// MergeClause merge merge clauses
+func (merge Merge) MergeClause(clause *Clause) {
+	clause.Expression = merge
+}
@@ -0,0 +1,65 @@
+package clause_test
+
+import (
+	"fmt"
+	"testing"
+
+	"gorm.io/gorm/clause"
+)
+
+func TestMerge(t *testing.T) {
+	results := []struct {
+		Clauses []clause.Interface
+		Result  string
+		Vars    []interface{}
+	}{
+		{
+			[]clause.Interface{clause.Merge{
+				Values: clause.Values{
+					Columns: []clause.Column{{Name: "id"}, {Name: "name"}},
+					Values:  [][]interface{}{{1, "jinzhu"}, {2, "jinzhu2"}},
+				},
+				On: []clause.Column{{Name: "id"}},
+				When: []clause.MergeWhen{
+					{Matched: true, DoUpdates: clause.AssignmentColumns([]string{"name"})},
+					{},
+				},
+			}},
+			"MERGE INTO `users` USING (VALUES (?,?),(?,?)) AS `excluded` (`id`,`name`) ON `users`.`id` = `excluded`.`id` WHEN MATCHED THEN UPDATE SET `name`=`excluded`.`name` WHEN NOT MATCHED THEN INSERT (`id`,`name`) VALUES (`excluded`.`id`,`excluded`.`name`)",
+			[]interface{}{1, "jinzhu", 2, "jinzhu2"},
+		},
+		{
+			[]clause.Interface{clause.Merge{
+				Table: clause.Table{Name: "products", Alias: "p"},
+				Using: clause.Expr{SQL: "?", Vars: []interface{}{clause.Table{Name: "new_products"}}},
+				Alias: "n",
+				On:    []clause.Column{{Name: "code"}},
+				When: []clause.MergeWhen{
+					{Matched: true, Where: clause.Where{Exprs: []clause.Expression{clause.Eq{Column: clause.Column{Table: "n", Name: "deleted"}, Value: true}}}, Delete: true},
+					{Matched: true, DoUpdates: clause.Set{{Column: clause.Column{Name: "price"}, Value: clause.Column{Table: "n", Name: "price"}}}},
+					{Columns: []clause.Column{{Name: "code"}, {Name: "price"}}},
+				},
+			}},
+			"MERGE INTO `products` `p` USING `new_products` AS `n` ON `p`.`code` = `n`.`code` WHEN MATCHED AND `n`.`deleted` = ? THEN DELETE WHEN MATCHED THEN UPDATE SET `price`=`n`.`price` WHEN NOT MATCHED THEN INSERT (`code`,`price`) VALUES (`n`.`code`,`n`.`price`)",
+			[]interface{}{true},
+		},
+		{
+			[]clause.Interface{clause.Merge{
+				Values: clause.Values{
+					Columns: []clause.Column{{Name: "id"}},
+					Values:  [][]interface{}{{1}},
+				},
+				On:   []clause.Column{{Name: "id"}},
+				When: []clause.MergeWhen{{Matched: true, DoNothing: true}, {}},
+			}},
+			"MERGE INTO `users` USING (VALUES (?)) AS `excluded` (`id`) ON `users`.`id` = `excluded`.`id` WHEN MATCHED THEN DO NOTHING WHEN NOT MATCHED THEN INSERT (`id`) VALUES (`excluded`.`id`)",
+			[]interface{}{1},
+		},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			checkBuildClauses(t, result.Clauses, result.Result, result.Vars)
+		})
+	}
+}
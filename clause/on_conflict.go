@@ -1,13 +1,38 @@
 package clause
 
 type OnConflict struct {
-	Columns      []Column
+	Columns []Column
+	// Where the predicate of DO UPDATE, conflicting rows not matched are left unchanged, e.g:
+	//
+	//	clause.OnConflict{UpdateAll: true, Where: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "excluded.version > users.version"}}}}
+	//	// ON CONFLICT (`id`) DO UPDATE SET ... WHERE excluded.version > users.version
 	Where        Where
 	TargetWhere  Where
 	OnConstraint string
-	DoNothing    bool
-	DoUpdates    Set
-	UpdateAll    bool
+	// DoNothing skip the conflicting rows, set `gorm:record_skipped_rows` to find out the skipped rows, e.g:
+	//
+	//	tx := db.Set("gorm:record_skipped_rows", true).Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "code"}}, DoNothing: true}).Create(&products)
+	//	skipped, _ := tx.Get("gorm:skipped_rows") // []Product
+	//
+	// it requires RETURNING support, the conflict columns are added to RETURNING, the returned rows are matched
+	// with the inserting rows by the conflict columns, so the conflict columns should be provided by the rows
+	DoNothing bool
+	DoUpdates Set
+	UpdateAll bool
+	// UpdateProvided update the columns provided in the insert values only,
+	// zero value fields of structs are treated as not provided
+	UpdateProvided bool
+	// KeepExistingOnNull keep the existing values of nullable columns if the inserting values are NULL when
+	// UpdateAll or UpdateProvided, builds `column=COALESCE(excluded.column,table.column)`, columns with
+	// `not null` tag are still overwritten
+	KeepExistingOnNull bool
+	// Omit columns or field names never overwritten by UpdateAll and UpdateProvided, e.g: created_at, created_by
+	Omit []string
+	// Resolve resolve conflicts in application, existing conflicting rows are queried before inserting, Resolve
+	// is called with pointers of the incoming and the existing row, and should change the incoming row to the
+	// merged result, which will be written with DO UPDATE. It costs an extra round-trip, run it in a transaction
+	// with a proper isolation level to avoid rows changed between the query and the upsert
+	Resolve func(incoming, existing interface{}) error
 }
 
 func (OnConflict) Name() string {
@@ -44,12 +69,13 @@ func (onConflict OnConflict) Build(builder Builder) {
 	} else {
 		builder.WriteString("DO UPDATE SET ")
 		onConflict.DoUpdates.Build(builder)
-	}
 
-	if len(onConflict.Where.Exprs) > 0 {
-		builder.WriteString(" WHERE ")
-		onConflict.Where.Build(builder)
-		builder.WriteByte(' ')
+		// the predicate only applies to DO UPDATE, rows not matched are left unchanged
+		if len(onConflict.Where.Exprs) > 0 {
+			builder.WriteString(" WHERE ")
+			onConflict.Where.Build(builder)
+			builder.WriteByte(' ')
+		}
 	}
 }
 
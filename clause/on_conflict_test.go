@@ -0,0 +1,33 @@
+package clause_test
+
+import (
+	"fmt"
+	"testing"
+
+	"gorm.io/gorm/clause"
+)
+
+func TestOnConflict(t *testing.T) {
+	where := clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "excluded.age > ?", Vars: []interface{}{18}}}}
+	results := []struct {
+		Clauses []clause.Interface
+		Result  string
+		Vars    []interface{}
+	}{
+		{
+			[]clause.Interface{clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, DoUpdates: clause.AssignmentColumns([]string{"name"}), Where: where}},
+			"ON CONFLICT (`id`) DO UPDATE SET `name`=`excluded`.`name` WHERE excluded.age > ?",
+			[]interface{}{18},
+		},
+		{
+			[]clause.Interface{clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, DoNothing: true, Where: where}},
+			"ON CONFLICT (`id`) DO NOTHING", nil,
+		},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			checkBuildClauses(t, result.Clauses, result.Result, result.Vars)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package clause
+
+import (
+	"strconv"
+	"strings"
+)
+
+// OnDuplicateKeyUpdate build ON CONFLICT clause as MySQL's ON DUPLICATE KEY UPDATE, it is registered as the ON CONFLICT
+// clause builder of the dialector implementing gorm.RowAliasDialectorInterface
+//
+// the row alias form `INSERT ... AS new ON DUPLICATE KEY UPDATE col=new.col` is used if RowAlias is not blank,
+// otherwise the legacy form `ON DUPLICATE KEY UPDATE col=VALUES(col)`, which is deprecated since MySQL 8.0.20
+type OnDuplicateKeyUpdate struct {
+	RowAlias string
+}
+
+// DefaultRowAlias the row alias used by dialectors supporting row alias in upsert
+const DefaultRowAlias = "new"
+
+// SupportRowAlias check if the MySQL server version supports row alias in upsert, row alias is supported by
+// MySQL 8.0.20+, but not older versions or MariaDB, dialectors could use it to implement gorm.RowAliasDialectorInterface
+func SupportRowAlias(serverVersion string) bool {
+	if strings.Contains(strings.ToLower(serverVersion), "mariadb") {
+		return false
+	}
+
+	var parts [3]int
+	for idx, part := range strings.SplitN(serverVersion, ".", 3) {
+		end := 0
+		for end < len(part) && part[end] >= '0' && part[end] <= '9' {
+			end++
+		}
+		parts[idx], _ = strconv.Atoi(part[:end])
+	}
+
+	if parts[0] != 8 {
+		return parts[0] > 8
+	}
+	return parts[1] > 0 || parts[2] >= 20
+}
+
+// Build build ON DUPLICATE KEY UPDATE clause
+func (o OnDuplicateKeyUpdate) Build(c Clause, builder Builder) {
+	onConflict, ok := c.Expression.(OnConflict)
+	if !ok {
+		c.Build(builder)
+		return
+	}
+
+	if o.RowAlias != "" {
+		builder.WriteString("AS ")
+		builder.WriteQuoted(o.RowAlias)
+		builder.WriteByte(' ')
+	}
+
+	builder.WriteString("ON DUPLICATE KEY UPDATE ")
+	if len(onConflict.DoUpdates) == 0 {
+		// DO NOTHING, update primary key with itself
+		builder.WriteQuoted(Column{Name: PrimaryKey})
+		builder.WriteByte('=')
+		builder.WriteQuoted(Column{Name: PrimaryKey})
+		return
+	}
+
+	for idx, assignment := range onConflict.DoUpdates {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+
+		builder.WriteQuoted(assignment.Column)
+		builder.WriteByte('=')
+		switch value := assignment.Value.(type) {
+		case Column:
+			if value.Table == "excluded" {
+				o.excluded(value.Name).Build(builder)
+			} else {
+				builder.AddVar(builder, value)
+			}
+		case extremum:
+			value.build(builder, o.excluded(value.Column))
+		case keepOnNull:
+			value.build(builder, o.excluded(value.Column))
+		default:
+			builder.AddVar(builder, assignment.Value)
+		}
+	}
+}
+
+// excluded returns the reference of the inserting value of column
+func (o OnDuplicateKeyUpdate) excluded(column string) Expression {
+	if o.RowAlias != "" {
+		return Expr{SQL: "?", Vars: []interface{}{Column{Table: o.RowAlias, Name: column}}}
+	}
+	return Expr{SQL: "VALUES(?)", Vars: []interface{}{Column{Name: column}}}
+}
@@ -0,0 +1,103 @@
+package clause_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"gorm.io/gorm/utils/tests"
+)
+
+// rowAliasDialector dialector builds ON CONFLICT as ON DUPLICATE KEY UPDATE
+type rowAliasDialector struct {
+	tests.DummyDialector
+	supportRowAlias bool
+}
+
+func (d rowAliasDialector) SupportRowAlias() bool {
+	return d.supportRowAlias
+}
+
+func TestOnDuplicateKeyUpdate(t *testing.T) {
+	values := clause.Values{Columns: []clause.Column{{Name: "name"}, {Name: "age"}}, Values: [][]interface{}{{"jinzhu", 18}}}
+	results := []struct {
+		RowAlias   bool
+		OnConflict clause.OnConflict
+		Result     string
+	}{
+		{
+			false,
+			clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"name", "age"})},
+			"INSERT INTO `users` (`name`,`age`) VALUES (?,?) ON DUPLICATE KEY UPDATE `name`=VALUES(`name`),`age`=VALUES(`age`)",
+		},
+		{
+			true,
+			clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"name", "age"})},
+			"INSERT INTO `users` (`name`,`age`) VALUES (?,?) AS `new` ON DUPLICATE KEY UPDATE `name`=`new`.`name`,`age`=`new`.`age`",
+		},
+		{
+			true,
+			clause.OnConflict{DoUpdates: clause.Set{{Column: clause.Column{Name: "age"}, Value: 20}}},
+			"INSERT INTO `users` (`name`,`age`) VALUES (?,?) AS `new` ON DUPLICATE KEY UPDATE `age`=?",
+		},
+		{
+			false,
+			clause.OnConflict{DoUpdates: clause.Set{clause.AssignmentGreatest("age")}},
+			"INSERT INTO `users` (`name`,`age`) VALUES (?,?) ON DUPLICATE KEY UPDATE `age`=GREATEST(`users`.`age`,VALUES(`age`))",
+		},
+		{
+			true,
+			clause.OnConflict{DoUpdates: clause.Set{clause.AssignmentLeast("age")}},
+			"INSERT INTO `users` (`name`,`age`) VALUES (?,?) AS `new` ON DUPLICATE KEY UPDATE `age`=LEAST(`users`.`age`,`new`.`age`)",
+		},
+		{
+			false,
+			clause.OnConflict{DoUpdates: clause.Set{clause.AssignmentKeepOnNull("name")}},
+			"INSERT INTO `users` (`name`,`age`) VALUES (?,?) ON DUPLICATE KEY UPDATE `name`=COALESCE(VALUES(`name`),`users`.`name`)",
+		},
+		{
+			false,
+			clause.OnConflict{DoNothing: true},
+			"INSERT INTO `users` (`name`,`age`) VALUES (?,?) ON DUPLICATE KEY UPDATE `id`=`id`",
+		},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			db, _ := gorm.Open(rowAliasDialector{supportRowAlias: result.RowAlias}, &gorm.Config{})
+			user, _ := schema.Parse(&tests.User{}, &sync.Map{}, db.NamingStrategy)
+			stmt := gorm.Statement{DB: db, Table: user.Table, Schema: user, Clauses: map[string]clause.Clause{}}
+			stmt.AddClause(clause.Insert{})
+			stmt.AddClause(values)
+			stmt.AddClause(result.OnConflict)
+			stmt.Build("INSERT", "VALUES", "ON CONFLICT")
+
+			if sql := strings.TrimSpace(stmt.SQL.String()); sql != result.Result {
+				t.Errorf("SQL expects %v got %v", result.Result, sql)
+			}
+		})
+	}
+}
+
+func TestSupportRowAlias(t *testing.T) {
+	versions := map[string]bool{
+		"5.7.44":         false,
+		"8.0.19":         false,
+		"8.0.20":         true,
+		"8.0.36-log":     true,
+		"8.4.0":          true,
+		"9.0.1":          true,
+		"10.5.8-MariaDB": false,
+		"":               false,
+	}
+
+	for version, expected := range versions {
+		if clause.SupportRowAlias(version) != expected {
+			t.Errorf("version %q support row alias should be %v", version, expected)
+		}
+	}
+}
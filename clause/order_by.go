@@ -1,9 +1,36 @@
 package clause
 
+import (
+	"fmt"
+	"strings"
+)
+
+// NullsOrder null ordering of order by column
+type NullsOrder string
+
+const (
+	NullsFirst NullsOrder = "FIRST"
+	NullsLast  NullsOrder = "LAST"
+)
+
+// nullsOrderDialects dialects support NULLS FIRST/LAST, it is emulated with CASE WHEN ... IS NULL for other dialects,
+// used if the dialector doesn't implement gorm.NullsOrderDialectorInterface
+var nullsOrderDialects = map[string]bool{
+	"postgres": true,
+	"sqlite":   true,
+	"oracle":   true,
+}
+
+// nullsOrderSupporter *gorm.Statement exposes whether NULLS FIRST/LAST is supported by the dialector
+type nullsOrderSupporter interface {
+	SupportNullsOrder() (supported bool, ok bool)
+}
+
 type OrderByColumn struct {
 	Column  Column
 	Desc    bool
 	Reorder bool
+	Nulls   NullsOrder
 }
 
 type OrderBy struct {
@@ -21,17 +48,73 @@ func (orderBy OrderBy) Build(builder Builder) {
 	if orderBy.Expression != nil {
 		orderBy.Expression.Build(builder)
 	} else {
+		nativeNulls := supportNullsOrder(builder)
+		defaultNulls := defaultNullsOrder(builder)
 		for idx, column := range orderBy.Columns {
 			if idx > 0 {
 				builder.WriteByte(',')
 			}
 
+			nulls := column.Nulls
+			if nulls == "" && !(column.Column.Raw && strings.Contains(strings.ToUpper(column.Column.Name), "NULLS ")) {
+				nulls = defaultNulls
+			}
+
+			checkOrdinal(builder, column.Column)
+			if nulls != "" && !nativeNulls {
+				// emulate NULLS FIRST/LAST by ordering the NULL check first, raw columns might contain the direction
+				if !column.Column.Raw {
+					builder.WriteString("CASE WHEN ")
+					builder.WriteQuoted(column.Column)
+					if nulls == NullsFirst {
+						builder.WriteString(" IS NULL THEN 0 ELSE 1 END,")
+					} else {
+						builder.WriteString(" IS NULL THEN 1 ELSE 0 END,")
+					}
+				} else if column.Nulls != "" {
+					builder.AddError(fmt.Errorf("NULLS %s of raw column %s is not supported by %s", column.Nulls, column.Column.Name, builder.(dialectNamer).Name()))
+				}
+				nulls = ""
+			}
+
 			builder.WriteQuoted(column.Column)
 			if column.Desc {
 				builder.WriteString(" DESC")
 			}
+
+			if nulls != "" {
+				builder.WriteString(" NULLS ")
+				builder.WriteString(string(nulls))
+			}
+		}
+	}
+}
+
+// supportNullsOrder whether NULLS FIRST/LAST is supported by the dialect, it is emulated otherwise
+func supportNullsOrder(builder Builder) bool {
+	if supporter, ok := builder.(nullsOrderSupporter); ok {
+		if supported, ok := supporter.SupportNullsOrder(); ok {
+			return supported
+		}
+	}
+
+	namer, ok := builder.(dialectNamer)
+	return !ok || nullsOrderDialects[namer.Name()]
+}
+
+// defaultNullsOrder returns the statement level null ordering set by `gorm:nulls_order`
+func defaultNullsOrder(builder Builder) NullsOrder {
+	if getter, ok := builder.(settingGetter); ok {
+		if v, ok := getter.Setting("gorm:nulls_order"); ok {
+			switch nulls := v.(type) {
+			case NullsOrder:
+				return nulls
+			case string:
+				return NullsOrder(strings.ToUpper(nulls))
+			}
 		}
 	}
+	return ""
 }
 
 // MergeClause merge order by clauses
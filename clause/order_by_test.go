@@ -2,9 +2,12 @@ package clause_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/utils/tests"
 )
 
 func TestOrderBy(t *testing.T) {
@@ -19,6 +22,12 @@ func TestOrderBy(t *testing.T) {
 			}},
 			"SELECT * FROM `users` ORDER BY `users`.`id` DESC", nil,
 		},
+		{
+			[]clause.Interface{clause.Select{}, clause.From{}, clause.OrderBy{
+				Columns: []clause.OrderByColumn{{Column: clause.Ordinal(3), Desc: true}, {Column: clause.Ordinal(1)}},
+			}},
+			"SELECT * FROM `users` ORDER BY 3 DESC,1", nil,
+		},
 		{
 			[]clause.Interface{
 				clause.Select{}, clause.From{}, clause.OrderBy{
@@ -56,3 +65,49 @@ func TestOrderBy(t *testing.T) {
 		})
 	}
 }
+
+// nullsOrderDialector dialector supports NULLS FIRST/LAST without a known dialect name
+type nullsOrderDialector struct {
+	tests.MockDialector
+}
+
+func (nullsOrderDialector) SupportNullsOrder() bool {
+	return true
+}
+
+func TestOrderByNulls(t *testing.T) {
+	orderBy := clause.OrderBy{Columns: []clause.OrderByColumn{
+		{Column: clause.Column{Name: "age"}, Desc: true},
+		{Column: clause.Column{Name: "name"}, Nulls: clause.NullsFirst},
+		{Column: clause.Column{Name: "email DESC NULLS FIRST", Raw: true}},
+	}}
+
+	for _, result := range []struct {
+		Dialector gorm.Dialector
+		Nulls     interface{}
+		Result    string
+	}{
+		{tests.MockDialector{DialectName: "postgres"}, nil, "`age` DESC,`name` NULLS FIRST,email DESC NULLS FIRST"},
+		{tests.MockDialector{DialectName: "postgres"}, clause.NullsLast, "`age` DESC NULLS LAST,`name` NULLS FIRST,email DESC NULLS FIRST"},
+		{tests.MockDialector{DialectName: "sqlite"}, "last", "`age` DESC NULLS LAST,`name` NULLS FIRST,email DESC NULLS FIRST"},
+		{tests.MockDialector{DialectName: "mysql"}, nil, "`age` DESC,CASE WHEN `name` IS NULL THEN 0 ELSE 1 END,`name`,email DESC NULLS FIRST"},
+		{tests.MockDialector{DialectName: "mysql"}, clause.NullsLast, "CASE WHEN `age` IS NULL THEN 1 ELSE 0 END,`age` DESC,CASE WHEN `name` IS NULL THEN 0 ELSE 1 END,`name`,email DESC NULLS FIRST"},
+		{nullsOrderDialector{MockDialector: tests.MockDialector{DialectName: "gaussdb"}}, clause.NullsLast, "`age` DESC NULLS LAST,`name` NULLS FIRST,email DESC NULLS FIRST"},
+	} {
+		stmt := tests.NewStatement(result.Dialector)
+		if result.Nulls != nil {
+			stmt.Settings.Store("gorm:nulls_order", result.Nulls)
+		}
+
+		orderBy.Build(stmt)
+		if sql := stmt.SQL.String(); sql != result.Result {
+			t.Errorf("%v: SQL expects %v got %v", result.Dialector.Name(), result.Result, sql)
+		}
+	}
+
+	stmt := tests.NewStatement(tests.MockDialector{DialectName: "sqlserver"})
+	clause.OrderBy{Columns: []clause.OrderByColumn{{Column: clause.Column{Name: "age desc", Raw: true}, Nulls: clause.NullsLast}}}.Build(stmt)
+	if err := stmt.Error; err == nil || !strings.Contains(err.Error(), "not supported by sqlserver") {
+		t.Errorf("should return error for NULLS ordering of raw column on sqlserver, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package clause
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ordinalGroupByUnsupportedDialects dialects disallow ordinals in GROUP BY, used if the dialector doesn't implement gorm.OrdinalGroupByDialectorInterface
+var ordinalGroupByUnsupportedDialects = map[string]bool{
+	"sqlserver": true,
+	"oracle":    true,
+}
+
+// ordinalGroupBySupporter *gorm.Statement exposes whether ordinals in GROUP BY are supported by the dialector
+type ordinalGroupBySupporter interface {
+	SupportOrdinalGroupBy() (supported bool, ok bool)
+}
+
+// Ordinal reference the column of the select list by position, it is written as the bare integer, e.g:
+//
+//	clause.GroupBy{Columns: []clause.Column{clause.Ordinal(1), clause.Ordinal(2)}} // GROUP BY 1,2
+//	clause.OrderByColumn{Column: clause.Ordinal(3), Desc: true}                    // ORDER BY 3 DESC
+func Ordinal(position int) Column {
+	return Column{Name: strconv.Itoa(position), Raw: true}
+}
+
+// ordinalPosition returns the position if the column is a reference of the select list
+func ordinalPosition(column Column) (int, bool) {
+	if !column.Raw || column.Table != "" || column.Alias != "" {
+		return 0, false
+	}
+
+	position, err := strconv.Atoi(column.Name)
+	return position, err == nil
+}
+
+// checkOrdinal validate the position if the column is an ordinal, returns whether it is an ordinal
+func checkOrdinal(builder Builder, column Column) bool {
+	position, ok := ordinalPosition(column)
+	if ok && position <= 0 {
+		builder.AddError(fmt.Errorf("ordinal position should be positive, got %d", position))
+	}
+	return ok
+}
+
+// warnOrdinalGroupBy warn if the dialect disallows ordinals in GROUP BY
+func warnOrdinalGroupBy(builder Builder) {
+	namer, ok := builder.(dialectNamer)
+	if !ok {
+		return
+	}
+
+	unsupported := ordinalGroupByUnsupportedDialects[namer.Name()]
+	if supporter, ok := builder.(ordinalGroupBySupporter); ok {
+		if supported, ok := supporter.SupportOrdinalGroupBy(); ok {
+			unsupported = !supported
+		}
+	}
+
+	if w, ok := builder.(warner); ok && unsupported {
+		w.Warn("ordinals in GROUP BY are not supported by %s, use the expressions instead", namer.Name())
+	}
+}
@@ -0,0 +1,109 @@
+package clause_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils/tests"
+)
+
+type ordinalLogWriter struct {
+	logs []string
+}
+
+func (w *ordinalLogWriter) Printf(format string, args ...interface{}) {
+	w.logs = append(w.logs, fmt.Sprintf(format, args...))
+}
+
+// ordinalGroupByDialector dialector disallows ordinals in GROUP BY without a known dialect name
+type ordinalGroupByDialector struct {
+	tests.MockDialector
+}
+
+func (ordinalGroupByDialector) SupportOrdinalGroupBy() bool {
+	return false
+}
+
+func TestOrdinal(t *testing.T) {
+	results := []struct {
+		Dialect   string
+		Dialector gorm.Dialector
+		Expr      clause.Expression
+		Result    string
+		HasError  bool
+		Warned    bool
+	}{
+		{
+			Dialect: "postgres",
+			Expr:    clause.GroupBy{Columns: []clause.Column{clause.Ordinal(1), {Name: "name"}}},
+			Result:  "1,`name`",
+		},
+		{
+			Dialect: "sqlserver",
+			Expr:    clause.GroupBy{Columns: []clause.Column{clause.Ordinal(1)}},
+			Result:  "1",
+			Warned:  true,
+		},
+		{
+			Dialect: "sqlserver",
+			Expr:    clause.OrderBy{Columns: []clause.OrderByColumn{{Column: clause.Ordinal(2), Desc: true}}},
+			Result:  "2 DESC",
+		},
+		{
+			Dialector: ordinalGroupByDialector{MockDialector: tests.MockDialector{DialectName: "db2"}},
+			Expr:      clause.GroupBy{Columns: []clause.Column{clause.Ordinal(1)}},
+			Result:    "1",
+			Warned:    true,
+		},
+		{
+			Dialect:  "postgres",
+			Expr:     clause.GroupBy{Columns: []clause.Column{clause.Ordinal(0)}},
+			HasError: true,
+		},
+		{
+			Dialect:  "postgres",
+			Expr:     clause.OrderBy{Columns: []clause.OrderByColumn{{Column: clause.Ordinal(-1)}}},
+			HasError: true,
+		},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			writer := &ordinalLogWriter{}
+			dialector := result.Dialector
+			if dialector == nil {
+				dialector = tests.MockDialector{DialectName: result.Dialect}
+			}
+			db, err := gorm.Open(dialector, &gorm.Config{
+				Logger: logger.New(writer, logger.Config{LogLevel: logger.Warn}),
+			})
+			if err != nil {
+				t.Fatalf("failed to open db, got %v", err)
+			}
+
+			stmt := &gorm.Statement{DB: db, Clauses: map[string]clause.Clause{}}
+			result.Expr.Build(stmt)
+
+			if result.HasError {
+				if stmt.Error == nil {
+					t.Errorf("expects error, got sql %v", stmt.SQL.String())
+				}
+				return
+			}
+
+			if stmt.Error != nil {
+				t.Fatalf("failed to build, got %v", stmt.Error)
+			}
+			if sql := stmt.SQL.String(); sql != result.Result {
+				t.Errorf("SQL expects %v got %v", result.Result, sql)
+			}
+			if warned := len(writer.logs) > 0 && strings.Contains(writer.logs[0], "ordinals in GROUP BY"); warned != result.Warned {
+				t.Errorf("warned expects %v, got logs %v", result.Warned, writer.logs)
+			}
+		})
+	}
+}
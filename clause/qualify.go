@@ -0,0 +1,39 @@
+package clause
+
+// Qualify filter the rows by the results of window functions, emits `QUALIFY conditions`
+//
+//	db.Select("*, row_number() OVER (PARTITION BY company_id ORDER BY age DESC) AS rn").
+//		Clauses(clause.Qualify{Exprs: []clause.Expression{clause.Eq{Column: "rn", Value: 1}}}).Find(&users)
+//	// SELECT *, row_number() OVER (PARTITION BY company_id ORDER BY age DESC) AS rn FROM users QUALIFY rn = 1
+//
+// 方言不支持 QUALIFY 时（未实现 gorm.QualifyDialectorInterface），查询会被改写为子查询，在外层 WHERE 中过滤，
+// ORDER BY、LIMIT 作用于外层查询：
+//
+//	// SELECT * FROM ( SELECT *, row_number() OVER (...) AS rn FROM users ) AS users WHERE rn = 1
+//
+// 因此条件应当引用 SELECT 中窗口函数的别名，而不是窗口函数本身。
+type Qualify struct {
+	Exprs []Expression
+}
+
+// Name qualify clause name
+func (qualify Qualify) Name() string {
+	return "QUALIFY"
+}
+
+// Build build qualify clause
+func (qualify Qualify) Build(builder Builder) {
+	Where{Exprs: qualify.Exprs}.Build(builder)
+}
+
+// MergeClause merge qualify clauses
+func (qualify Qualify) MergeClause(clause *Clause) {
+	if q, ok := clause.Expression.(Qualify); ok {
+		exprs := make([]Expression, len(q.Exprs)+len(qualify.Exprs))
+		copy(exprs, q.Exprs)
+		copy(exprs[len(q.Exprs):], qualify.Exprs)
+		qualify.Exprs = exprs
+	}
+
+	clause.Expression = qualify
+}
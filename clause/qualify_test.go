@@ -0,0 +1,39 @@
+package clause_test
+
+import (
+	"fmt"
+	"testing"
+
+	"gorm.io/gorm/clause"
+)
+
+func TestQualify(t *testing.T) {
+	results := []struct {
+		Clauses []clause.Interface
+		Result  string
+		Vars    []interface{}
+	}{
+		{
+			[]clause.Interface{clause.Select{}, clause.From{}, clause.Qualify{
+				Exprs: []clause.Expression{clause.Eq{Column: "rn", Value: 1}},
+			}},
+			"SELECT * FROM `users` QUALIFY `rn` = ?",
+			[]interface{}{1},
+		},
+		{
+			[]clause.Interface{clause.Select{}, clause.From{}, clause.Qualify{
+				Exprs: []clause.Expression{clause.Lte{Column: "rn", Value: 3}},
+			}, clause.Qualify{
+				Exprs: []clause.Expression{clause.Or(clause.Eq{Column: "age", Value: 18})},
+			}},
+			"SELECT * FROM `users` QUALIFY `rn` <= ? OR `age` = ?",
+			[]interface{}{3, 18},
+		},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			checkBuildClauses(t, result.Clauses, result.Result, result.Vars)
+		})
+	}
+}
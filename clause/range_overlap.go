@@ -0,0 +1,111 @@
+package clause
+
+import "fmt"
+
+// range bounds, `[` and `]` include the boundary, `(` and `)` exclude it
+const (
+	BoundsInclusiveExclusive = "[)"
+	BoundsInclusive          = "[]"
+	BoundsExclusiveInclusive = "(]"
+	BoundsExclusive          = "()"
+)
+
+var rangeBounds = map[string]bool{
+	BoundsInclusiveExclusive: true,
+	BoundsInclusive:          true,
+	BoundsExclusiveInclusive: true,
+	BoundsExclusive:          true,
+}
+
+// Overlap time range overlap condition, both ranges share the same boundary semantics, defaults to `[)`
+//
+//	db.Where(clause.RangeOverlap("start_at", "end_at", start, end)).Find(&bookings)
+//	// SELECT * FROM "bookings" WHERE ("start_at" < $1 AND $2 < "end_at")
+//
+//	db.Where(clause.RangeColumnOverlap("during", start, end)).Find(&bookings)
+//	// SELECT * FROM "bookings" WHERE "during" && tstzrange($1,$2,'[)')
+type Overlap struct {
+	StartColumn interface{}
+	EndColumn   interface{}
+	RangeColumn interface{} // native range column, only supported by postgres
+	RangeType   string      // range constructor used with RangeColumn, defaults to tstzrange
+	Start       interface{}
+	End         interface{}
+	Bounds      string
+}
+
+// RangeOverlap overlap condition of the range stored in startCol and endCol with [start, end)
+func RangeOverlap(startCol, endCol interface{}, start, end interface{}) Overlap {
+	return Overlap{StartColumn: startCol, EndColumn: endCol, Start: start, End: end}
+}
+
+// RangeColumnOverlap overlap condition of the native range column with [start, end), uses the `&&` operator
+func RangeColumnOverlap(rangeCol interface{}, start, end interface{}) Overlap {
+	return Overlap{RangeColumn: rangeCol, Start: start, End: end}
+}
+
+// WithBounds set the boundary semantics, e.g: clause.BoundsInclusive
+func (overlap Overlap) WithBounds(bounds string) Overlap {
+	overlap.Bounds = bounds
+	return overlap
+}
+
+// Build build range overlap condition
+func (overlap Overlap) Build(builder Builder) {
+	bounds := overlap.Bounds
+	if bounds == "" {
+		bounds = BoundsInclusiveExclusive
+	} else if !rangeBounds[bounds] {
+		builder.AddError(fmt.Errorf("invalid range bounds %q", bounds))
+		return
+	}
+
+	if overlap.RangeColumn != nil {
+		if requirePostgres(builder, "range column overlap") {
+			rangeType := overlap.RangeType
+			if rangeType == "" {
+				rangeType = "tstzrange"
+			}
+
+			writeDocument(builder, overlap.RangeColumn)
+			builder.WriteString(" && ")
+			builder.WriteString(rangeType)
+			builder.WriteByte('(')
+			builder.AddVar(builder, overlap.Start, overlap.End)
+			builder.WriteString(",'")
+			builder.WriteString(bounds)
+			builder.WriteString("')")
+		}
+		return
+	}
+
+	// closed ranges overlap when they share a boundary, others must cross it
+	op := " < "
+	if bounds == BoundsInclusive {
+		op = " <= "
+	}
+
+	// wrapped in parentheses to keep the precedence when combined with OR conditions
+	builder.WriteByte('(')
+	writeDocument(builder, overlap.StartColumn)
+	builder.WriteString(op)
+	builder.AddVar(builder, overlap.End)
+	builder.WriteString(" AND ")
+	builder.AddVar(builder, overlap.Start)
+	builder.WriteString(op)
+	writeDocument(builder, overlap.EndColumn)
+	builder.WriteByte(')')
+}
+
+// NegationBuild build negation of the range overlap condition
+func (overlap Overlap) NegationBuild(builder Builder) {
+	if overlap.RangeColumn == nil {
+		builder.WriteString("NOT ")
+		overlap.Build(builder)
+		return
+	}
+
+	builder.WriteString("NOT (")
+	overlap.Build(builder)
+	builder.WriteByte(')')
+}
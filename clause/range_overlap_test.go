@@ -0,0 +1,74 @@
+package clause_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/utils/tests"
+)
+
+func TestRangeOverlap(t *testing.T) {
+	pgDialector := tests.MockDialector{DialectName: "postgres", QuoteToFunc: tests.DoubleQuoteTo, BindVarToFunc: tests.DollarBindVarTo}
+	start, end := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	results := []struct {
+		Expr   clause.Expression
+		Result string
+		Vars   []interface{}
+	}{
+		{
+			Expr:   clause.RangeOverlap("start_at", "end_at", start, end),
+			Result: `("start_at" < $1 AND $2 < "end_at")`,
+			Vars:   []interface{}{end, start},
+		},
+		{
+			Expr:   clause.RangeOverlap(clause.Column{Table: "bookings", Name: "start_at"}, clause.Column{Table: "bookings", Name: "end_at"}, start, end).WithBounds(clause.BoundsInclusive),
+			Result: `("bookings"."start_at" <= $1 AND $2 <= "bookings"."end_at")`,
+			Vars:   []interface{}{end, start},
+		},
+		{
+			Expr:   clause.Not(clause.RangeOverlap("start_at", "end_at", start, end)),
+			Result: `NOT ("start_at" < $1 AND $2 < "end_at")`,
+			Vars:   []interface{}{end, start},
+		},
+		{
+			Expr:   clause.RangeColumnOverlap("during", start, end),
+			Result: `"during" && tstzrange($1,$2,'[)')`,
+			Vars:   []interface{}{start, end},
+		},
+		{
+			Expr:   clause.Overlap{RangeColumn: "days", RangeType: "daterange", Start: "2024-01-01", End: "2024-01-31", Bounds: clause.BoundsInclusive},
+			Result: `"days" && daterange($1,$2,'[]')`,
+			Vars:   []interface{}{"2024-01-01", "2024-01-31"},
+		},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			sql, vars, err := tests.BuildExpression(pgDialector, result.Expr)
+			if err != nil {
+				t.Fatalf("failed to build, got error %v", err)
+			}
+
+			if sql != result.Result {
+				t.Errorf("SQL expects %v got %v", result.Result, sql)
+			}
+
+			if fmt.Sprint(vars) != fmt.Sprint(result.Vars) {
+				t.Errorf("Vars expects %+v got %+v", result.Vars, vars)
+			}
+		})
+	}
+
+	if _, _, err := tests.BuildExpression(pgDialector, clause.RangeOverlap("start_at", "end_at", start, end).WithBounds("[[")); err == nil || !strings.Contains(err.Error(), "invalid range bounds") {
+		t.Errorf("expects invalid bounds error, got %v", err)
+	}
+
+	stmt := db.Session(&gorm.Session{DryRun: true}).Model(&tests.User{}).Where(clause.RangeColumnOverlap("during", start, end)).Find(&[]tests.User{}).Statement
+	if stmt.Error == nil || !strings.Contains(stmt.Error.Error(), "only supported by postgres") {
+		t.Errorf("expects unsupported dialect error, got %v", stmt.Error)
+	}
+}
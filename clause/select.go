@@ -16,6 +16,7 @@ func (s Select) Build(builder Builder) {
 		if s.Distinct {
 			builder.WriteString("DISTINCT ")
 		}
+		writeTop(builder)
 
 		for idx, column := range s.Columns {
 			if idx > 0 {
@@ -24,6 +25,7 @@ func (s Select) Build(builder Builder) {
 			builder.WriteQuoted(column)
 		}
 	} else {
+		writeTop(builder)
 		builder.WriteByte('*')
 	}
 }
@@ -58,3 +58,207 @@ func AssignmentColumns(values []string) Set {
 	}
 	return assignments
 }
+
+// AssignmentIfNull assign value only when the column is NULL in database, builds `column=COALESCE(column,value)`
+//
+//	db.Model(&User{}).Where("role = ?", "admin").Updates(clause.Set{clause.AssignmentIfNull("age", 18)})
+//	// UPDATE users SET age=COALESCE(age,18) WHERE role = 'admin'
+func AssignmentIfNull(column string, value interface{}) Assignment {
+	return Assignment{Column: Column{Name: column}, Value: Expr{SQL: "COALESCE(?,?)", Vars: []interface{}{Column{Name: column}, value}}}
+}
+
+// AssignmentsIfNull assign values only to the columns which are NULL in database, keys should be column names
+func AssignmentsIfNull(values map[string]interface{}) Set {
+	assignments := Assignments(values)
+	for idx, assignment := range assignments {
+		assignments[idx] = AssignmentIfNull(assignment.Column.Name, assignment.Value)
+	}
+	return assignments
+}
+
+// KeyedAssignments the values assigned to the row matched by Key
+type KeyedAssignments struct {
+	Key    interface{}
+	Values map[string]interface{}
+}
+
+// BulkAssignments assign different values to the rows matched by key in one statement, builds
+// `column=CASE key WHEN ? THEN ? ... ELSE column END` for each column, keys of Values should be column names,
+// columns not assigned to a row keep unchanged
+//
+//	db.Model(&User{}).Where("id IN ?", []int{1, 2}).Updates(clause.BulkAssignments(clause.Column{Name: "id"}, []clause.KeyedAssignments{
+//	  {Key: 1, Values: map[string]interface{}{"name": "a"}},
+//	  {Key: 2, Values: map[string]interface{}{"name": "b", "age": 18}},
+//	}))
+//	// UPDATE users SET age=CASE id WHEN 2 THEN 18 ELSE age END,name=CASE id WHEN 1 THEN 'a' WHEN 2 THEN 'b' ELSE name END WHERE id IN (1,2)
+func BulkAssignments(key Column, rows []KeyedAssignments) Set {
+	cases := map[string]*Case{}
+	for _, row := range rows {
+		for column, value := range row.Values {
+			c, ok := cases[column]
+			if !ok {
+				c = &Case{Column: key, Else: Column{Name: column}}
+				cases[column] = c
+			}
+			c.Whens = append(c.Whens, CaseWhen{When: row.Key, Then: value})
+		}
+	}
+
+	values := make(map[string]interface{}, len(cases))
+	for column, c := range cases {
+		values[column] = *c
+	}
+	return Assignments(values)
+}
+
+// Case simple case expression `CASE column WHEN ? THEN ? ... ELSE ? END`, ELSE is omitted if Else is nil
+type Case struct {
+	Column Column
+	Whens  []CaseWhen
+	Else   interface{}
+}
+
+// CaseWhen WHEN ? THEN ? of case expression
+type CaseWhen struct {
+	When interface{}
+	Then interface{}
+}
+
+// Build build case expression
+func (c Case) Build(builder Builder) {
+	builder.WriteString("CASE ")
+	builder.WriteQuoted(c.Column)
+	for _, when := range c.Whens {
+		builder.WriteString(" WHEN ")
+		builder.AddVar(builder, when.When)
+		builder.WriteString(" THEN ")
+		builder.AddVar(builder, when.Then)
+	}
+
+	if c.Else != nil {
+		builder.WriteString(" ELSE ")
+		builder.AddVar(builder, c.Else)
+	}
+	builder.WriteString(" END")
+}
+
+// AssignmentGreatest keep the greatest of the existing and the inserting value of column when upserting,
+// builds `column=GREATEST(table.column,excluded.column)`, `MAX` is used for sqlite and CASE for sqlserver
+//
+//	db.Clauses(clause.OnConflict{
+//	  Columns:   []clause.Column{{Name: "name"}},
+//	  DoUpdates: clause.Set{clause.AssignmentGreatest("high_water_mark")},
+//	}).Create(&metrics)
+//
+// NULL values are handled as the dialect does, e.g: GREATEST ignores NULL on postgres, but returns NULL on mysql
+func AssignmentGreatest(column string) Assignment {
+	return Assignment{Column: Column{Name: column}, Value: extremum{Column: column}}
+}
+
+// AssignmentLeast keep the least of the existing and the inserting value of column when upserting,
+// builds `column=LEAST(table.column,excluded.column)`, `MIN` is used for sqlite and CASE for sqlserver
+func AssignmentLeast(column string) Assignment {
+	return Assignment{Column: Column{Name: column}, Value: extremum{Column: column, Least: true}}
+}
+
+// extremumFunctions dialects using other functions than GREATEST and LEAST, empty functions are emulated with CASE,
+// used if the dialector doesn't implement gorm.ExtremumDialectorInterface
+var extremumFunctions = map[string][2]string{
+	"sqlite":    {"MAX", "MIN"},
+	"sqlserver": {"", ""},
+}
+
+// extremumFunctioner *gorm.Statement exposes the greatest and least functions of the dialector
+type extremumFunctioner interface {
+	ExtremumFunctions() (greatest, least string, ok bool)
+}
+
+// extremum the greatest or least of the existing and the inserting value of column
+type extremum struct {
+	Column string
+	Least  bool
+}
+
+// Build build the extremum with the inserting value referenced by `excluded`
+func (e extremum) Build(builder Builder) {
+	e.build(builder, excludedColumn(builder, e.Column))
+}
+
+func (e extremum) build(builder Builder, excluded interface{}) {
+	existing := Column{Table: CurrentTable, Name: e.Column}
+
+	functions := [2]string{"GREATEST", "LEAST"}
+	if namer, ok := builder.(dialectNamer); ok {
+		if fs, ok := extremumFunctions[namer.Name()]; ok {
+			functions = fs
+		}
+	}
+	if functioner, ok := builder.(extremumFunctioner); ok {
+		if greatest, least, ok := functioner.ExtremumFunctions(); ok {
+			functions = [2]string{greatest, least}
+		}
+	}
+
+	function := functions[0]
+	if e.Least {
+		function = functions[1]
+	}
+
+	if function == "" {
+		// keep the existing value if any of them is NULL
+		operator := " > "
+		if e.Least {
+			operator = " < "
+		}
+		builder.WriteString("CASE WHEN ")
+		builder.AddVar(builder, excluded)
+		builder.WriteString(operator)
+		builder.WriteQuoted(existing)
+		builder.WriteString(" THEN ")
+		builder.AddVar(builder, excluded)
+		builder.WriteString(" ELSE ")
+		builder.WriteQuoted(existing)
+		builder.WriteString(" END")
+		return
+	}
+
+	builder.WriteString(function)
+	builder.WriteByte('(')
+	builder.WriteQuoted(existing)
+	builder.WriteByte(',')
+	builder.AddVar(builder, excluded)
+	builder.WriteByte(')')
+}
+
+// excludedColumn reference of the inserting value of column, mysql has no `excluded` table in
+// ON DUPLICATE KEY UPDATE, `VALUES(column)` is used instead
+func excludedColumn(builder Builder, column string) interface{} {
+	if namer, ok := builder.(dialectNamer); ok && namer.Name() == "mysql" {
+		return OnDuplicateKeyUpdate{}.excluded(column)
+	}
+	return Column{Table: "excluded", Name: column}
+}
+
+// AssignmentKeepOnNull keep the existing value of column if the inserting value is NULL when upserting,
+// builds `column=COALESCE(excluded.column,table.column)`
+func AssignmentKeepOnNull(column string) Assignment {
+	return Assignment{Column: Column{Name: column}, Value: keepOnNull{Column: column}}
+}
+
+// keepOnNull the inserting value of column, or the existing value if it is NULL
+type keepOnNull struct {
+	Column string
+}
+
+// Build build the keepOnNull with the inserting value referenced by `excluded`
+func (k keepOnNull) Build(builder Builder) {
+	k.build(builder, excludedColumn(builder, k.Column))
+}
+
+func (k keepOnNull) build(builder Builder, excluded interface{}) {
+	builder.WriteString("COALESCE(")
+	builder.AddVar(builder, excluded)
+	builder.WriteByte(',')
+	builder.WriteQuoted(Column{Table: CurrentTable, Name: k.Column})
+	builder.WriteByte(')')
+}
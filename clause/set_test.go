@@ -6,7 +6,9 @@ import (
 	"strings"
 	"testing"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/utils/tests"
 )
 
 func TestSet(t *testing.T) {
@@ -32,6 +34,25 @@ func TestSet(t *testing.T) {
 			"UPDATE `users` SET `name`=?",
 			[]interface{}{"jinzhu"},
 		},
+		{
+			[]clause.Interface{
+				clause.Update{},
+				clause.AssignmentsIfNull(map[string]interface{}{"name": "jinzhu", "age": 18}),
+			},
+			"UPDATE `users` SET `age`=COALESCE(`age`,?),`name`=COALESCE(`name`,?)",
+			[]interface{}{18, "jinzhu"},
+		},
+		{
+			[]clause.Interface{
+				clause.Update{},
+				clause.BulkAssignments(clause.Column{Name: "id"}, []clause.KeyedAssignments{
+					{Key: 1, Values: map[string]interface{}{"name": "a"}},
+					{Key: 2, Values: map[string]interface{}{"name": "b", "age": 18}},
+				}),
+			},
+			"UPDATE `users` SET `age`=CASE `id` WHEN ? THEN ? ELSE `age` END,`name`=CASE `id` WHEN ? THEN ? WHEN ? THEN ? ELSE `name` END",
+			[]interface{}{2, 18, 1, "a", 2, "b"},
+		},
 	}
 
 	for idx, result := range results {
@@ -57,3 +78,76 @@ func TestAssignments(t *testing.T) {
 		t.Errorf("invalid assignments, got %v", assignments)
 	}
 }
+
+// extremumDialector dialector compares values with CASE without a known dialect name
+type extremumDialector struct {
+	tests.MockDialector
+}
+
+func (extremumDialector) ExtremumFunctions() (greatest, least string) {
+	return "", ""
+}
+
+func TestAssignmentGreatestAndLeast(t *testing.T) {
+	results := []struct {
+		Dialector gorm.Dialector
+		Set       clause.Set
+		Result    string
+	}{
+		{
+			Dialector: tests.MockDialector{DialectName: "postgres", QuoteToFunc: tests.DoubleQuoteTo},
+			Set:       clause.Set{clause.AssignmentGreatest("score"), clause.AssignmentLeast("first_seen")},
+			Result:    `"score"=GREATEST("users"."score","excluded"."score"),"first_seen"=LEAST("users"."first_seen","excluded"."first_seen")`,
+		},
+		{
+			Dialector: tests.MockDialector{DialectName: "sqlite"},
+			Set:       clause.Set{clause.AssignmentGreatest("score"), clause.AssignmentLeast("first_seen")},
+			Result:    "`score`=MAX(`users`.`score`,`excluded`.`score`),`first_seen`=MIN(`users`.`first_seen`,`excluded`.`first_seen`)",
+		},
+		{
+			Dialector: tests.MockDialector{DialectName: "sqlserver"},
+			Set:       clause.Set{clause.AssignmentGreatest("score"), clause.AssignmentLeast("first_seen")},
+			Result:    "`score`=CASE WHEN `excluded`.`score` > `users`.`score` THEN `excluded`.`score` ELSE `users`.`score` END,`first_seen`=CASE WHEN `excluded`.`first_seen` < `users`.`first_seen` THEN `excluded`.`first_seen` ELSE `users`.`first_seen` END",
+		},
+		{
+			Dialector: tests.MockDialector{DialectName: "mysql"},
+			Set:       clause.Set{clause.AssignmentGreatest("score"), clause.AssignmentLeast("first_seen")},
+			Result:    "`score`=GREATEST(`users`.`score`,VALUES(`score`)),`first_seen`=LEAST(`users`.`first_seen`,VALUES(`first_seen`))",
+		},
+		{
+			Dialector: extremumDialector{MockDialector: tests.MockDialector{DialectName: "db2"}},
+			Set:       clause.Set{clause.AssignmentGreatest("score")},
+			Result:    "`score`=CASE WHEN `excluded`.`score` > `users`.`score` THEN `excluded`.`score` ELSE `users`.`score` END",
+		},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			stmt := tests.NewStatement(result.Dialector)
+			stmt.Table = "users"
+			result.Set.Build(stmt)
+
+			if sql := stmt.SQL.String(); sql != result.Result {
+				t.Errorf("SQL expects %v got %v", result.Result, sql)
+			}
+		})
+	}
+}
+
+func TestAssignmentKeepOnNull(t *testing.T) {
+	stmt := tests.NewStatement(tests.MockDialector{DialectName: "postgres", QuoteToFunc: tests.DoubleQuoteTo})
+	stmt.Table = "users"
+	clause.Set{clause.AssignmentKeepOnNull("name")}.Build(stmt)
+
+	if sql, expects := stmt.SQL.String(), `"name"=COALESCE("excluded"."name","users"."name")`; sql != expects {
+		t.Errorf("SQL expects %v got %v", expects, sql)
+	}
+
+	stmt = tests.NewStatement(tests.MockDialector{DialectName: "mysql"})
+	stmt.Table = "users"
+	clause.Set{clause.AssignmentKeepOnNull("name")}.Build(stmt)
+
+	if sql, expects := stmt.SQL.String(), "`name`=COALESCE(VALUES(`name`),`users`.`name`)"; sql != expects {
+		t.Errorf("SQL expects %v got %v", expects, sql)
+	}
+}
@@ -0,0 +1,86 @@
+package clause
+
+import "fmt"
+
+func requirePostgres(builder Builder, expr string) bool {
+	if namer, ok := builder.(dialectNamer); ok && namer.Name() != "postgres" {
+		builder.AddError(fmt.Errorf("%s is only supported by postgres, got %s", expr, namer.Name()))
+		return false
+	}
+	return true
+}
+
+func writeDocument(builder Builder, document interface{}) {
+	if expr, ok := document.(Expression); ok {
+		expr.Build(builder)
+	} else {
+		builder.WriteQuoted(document)
+	}
+}
+
+// TSQuery full text search query, share it between TextMatch and TextRank to search and rank with the same query
+type TSQuery struct {
+	Config string // text search configuration, e.g: english
+	Query  string
+	Func   string // to_tsquery, plainto_tsquery, websearch_to_tsquery, defaults to plainto_tsquery
+}
+
+// Build build tsquery
+func (query TSQuery) Build(builder Builder) {
+	if query.Func == "" {
+		builder.WriteString("plainto_tsquery(")
+	} else {
+		builder.WriteString(query.Func)
+		builder.WriteByte('(')
+	}
+
+	if query.Config != "" {
+		builder.AddVar(builder, query.Config)
+		builder.WriteByte(',')
+	}
+	builder.AddVar(builder, query.Query)
+	builder.WriteByte(')')
+}
+
+// TextMatch full text search condition `document @@ query`, document could be a tsvector column or an expression
+//
+//	query := clause.TSQuery{Config: "english", Query: "gorm"}
+//	db.Where(clause.TextMatch{Column: "search_vector", Query: query}).Order(clause.TextRank{Column: "search_vector", Query: query}.OrderBy(true))
+type TextMatch struct {
+	Column interface{}
+	Query  TSQuery
+}
+
+// Build build full text search condition
+func (match TextMatch) Build(builder Builder) {
+	if requirePostgres(builder, "full text search") {
+		writeDocument(builder, match.Column)
+		builder.WriteString(" @@ ")
+		match.Query.Build(builder)
+	}
+}
+
+// TextRank full text search ranking `ts_rank(document, query)`, could be used as a select column or an order by term
+type TextRank struct {
+	Column interface{}
+	Query  TSQuery
+}
+
+// Build build full text search ranking
+func (rank TextRank) Build(builder Builder) {
+	if requirePostgres(builder, "ts_rank") {
+		builder.WriteString("ts_rank(")
+		writeDocument(builder, rank.Column)
+		builder.WriteByte(',')
+		rank.Query.Build(builder)
+		builder.WriteByte(')')
+	}
+}
+
+// OrderBy order by the ranking
+func (rank TextRank) OrderBy(desc bool) OrderBy {
+	if desc {
+		return OrderBy{Expression: Expr{SQL: "? DESC", Vars: []interface{}{rank}}}
+	}
+	return OrderBy{Expression: Expr{SQL: "?", Vars: []interface{}{rank}}}
+}
@@ -0,0 +1,66 @@
+package clause_test
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"gorm.io/gorm/utils/tests"
+)
+
+func TestTextSearch(t *testing.T) {
+	pgDB, _ := gorm.Open(tests.MockDialector{DialectName: "postgres"}, nil)
+	query := clause.TSQuery{Config: "english", Query: "gorm"}
+	results := []struct {
+		Clauses []clause.Interface
+		Result  string
+		Vars    []interface{}
+	}{
+		{
+			[]clause.Interface{clause.Select{}, clause.From{}, clause.Where{Exprs: []clause.Expression{clause.TextMatch{Column: "search_vector", Query: query}}}},
+			"SELECT * FROM `users` WHERE `search_vector` @@ plainto_tsquery(?,?)",
+			[]interface{}{"english", "gorm"},
+		},
+		{
+			[]clause.Interface{clause.Select{}, clause.From{}, clause.Where{Exprs: []clause.Expression{clause.TextMatch{Column: clause.Expr{SQL: "to_tsvector(?)", Vars: []interface{}{clause.Column{Name: "name"}}}, Query: clause.TSQuery{Query: "gorm & orm", Func: "to_tsquery"}}}}},
+			"SELECT * FROM `users` WHERE to_tsvector(`name`) @@ to_tsquery(?)",
+			[]interface{}{"gorm & orm"},
+		},
+		{
+			[]clause.Interface{clause.Select{}, clause.From{}, clause.Where{Exprs: []clause.Expression{clause.TextMatch{Column: "search_vector", Query: query}}}, clause.TextRank{Column: "search_vector", Query: query}.OrderBy(true)},
+			"SELECT * FROM `users` WHERE `search_vector` @@ plainto_tsquery(?,?) ORDER BY ts_rank(`search_vector`,plainto_tsquery(?,?)) DESC",
+			[]interface{}{"english", "gorm", "english", "gorm"},
+		},
+	}
+
+	for _, result := range results {
+		var (
+			user, _ = schema.Parse(&tests.User{}, &sync.Map{}, pgDB.NamingStrategy)
+			stmt    = gorm.Statement{DB: pgDB, Table: user.Table, Schema: user, Clauses: map[string]clause.Clause{}}
+			names   []string
+		)
+
+		for _, c := range result.Clauses {
+			names = append(names, c.Name())
+			stmt.AddClause(c)
+		}
+		stmt.Build(names...)
+
+		if sql := strings.TrimSpace(stmt.SQL.String()); sql != result.Result {
+			t.Errorf("SQL expects %v got %v", result.Result, sql)
+		}
+
+		if !reflect.DeepEqual(stmt.Vars, result.Vars) {
+			t.Errorf("Vars expects %+v got %+v", result.Vars, stmt.Vars)
+		}
+	}
+
+	stmt := db.Session(&gorm.Session{DryRun: true}).Model(&tests.User{}).Where(clause.TextMatch{Column: "search_vector", Query: query}).Find(&[]tests.User{}).Statement
+	if stmt.Error == nil || !strings.Contains(stmt.Error.Error(), "only supported by postgres") {
+		t.Errorf("expects unsupported dialect error, got %v", stmt.Error)
+	}
+}
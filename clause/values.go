@@ -1,5 +1,10 @@
 package clause
 
+import (
+	"errors"
+	"fmt"
+)
+
 type Values struct {
 	Columns []Column
 	Values  [][]interface{}
@@ -43,3 +48,82 @@ func (values Values) MergeClause(clause *Clause) {
 	clause.Name = ""
 	clause.Expression = values
 }
+
+// conditionalValuesDialects dialects support inserting selected values, maps to the FROM clause required by the dialect,
+// used if the dialector doesn't implement gorm.ConditionalValuesDialectorInterface
+var conditionalValuesDialects = map[string]string{
+	"postgres":  "",
+	"sqlite":    "",
+	"sqlserver": "",
+	"mysql":     " FROM DUAL",
+	"oracle":    " FROM DUAL",
+}
+
+// conditionalValuesFromer *gorm.Statement exposes the FROM clause required by the dialector to select values
+type conditionalValuesFromer interface {
+	ConditionalValuesFrom() (from string, ok bool)
+}
+
+// ConditionalValues insert values only if the precondition holds, the check and the insert are done in one statement
+//
+//	INSERT INTO `orders` (`name`) SELECT ? WHERE EXISTS(SELECT 1 FROM `quotas` WHERE remaining > 0)
+type ConditionalValues struct {
+	Values       Values
+	Precondition Expression
+}
+
+// Name from clause name
+func (ConditionalValues) Name() string {
+	return "VALUES"
+}
+
+// Build build conditional values
+func (values ConditionalValues) Build(builder Builder) {
+	var fromClause string
+	if namer, ok := builder.(dialectNamer); ok {
+		from, supported := conditionalValuesDialects[namer.Name()]
+		if fromer, ok := builder.(conditionalValuesFromer); ok {
+			if f, ok := fromer.ConditionalValuesFrom(); ok {
+				from, supported = f, true
+			}
+		}
+
+		if !supported {
+			builder.AddError(fmt.Errorf("conditional insert is not supported by %s", namer.Name()))
+			return
+		}
+		fromClause = from
+	}
+
+	if len(values.Values.Columns) == 0 || values.Precondition == nil {
+		builder.AddError(errors.New("conditional insert requires columns and precondition"))
+		return
+	}
+
+	builder.WriteByte('(')
+	for idx, column := range values.Values.Columns {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteQuoted(column)
+	}
+	builder.WriteByte(')')
+
+	for idx, value := range values.Values.Values {
+		if idx > 0 {
+			builder.WriteString(" UNION ALL")
+		}
+
+		builder.WriteString(" SELECT ")
+		builder.AddVar(builder, value...)
+		builder.WriteString(fromClause)
+		builder.WriteString(" WHERE ")
+		values.Precondition.Build(builder)
+	}
+}
+
+// MergeClause merge conditional values clauses
+func (values ConditionalValues) MergeClause(clause *Clause) {
+	clause.Name = ""
+	clause.Expression = values
+}
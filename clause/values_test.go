@@ -2,9 +2,12 @@ package clause_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/utils/tests"
 )
 
 func TestValues(t *testing.T) {
@@ -32,3 +35,63 @@ func TestValues(t *testing.T) {
 		})
 	}
 }
+
+// conditionalValuesDialector dialector selects values from a dummy table without a known dialect name
+type conditionalValuesDialector struct {
+	tests.MockDialector
+}
+
+func (conditionalValuesDialector) ConditionalValuesFrom() string {
+	return " FROM SYSIBM.SYSDUMMY1"
+}
+
+func TestConditionalValues(t *testing.T) {
+	values := clause.Values{
+		Columns: []clause.Column{{Name: "name"}, {Name: "age"}},
+		Values:  [][]interface{}{{"jinzhu", 18}, {"josh", 1}},
+	}
+	precondition := clause.Expr{SQL: "EXISTS(SELECT 1 FROM quotas WHERE remaining >= ?)", Vars: []interface{}{2}}
+
+	results := []struct {
+		Dialector gorm.Dialector
+		Result    string
+		Vars      []interface{}
+	}{
+		{
+			tests.MockDialector{DialectName: "postgres", QuoteToFunc: tests.DoubleQuoteTo, BindVarToFunc: tests.DollarBindVarTo},
+			`("name","age") SELECT $1,$2 WHERE EXISTS(SELECT 1 FROM quotas WHERE remaining >= $3) UNION ALL SELECT $4,$5 WHERE EXISTS(SELECT 1 FROM quotas WHERE remaining >= $6)`,
+			[]interface{}{"jinzhu", 18, 2, "josh", 1, 2},
+		},
+		{
+			tests.MockDialector{DialectName: "mysql"},
+			"(`name`,`age`) SELECT ?,? FROM DUAL WHERE EXISTS(SELECT 1 FROM quotas WHERE remaining >= ?) UNION ALL SELECT ?,? FROM DUAL WHERE EXISTS(SELECT 1 FROM quotas WHERE remaining >= ?)",
+			[]interface{}{"jinzhu", 18, 2, "josh", 1, 2},
+		},
+		{
+			conditionalValuesDialector{MockDialector: tests.MockDialector{DialectName: "db2"}},
+			"(`name`,`age`) SELECT ?,? FROM SYSIBM.SYSDUMMY1 WHERE EXISTS(SELECT 1 FROM quotas WHERE remaining >= ?) UNION ALL SELECT ?,? FROM SYSIBM.SYSDUMMY1 WHERE EXISTS(SELECT 1 FROM quotas WHERE remaining >= ?)",
+			[]interface{}{"jinzhu", 18, 2, "josh", 1, 2},
+		},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			sql, vars, err := tests.BuildExpression(result.Dialector, clause.ConditionalValues{Values: values, Precondition: precondition})
+			if err != nil {
+				t.Fatalf("failed to build, got error %v", err)
+			}
+
+			if sql != result.Result {
+				t.Errorf("SQL expects %v got %v", result.Result, sql)
+			}
+
+			if fmt.Sprint(vars) != fmt.Sprint(result.Vars) {
+				t.Errorf("Vars expects %+v got %+v", result.Vars, vars)
+			}
+		})
+	}
+
+	if _, _, err := tests.BuildExpression(tests.MockDialector{}, clause.ConditionalValues{Values: values, Precondition: precondition}); err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("expects unsupported dialect error, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package clause
 
 import (
+	"reflect"
 	"strings"
 )
 
@@ -42,8 +43,6 @@ func (where Where) Build(builder Builder) {
 
 // buildExprs 构建表达式。
 func buildExprs(exprs []Expression, builder Builder, joinCond string) {
-	wrapInParentheses := false
-
 	for idx, expr := range exprs {
 		if idx > 0 {
 			if v, ok := expr.(OrConditions); ok && len(v.Exprs) == 1 {
@@ -53,42 +52,44 @@ func buildExprs(exprs []Expression, builder Builder, joinCond string) {
 			}
 		}
 
-		if len(exprs) > 1 {
-			switch v := expr.(type) {
-			case OrConditions:
-				if len(v.Exprs) == 1 {
-					if e, ok := v.Exprs[0].(Expr); ok {
-						sql := strings.ToUpper(e.SQL)
-						wrapInParentheses = strings.Contains(sql, AndWithSpace) || strings.Contains(sql, OrWithSpace)
-					}
-				}
-			case AndConditions:
-				if len(v.Exprs) == 1 {
-					if e, ok := v.Exprs[0].(Expr); ok {
-						sql := strings.ToUpper(e.SQL)
-						wrapInParentheses = strings.Contains(sql, AndWithSpace) || strings.Contains(sql, OrWithSpace)
-					}
-				}
-			case Expr:
-				sql := strings.ToUpper(v.SQL)
-				wrapInParentheses = strings.Contains(sql, AndWithSpace) || strings.Contains(sql, OrWithSpace)
-			case NamedExpr:
-				sql := strings.ToUpper(v.SQL)
-				wrapInParentheses = strings.Contains(sql, AndWithSpace) || strings.Contains(sql, OrWithSpace)
-			}
-		}
-
-		if wrapInParentheses {
+		// 每个表达式独立判断是否需要括号。
+		if len(exprs) > 1 && needParentheses(expr) {
 			builder.WriteByte('(')
 			expr.Build(builder)
 			builder.WriteByte(')')
-			wrapInParentheses = false
 		} else {
 			expr.Build(builder)
 		}
 	}
 }
 
+// needParentheses 判断表达式与其他条件组合时是否需要括号，原始 SQL 中包含 AND 或 OR 时需要括号，
+// 多个表达式的 AndConditions、OrConditions 由自身添加括号。
+func needParentheses(expr Expression) bool {
+	var sql string
+	switch v := expr.(type) {
+	case OrConditions:
+		if len(v.Exprs) == 1 {
+			if e, ok := v.Exprs[0].(Expr); ok {
+				sql = e.SQL
+			}
+		}
+	case AndConditions:
+		if len(v.Exprs) == 1 {
+			if e, ok := v.Exprs[0].(Expr); ok {
+				sql = e.SQL
+			}
+		}
+	case Expr:
+		sql = v.SQL
+	case NamedExpr:
+		sql = v.SQL
+	}
+
+	sql = strings.ToUpper(sql)
+	return strings.Contains(sql, AndWithSpace) || strings.Contains(sql, OrWithSpace)
+}
+
 // MergeClause merge where clauses
 func (where Where) MergeClause(clause *Clause) {
 	if w, ok := clause.Expression.(Where); ok {
@@ -101,8 +102,81 @@ func (where Where) MergeClause(clause *Clause) {
 	clause.Expression = where
 }
 
-// And 构建AND条件。
+// Dedup 去除重复的条件，仅合并 SQL 与参数完全相同的 Expr 或 Eq 条件，其余条件保持原有顺序。
+// 包含以 OR 连接的条件时不去重，因为删除重复条件会改变运算优先级。
+func (where Where) Dedup() Where {
+	if hasOrJoiner(where.Exprs) {
+		return where
+	}
+
+	var exprs []Expression
+	for idx, expr := range where.Exprs {
+		duplicated := false
+		for _, prev := range where.Exprs[:idx] {
+			if duplicated = identicalExprs(prev, expr); duplicated {
+				break
+			}
+		}
+
+		if duplicated {
+			if exprs == nil {
+				exprs = make([]Expression, idx, len(where.Exprs))
+				copy(exprs, where.Exprs[:idx])
+			}
+		} else if exprs != nil {
+			exprs = append(exprs, expr)
+		}
+	}
+
+	if exprs != nil {
+		where.Exprs = exprs
+	}
+	return where
+}
+
+// identicalExprs 判断两个 Expr 或 Eq 条件的 SQL 与参数是否完全相同，其他类型的表达式视为不同。
+func identicalExprs(a, b Expression) bool {
+	switch av := a.(type) {
+	case Expr:
+		bv, ok := b.(Expr)
+		return ok && av.SQL == bv.SQL && av.WithoutParentheses == bv.WithoutParentheses && identicalValues(av.Vars, bv.Vars)
+	case Eq:
+		bv, ok := b.(Eq)
+		return ok && identicalValues([]interface{}{av.Column, av.Value}, []interface{}{bv.Column, bv.Value})
+	}
+	return false
+}
+
+// identicalValues 判断参数是否完全相同，指针参数（如子查询）必须是同一个指针。
+func identicalValues(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for idx := range a {
+		if reflect.TypeOf(a[idx]) != reflect.TypeOf(b[idx]) {
+			return false
+		}
+
+		switch reflect.ValueOf(a[idx]).Kind() {
+		case reflect.Ptr:
+			if a[idx] != b[idx] {
+				return false
+			}
+		case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+			return false
+		default:
+			if !reflect.DeepEqual(a[idx], b[idx]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// And 构建AND条件，忽略 nil 表达式。
 func And(exprs ...Expression) Expression {
+	exprs = compactExprs(exprs)
 	if len(exprs) == 0 {
 		return nil
 	}
@@ -132,12 +206,34 @@ func (and AndConditions) Build(builder Builder) {
 	}
 }
 
-// Or 构建OR条件。
+// Or 构建OR条件，忽略 nil 表达式。
+// 单个表达式的 Or 条件在 WHERE 中表示以 OR 连接，因此只有忽略 nil 后剩余一个表达式时才直接返回该表达式，如 Or(nil, cond) 即 cond。
 func Or(exprs ...Expression) Expression {
-	if len(exprs) == 0 {
+	compacted := compactExprs(exprs)
+	switch {
+	case len(compacted) == 0:
 		return nil
+	case len(compacted) == 1 && len(exprs) > 1:
+		return compacted[0]
 	}
-	return OrConditions{Exprs: exprs}
+	return OrConditions{Exprs: compacted}
+}
+
+// compactExprs 去除 nil 表达式，没有 nil 表达式时返回原切片。
+func compactExprs(exprs []Expression) []Expression {
+	for idx, expr := range exprs {
+		if expr == nil {
+			compacted := make([]Expression, idx, len(exprs)-1)
+			copy(compacted, exprs[:idx])
+			for _, expr := range exprs[idx+1:] {
+				if expr != nil {
+					compacted = append(compacted, expr)
+				}
+			}
+			return compacted
+		}
+	}
+	return exprs
 }
 
 // OrConditions 结构体，用于存储OR条件。
@@ -253,3 +349,122 @@ func (not NotConditions) Build(builder Builder) {
 		}
 	}
 }
+
+// NegateScope 构建整个组合条件的否定，按德摩根定律将 NOT 下推到子条件，可复用同一个条件查询其补集，E.g:
+//
+//	active := clause.And(clause.Eq{Column: "status", Value: "active"}, clause.Or(clause.Gt{Column: "age", Value: 18}, clause.Eq{Column: "vip", Value: true}))
+//	db.Where(clause.NegateScope(active)).Find(&users)
+//	// SELECT * FROM `users` WHERE (`status` <> 'active' OR (`age` <= 18 AND `vip` <> true))
+func NegateScope(expr Expression) Expression {
+	if expr == nil {
+		return nil
+	}
+	return negatedScope{Expr: expr}
+}
+
+// negatedScope 结构体，用于存储被否定的组合条件。
+type negatedScope struct {
+	Expr Expression
+}
+
+// Build 构建否定条件的SQL。
+func (negated negatedScope) Build(builder Builder) {
+	buildNegation(builder, negated.Expr)
+}
+
+// NegationBuild 否定的否定即原条件。
+func (negated negatedScope) NegationBuild(builder Builder) {
+	buildParentheses(builder, negated.Expr)
+}
+
+// buildNegation 构建表达式的否定。
+func buildNegation(builder Builder, expr Expression) {
+	switch v := expr.(type) {
+	case NegationExpressionBuilder:
+		v.NegationBuild(builder)
+	case AndConditions:
+		if !hasOrJoiner(v.Exprs) {
+			buildNegations(builder, v.Exprs, OrWithSpace)
+			return
+		}
+		builder.WriteString("NOT ")
+		buildParentheses(builder, v)
+	case Where:
+		if !hasOrJoiner(v.Exprs) {
+			buildNegations(builder, v.Exprs, OrWithSpace)
+			return
+		}
+		builder.WriteString("NOT ")
+		buildParentheses(builder, v)
+	case OrConditions:
+		buildNegations(builder, v.Exprs, AndWithSpace)
+	case NotConditions:
+		if len(v.Exprs) == 1 {
+			buildParentheses(builder, v.Exprs[0])
+			return
+		}
+		builder.WriteString("NOT ")
+		buildParentheses(builder, v)
+	default:
+		builder.WriteString("NOT ")
+		buildParentheses(builder, expr)
+	}
+}
+
+// buildNegations 构建每个表达式的否定，并以 joinCond 连接。
+func buildNegations(builder Builder, exprs []Expression, joinCond string) {
+	if len(exprs) > 1 {
+		builder.WriteByte('(')
+	}
+
+	for idx, expr := range exprs {
+		if idx > 0 {
+			builder.WriteString(joinCond)
+		}
+		buildNegation(builder, expr)
+	}
+
+	if len(exprs) > 1 {
+		builder.WriteByte(')')
+	}
+}
+
+// hasOrJoiner 判断 AND 条件中是否包含以 OR 连接的单个 Or 条件，这时无法按德摩根定律下推 NOT。
+func hasOrJoiner(exprs []Expression) bool {
+	if len(exprs) > 1 {
+		for _, expr := range exprs {
+			if v, ok := expr.(OrConditions); ok && len(v.Exprs) == 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// buildParentheses 构建括号包裹的表达式，多个条件的 And、Or、Not 条件自带括号。
+func buildParentheses(builder Builder, expr Expression) {
+	switch v := expr.(type) {
+	case AndConditions:
+		if len(v.Exprs) > 1 {
+			v.Build(builder)
+			return
+		}
+	case OrConditions:
+		if len(v.Exprs) > 1 {
+			v.Build(builder)
+			return
+		}
+	case NotConditions:
+		// NOT 条件包含否定构建器时自带括号
+		for _, e := range v.Exprs {
+			if _, ok := e.(NegationExpressionBuilder); ok && len(v.Exprs) > 1 {
+				v.Build(builder)
+				return
+			}
+		}
+	}
+
+	builder.WriteByte('(')
+	expr.Build(builder)
+	builder.WriteByte(')')
+}
@@ -2,6 +2,7 @@ package clause_test
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"gorm.io/gorm/clause"
@@ -137,3 +138,251 @@ func TestWhere(t *testing.T) {
 		})
 	}
 }
+
+func TestWhereParentheses(t *testing.T) {
+	var (
+		a = clause.Eq{Column: "a", Value: 1}
+		b = clause.Eq{Column: "b", Value: 2}
+		c = clause.Eq{Column: "c", Value: 3}
+		d = clause.Eq{Column: "d", Value: 4}
+		e = clause.Eq{Column: "e", Value: 5}
+	)
+
+	results := []struct {
+		Exprs  []clause.Expression
+		Result string
+		Vars   []interface{}
+	}{
+		{
+			[]clause.Expression{clause.Or(a, b), clause.Or(c, d), e},
+			"SELECT * FROM `users` WHERE (`a` = ? OR `b` = ?) AND (`c` = ? OR `d` = ?) AND `e` = ?",
+			[]interface{}{1, 2, 3, 4, 5},
+		},
+		{
+			[]clause.Expression{clause.Or(a, b), clause.Or(c, d), clause.Or(a, e), d},
+			"SELECT * FROM `users` WHERE (`a` = ? OR `b` = ?) AND (`c` = ? OR `d` = ?) AND (`a` = ? OR `e` = ?) AND `d` = ?",
+			[]interface{}{1, 2, 3, 4, 1, 5, 4},
+		},
+		{
+			[]clause.Expression{clause.Expr{SQL: "x = 1 OR y = 2"}, clause.Or(c, d), e},
+			"SELECT * FROM `users` WHERE (x = 1 OR y = 2) AND (`c` = ? OR `d` = ?) AND `e` = ?",
+			[]interface{}{3, 4, 5},
+		},
+		{
+			[]clause.Expression{a, clause.Or(clause.Expr{SQL: "x = 1 AND y = 2"}), clause.Or(c, d), clause.Expr{SQL: "m = 1 OR n = 2"}},
+			"SELECT * FROM `users` WHERE `a` = ? OR (x = 1 AND y = 2) AND (`c` = ? OR `d` = ?) AND (m = 1 OR n = 2)",
+			[]interface{}{1, 3, 4},
+		},
+		{
+			[]clause.Expression{clause.Expr{SQL: "x = 1 OR y = 2"}, e, clause.Expr{SQL: "z = 3"}},
+			"SELECT * FROM `users` WHERE (x = 1 OR y = 2) AND `e` = ? AND z = 3",
+			[]interface{}{5},
+		},
+		{
+			[]clause.Expression{clause.And(a, b), clause.Or(clause.And(c, d)), e},
+			"SELECT * FROM `users` WHERE (`a` = ? AND `b` = ?) OR (`c` = ? AND `d` = ?) AND `e` = ?",
+			[]interface{}{1, 2, 3, 4, 5},
+		},
+		{
+			[]clause.Expression{clause.Or(a, clause.And(b, c)), clause.Or(clause.And(c, d), e)},
+			"SELECT * FROM `users` WHERE (`a` = ? OR (`b` = ? AND `c` = ?)) AND ((`c` = ? AND `d` = ?) OR `e` = ?)",
+			[]interface{}{1, 2, 3, 3, 4, 5},
+		},
+		{
+			[]clause.Expression{clause.Or(clause.Expr{SQL: "x = 1 AND y = 2"}), clause.Or(c), e},
+			"SELECT * FROM `users` WHERE `e` = ? OR `c` = ? OR (x = 1 AND y = 2)",
+			[]interface{}{5, 3},
+		},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			checkBuildClauses(t, []clause.Interface{clause.Select{}, clause.From{}, clause.Where{Exprs: result.Exprs}}, result.Result, result.Vars)
+		})
+	}
+}
+
+func TestNegateScope(t *testing.T) {
+	scope := clause.And(
+		clause.Eq{Column: "status", Value: "active"},
+		clause.Or(clause.Gt{Column: "age", Value: 18}, clause.And(clause.Eq{Column: "vip", Value: true}, clause.Expr{SQL: "score > ? OR level > ?", Vars: []interface{}{60, 3}})),
+	)
+
+	results := []struct {
+		Clauses []clause.Interface
+		Result  string
+		Vars    []interface{}
+	}{
+		{
+			[]clause.Interface{clause.Select{}, clause.From{}, clause.Where{Exprs: []clause.Expression{scope}}},
+			"SELECT * FROM `users` WHERE `status` = ? AND (`age` > ? OR (`vip` = ? AND (score > ? OR level > ?)))",
+			[]interface{}{"active", 18, true, 60, 3},
+		},
+		{
+			[]clause.Interface{clause.Select{}, clause.From{}, clause.Where{Exprs: []clause.Expression{clause.NegateScope(scope)}}},
+			"SELECT * FROM `users` WHERE (`status` <> ? OR (`age` <= ? AND (`vip` <> ? OR NOT (score > ? OR level > ?))))",
+			[]interface{}{"active", 18, true, 60, 3},
+		},
+		{
+			[]clause.Interface{clause.Select{}, clause.From{}, clause.Where{Exprs: []clause.Expression{clause.Not(clause.NegateScope(scope)), clause.Eq{Column: "deleted", Value: false}}}},
+			"SELECT * FROM `users` WHERE (`status` = ? AND (`age` > ? OR (`vip` = ? AND (score > ? OR level > ?)))) AND `deleted` = ?",
+			[]interface{}{"active", 18, true, 60, 3, false},
+		},
+		{
+			[]clause.Interface{clause.Select{}, clause.From{}, clause.Where{Exprs: []clause.Expression{clause.NegateScope(clause.And(clause.Eq{Column: "name", Value: "jinzhu"}, clause.Or(clause.Eq{Column: "age", Value: 18})))}}},
+			"SELECT * FROM `users` WHERE NOT (`name` = ? OR `age` = ?)",
+			[]interface{}{"jinzhu", 18},
+		},
+		{
+			[]clause.Interface{clause.Select{}, clause.From{}, clause.Where{Exprs: []clause.Expression{clause.NegateScope(clause.Not(clause.Eq{Column: "name", Value: "jinzhu"}, clause.Lt{Column: "age", Value: 18}))}}},
+			"SELECT * FROM `users` WHERE NOT (`name` <> ? AND `age` >= ?)",
+			[]interface{}{"jinzhu", 18},
+		},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			checkBuildClauses(t, result.Clauses, result.Result, result.Vars)
+		})
+	}
+}
+
+func TestNotInSubQuery(t *testing.T) {
+	subQuery := db.Table("companies").Select("id").Where("name = ? AND active = ?", "jinzhu", true)
+
+	results := []struct {
+		Exprs  []clause.Expression
+		Result string
+		Vars   []interface{}
+	}{
+		{
+			[]clause.Expression{clause.Not(clause.IN{Column: "company_id", Values: []interface{}{1, 2}})},
+			"SELECT * FROM `users` WHERE `company_id` NOT IN (?,?)",
+			[]interface{}{1, 2},
+		},
+		{
+			[]clause.Expression{clause.Not(clause.IN{Column: "company_id", Values: []interface{}{subQuery}})},
+			"SELECT * FROM `users` WHERE `company_id` NOT IN (SELECT id FROM `companies` WHERE name = ? AND active = ?)",
+			[]interface{}{"jinzhu", true},
+		},
+		{
+			[]clause.Expression{clause.Not(clause.IN{Column: "company_id", Values: []interface{}{clause.Expr{SQL: "SELECT id FROM companies WHERE name = ?", Vars: []interface{}{"jinzhu"}}}})},
+			"SELECT * FROM `users` WHERE `company_id` NOT IN (SELECT id FROM companies WHERE name = ?)",
+			[]interface{}{"jinzhu"},
+		},
+		{
+			[]clause.Expression{clause.IN{Column: "company_id", Values: []interface{}{subQuery}}},
+			"SELECT * FROM `users` WHERE `company_id` IN (SELECT id FROM `companies` WHERE name = ? AND active = ?)",
+			[]interface{}{"jinzhu", true},
+		},
+		{
+			[]clause.Expression{clause.Not(clause.Expr{SQL: "company_id IN (?)", Vars: []interface{}{[]interface{}{1, 2}}})},
+			"SELECT * FROM `users` WHERE NOT company_id IN (?,?)",
+			[]interface{}{1, 2},
+		},
+		{
+			[]clause.Expression{clause.Not(clause.Expr{SQL: "company_id IN (?)", Vars: []interface{}{subQuery}})},
+			"SELECT * FROM `users` WHERE NOT company_id IN (SELECT id FROM `companies` WHERE name = ? AND active = ?)",
+			[]interface{}{"jinzhu", true},
+		},
+		{
+			[]clause.Expression{clause.Not(clause.Expr{SQL: "company_id IN (SELECT id FROM companies WHERE name = ? OR active = ?)", Vars: []interface{}{"jinzhu", true}}, clause.Eq{Column: "age", Value: 18})},
+			"SELECT * FROM `users` WHERE (NOT (company_id IN (SELECT id FROM companies WHERE name = ? OR active = ?)) AND `age` <> ?)",
+			[]interface{}{"jinzhu", true, 18},
+		},
+		{
+			[]clause.Expression{clause.Not(clause.Expr{SQL: "(company_id IN (SELECT id FROM companies)) OR age > 18"})},
+			"SELECT * FROM `users` WHERE NOT ((company_id IN (SELECT id FROM companies)) OR age > 18)",
+			nil,
+		},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			checkBuildClauses(t, []clause.Interface{clause.Select{}, clause.From{}, clause.Where{Exprs: result.Exprs}}, result.Result, result.Vars)
+		})
+	}
+}
+
+func TestWhereDedup(t *testing.T) {
+	var (
+		a    = clause.Eq{Column: "a", Value: 1}
+		b    = clause.Expr{SQL: "b IN (?)", Vars: []interface{}{[]int{1, 2}}}
+		sub  = db.Table("companies").Select("id")
+		sub2 = db.Table("companies").Select("id")
+	)
+
+	results := []struct {
+		Where  clause.Where
+		Result []clause.Expression
+	}{
+		{
+			clause.Where{Exprs: []clause.Expression{a, b, clause.Eq{Column: "a", Value: 1}, clause.Expr{SQL: "b IN (?)", Vars: []interface{}{[]int{1, 2}}}}},
+			[]clause.Expression{a, b},
+		},
+		{
+			clause.Where{Exprs: []clause.Expression{a, clause.Eq{Column: "a", Value: 2}, clause.Eq{Column: clause.Column{Name: "a"}, Value: 1}, b, clause.Expr{SQL: "b IN (?)", Vars: []interface{}{[]int{1, 3}}}}},
+			[]clause.Expression{a, clause.Eq{Column: "a", Value: 2}, clause.Eq{Column: clause.Column{Name: "a"}, Value: 1}, b, clause.Expr{SQL: "b IN (?)", Vars: []interface{}{[]int{1, 3}}}},
+		},
+		{
+			clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "c IN (?)", Vars: []interface{}{sub}}, a, clause.Expr{SQL: "c IN (?)", Vars: []interface{}{sub}}, clause.Expr{SQL: "c IN (?)", Vars: []interface{}{sub2}}}},
+			[]clause.Expression{clause.Expr{SQL: "c IN (?)", Vars: []interface{}{sub}}, a, clause.Expr{SQL: "c IN (?)", Vars: []interface{}{sub2}}},
+		},
+		{
+			clause.Where{Exprs: []clause.Expression{a, clause.Or(b), a}},
+			[]clause.Expression{a, clause.Or(b), a},
+		},
+		{
+			clause.Where{Exprs: []clause.Expression{clause.Gt{Column: "d", Value: 1}, clause.Gt{Column: "d", Value: 1}}},
+			[]clause.Expression{clause.Gt{Column: "d", Value: 1}, clause.Gt{Column: "d", Value: 1}},
+		},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			if exprs := result.Where.Dedup().Exprs; len(exprs) != len(result.Result) {
+				t.Fatalf("expects %v expressions, got %v", len(result.Result), len(exprs))
+			} else {
+				for i, expr := range exprs {
+					if fmt.Sprintf("%#v", expr) != fmt.Sprintf("%#v", result.Result[i]) {
+						t.Errorf("expression #%v expects %#v, got %#v", i, result.Result[i], expr)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestAndOrWithNilExpressions(t *testing.T) {
+	var (
+		a = clause.Eq{Column: "a", Value: 1}
+		b = clause.Eq{Column: "b", Value: 2}
+	)
+
+	results := []struct {
+		Expr   clause.Expression
+		Result clause.Expression
+	}{
+		{clause.And(), nil},
+		{clause.And(nil, nil), nil},
+		{clause.And(nil, a, nil), a},
+		{clause.And(a, nil, b), clause.AndConditions{Exprs: []clause.Expression{a, b}}},
+		{clause.And(nil, clause.Or(a)), clause.AndConditions{Exprs: []clause.Expression{clause.OrConditions{Exprs: []clause.Expression{a}}}}},
+		{clause.Or(), nil},
+		{clause.Or(nil, nil), nil},
+		{clause.Or(nil, a), a},
+		{clause.Or(a), clause.OrConditions{Exprs: []clause.Expression{a}}},
+		{clause.Or(a, nil, b, nil), clause.OrConditions{Exprs: []clause.Expression{a, b}}},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			if !reflect.DeepEqual(result.Expr, result.Result) {
+				t.Errorf("expects %#v, got %#v", result.Result, result.Expr)
+			}
+		})
+	}
+
+	checkBuildClauses(t, []clause.Interface{clause.Select{}, clause.From{}, clause.Where{Exprs: []clause.Expression{clause.Or(nil, clause.And(nil, a)), clause.Or(nil, b, nil)}}},
+		"SELECT * FROM `users` WHERE `a` = ? AND `b` = ?", []interface{}{1, 2})
+}
@@ -1,3 +1,138 @@
 package clause
 
-type With struct{}
+import "fmt"
+
+// recursiveCTEDialects dialects support WITH RECURSIVE, used if the dialector doesn't implement gorm.RecursiveCTEDialectorInterface
+var recursiveCTEDialects = map[string]bool{
+	"postgres": true,
+	"mysql":    true,
+	"sqlite":   true,
+}
+
+// recursiveCTESupporter *gorm.Statement exposes whether WITH RECURSIVE is supported by the dialector
+type recursiveCTESupporter interface {
+	SupportRecursiveCTE() (supported bool, ok bool)
+}
+
+// With common table expressions
+type With struct {
+	Recursive bool
+	CTEs      []CTE
+}
+
+// CTE common table expression `name (columns) AS (expression)`
+type CTE struct {
+	Name       string
+	Columns    []string
+	Expression Expression
+}
+
+// Name with clause name
+func (with With) Name() string {
+	return "WITH"
+}
+
+// Build build with clause
+func (with With) Build(builder Builder) {
+	if with.Recursive {
+		if namer, ok := builder.(dialectNamer); ok && !supportRecursiveCTE(builder, namer.Name()) {
+			builder.AddError(fmt.Errorf("recursive common table expression is not supported by %s", namer.Name()))
+			return
+		}
+		builder.WriteString("RECURSIVE ")
+	}
+
+	for idx, cte := range with.CTEs {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+
+		builder.WriteQuoted(cte.Name)
+		if len(cte.Columns) > 0 {
+			builder.WriteByte(' ')
+			builder.WriteQuoted(cte.Columns)
+		}
+		builder.WriteString(" AS (")
+		cte.Expression.Build(builder)
+		builder.WriteByte(')')
+	}
+}
+
+func supportRecursiveCTE(builder Builder, name string) bool {
+	if supporter, ok := builder.(recursiveCTESupporter); ok {
+		if supported, ok := supporter.SupportRecursiveCTE(); ok {
+			return supported
+		}
+	}
+	return recursiveCTEDialects[name]
+}
+
+// MergeClause merge with clauses
+func (with With) MergeClause(clause *Clause) {
+	if v, ok := clause.Expression.(With); ok {
+		with.Recursive = with.Recursive || v.Recursive
+		with.CTEs = append(append([]CTE{}, v.CTEs...), with.CTEs...)
+	}
+	clause.Expression = with
+}
+
+// RecursiveTreeExpr traverse an adjacency list hierarchy from the root rows with recursive CTE
+type RecursiveTreeExpr struct {
+	Table  string
+	ID     string
+	Parent string
+	Root   Expression
+	Alias  string // name of the CTE, defaults to `tree`
+}
+
+// RecursiveTree returns a subquery selecting the root rows matching rootCondition and all of their descendants,
+// rows are linked by parentCol referencing idCol of the table
+//
+//	db.Table("(?) AS categories", clause.RecursiveTree("categories", "id", "parent_id", clause.Eq{Column: "id", Value: 1})).Find(&categories)
+//	// SELECT * FROM (WITH RECURSIVE "tree" AS (SELECT * FROM "categories" WHERE "id" = 1 UNION ALL
+//	// SELECT "categories".* FROM "categories" INNER JOIN "tree" ON "categories"."parent_id" = "tree"."id") SELECT * FROM "tree") AS categories
+func RecursiveTree(table, idCol, parentCol string, rootCondition Expression) RecursiveTreeExpr {
+	return RecursiveTreeExpr{Table: table, ID: idCol, Parent: parentCol, Root: rootCondition}
+}
+
+// Build build recursive tree subquery
+func (tree RecursiveTreeExpr) Build(builder Builder) {
+	alias := tree.Alias
+	if alias == "" {
+		alias = "tree"
+	}
+
+	builder.WriteByte('(')
+	builder.WriteString("WITH ")
+	With{Recursive: true, CTEs: []CTE{{Name: alias, Expression: recursiveTreeMembers{tree: tree, alias: alias}}}}.Build(builder)
+	builder.WriteString(" SELECT * FROM ")
+	builder.WriteQuoted(alias)
+	builder.WriteByte(')')
+}
+
+// recursiveTreeMembers anchor member and recursive member of the tree CTE
+type recursiveTreeMembers struct {
+	tree  RecursiveTreeExpr
+	alias string
+}
+
+func (members recursiveTreeMembers) Build(builder Builder) {
+	tree := members.tree
+	builder.WriteString("SELECT * FROM ")
+	builder.WriteQuoted(Table{Name: tree.Table})
+	if tree.Root != nil {
+		builder.WriteString(" WHERE ")
+		Where{Exprs: []Expression{tree.Root}}.Build(builder)
+	}
+
+	builder.WriteString(" UNION ALL SELECT ")
+	builder.WriteQuoted(Table{Name: tree.Table})
+	builder.WriteString(".* FROM ")
+	builder.WriteQuoted(Table{Name: tree.Table})
+	builder.WriteString(" INNER JOIN ")
+	builder.WriteQuoted(members.alias)
+	builder.WriteString(" ON ")
+	builder.WriteQuoted(Column{Table: tree.Table, Name: tree.Parent})
+	builder.WriteString(" = ")
+	builder.WriteQuoted(Column{Table: members.alias, Name: tree.ID})
+}
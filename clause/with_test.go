@@ -0,0 +1,69 @@
+package clause_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/utils/tests"
+)
+
+func TestWith(t *testing.T) {
+	results := []struct {
+		Clauses []clause.Interface
+		Result  string
+		Vars    []interface{}
+	}{
+		{
+			[]clause.Interface{clause.With{CTEs: []clause.CTE{{Name: "adults", Expression: clause.Expr{SQL: "SELECT * FROM users WHERE age >= ?", Vars: []interface{}{18}}}}}, clause.Select{}, clause.From{}},
+			"WITH `adults` AS (SELECT * FROM users WHERE age >= ?) SELECT * FROM `users`",
+			[]interface{}{18},
+		},
+		{
+			[]clause.Interface{
+				clause.With{CTEs: []clause.CTE{{Name: "adults", Expression: clause.Expr{SQL: "SELECT 1"}}}},
+				clause.With{CTEs: []clause.CTE{{Name: "nums", Columns: []string{"n"}, Expression: clause.Expr{SQL: "SELECT 1"}}}},
+				clause.Select{}, clause.From{},
+			},
+			"WITH `adults` AS (SELECT 1),`nums` (`n`) AS (SELECT 1) SELECT * FROM `users`",
+			nil,
+		},
+	}
+
+	for idx, result := range results {
+		t.Run(fmt.Sprintf("case #%v", idx), func(t *testing.T) {
+			checkBuildClauses(t, result.Clauses, result.Result, result.Vars)
+		})
+	}
+}
+
+func TestRecursiveTree(t *testing.T) {
+	pgDialector := tests.MockDialector{DialectName: "postgres", QuoteToFunc: tests.DoubleQuoteTo, BindVarToFunc: tests.DollarBindVarTo}
+	sql, vars, err := tests.BuildExpression(pgDialector, clause.RecursiveTree("categories", "id", "parent_id", clause.Eq{Column: "id", Value: 1}))
+	if err != nil {
+		t.Fatalf("failed to build, got error %v", err)
+	}
+
+	expected := `(WITH RECURSIVE "tree" AS (SELECT * FROM "categories" WHERE "id" = $1 UNION ALL SELECT "categories".* FROM "categories" INNER JOIN "tree" ON "categories"."parent_id" = "tree"."id") SELECT * FROM "tree")`
+	if sql != expected || fmt.Sprint(vars) != "[1]" {
+		t.Errorf("expects %v, %v, got %v, %v", expected, []interface{}{1}, sql, vars)
+	}
+
+	if _, _, err := tests.BuildExpression(tests.MockDialector{DialectName: "sqlserver"}, clause.RecursiveTree("categories", "id", "parent_id", nil)); err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("expects unsupported dialect error, got %v", err)
+	}
+
+	if _, _, err := tests.BuildExpression(recursiveCTEDialector{MockDialector: tests.MockDialector{DialectName: "gaussdb"}}, clause.RecursiveTree("categories", "id", "parent_id", nil)); err != nil {
+		t.Errorf("dialector supports recursive common table expression should not return error, got %v", err)
+	}
+}
+
+// recursiveCTEDialector dialector supports WITH RECURSIVE without a known dialect name
+type recursiveCTEDialector struct {
+	tests.MockDialector
+}
+
+func (recursiveCTEDialector) SupportRecursiveCTE() bool {
+	return true
+}
@@ -0,0 +1,163 @@
+package gorm
+
+import (
+	"fmt"
+	"time"
+)
+
+// TransactionCoordinator coordinates transactions of multiple databases with best-effort two-phase commit
+//
+// participants whose dialector implements PreparedTransactionDialectorInterface are prepared before committing,
+// others are committed sequentially, if one of them failed to commit after previous ones committed,
+// the committed ones are compensated by their compensate functions, the fallback is NOT atomic,
+// other processes might observe the committed changes before they are compensated
+type TransactionCoordinator struct {
+	participants []participant
+}
+
+type participant struct {
+	db         *DB
+	compensate func(db *DB) error
+}
+
+type participantTx struct {
+	participant
+	tx         *DB
+	preparedID string
+	committed  bool
+}
+
+// NewTransactionCoordinator returns a coordinator of the databases
+//
+//	err := gorm.NewTransactionCoordinator(mainDB).
+//		Join(outboxDB, func(db *gorm.DB) error { return db.Delete(&event).Error }).
+//		Transaction(func(txs ...*gorm.DB) error {
+//			if err := txs[0].Create(&order).Error; err != nil {
+//				return err
+//			}
+//			return txs[1].Create(&event).Error
+//		})
+func NewTransactionCoordinator(dbs ...*DB) *TransactionCoordinator {
+	coordinator := &TransactionCoordinator{}
+	for _, db := range dbs {
+		coordinator.Join(db, nil)
+	}
+	return coordinator
+}
+
+// Join adds a database, compensate is used to undo committed changes when following commits failed
+func (c *TransactionCoordinator) Join(db *DB, compensate func(db *DB) error) *TransactionCoordinator {
+	c.participants = append(c.participants, participant{db: db, compensate: compensate})
+	return c
+}
+
+// Transaction begins transactions of all databases, runs fc with them in the order they were joined, then commits them
+func (c *TransactionCoordinator) Transaction(fc func(txs ...*DB) error) (err error) {
+	var (
+		ptxs = make([]*participantTx, 0, len(c.participants))
+		txs  = make([]*DB, 0, len(c.participants))
+	)
+
+	rollback := func() {
+		for _, ptx := range ptxs {
+			if ptx.committed {
+				continue
+			}
+
+			if ptx.preparedID != "" {
+				err = joinError(err, ptx.db.Dialector.(PreparedTransactionDialectorInterface).RollbackPrepared(ptx.db, ptx.preparedID))
+			} else {
+				ptx.tx.Rollback()
+			}
+		}
+	}
+
+	for _, p := range c.participants {
+		tx := p.db.Begin()
+		if tx.Error != nil {
+			rollback()
+			return tx.Error
+		}
+		ptxs = append(ptxs, &participantTx{participant: p, tx: tx})
+		txs = append(txs, tx)
+	}
+
+	panicked := true
+	defer func() {
+		if panicked {
+			rollback()
+		}
+	}()
+
+	if err = fc(txs...); err != nil {
+		panicked = false
+		rollback()
+		return err
+	}
+	panicked = false
+
+	// phase one, prepare participants support prepared transaction
+	prefix := fmt.Sprintf("gorm_%d", time.Now().UnixNano())
+	for idx, ptx := range ptxs {
+		if preparer, ok := ptx.db.Dialector.(PreparedTransactionDialectorInterface); ok {
+			id := fmt.Sprintf("%s_%d", prefix, idx)
+			if err = preparer.PrepareTransaction(ptx.tx, id); err != nil {
+				rollback()
+				return err
+			}
+
+			ptx.preparedID = id
+			// the connection is no longer in a transaction after prepared, release it
+			if err = ptx.tx.Commit().Error; err != nil {
+				rollback()
+				return err
+			}
+		}
+	}
+
+	// phase two, commit participants can't be prepared one by one, then prepared ones
+	for _, ptx := range ptxs {
+		if ptx.preparedID == "" {
+			if err = ptx.tx.Commit().Error; err != nil {
+				rollback()
+				return c.compensate(ptxs, err)
+			}
+			ptx.committed = true
+		}
+	}
+
+	for _, ptx := range ptxs {
+		if ptx.preparedID != "" {
+			if commitErr := ptx.db.Dialector.(PreparedTransactionDialectorInterface).CommitPrepared(ptx.db, ptx.preparedID); commitErr != nil {
+				err = joinError(err, fmt.Errorf("failed to commit prepared transaction %s: %w", ptx.preparedID, commitErr))
+			} else {
+				ptx.committed = true
+			}
+		}
+	}
+	return err
+}
+
+// compensate undo committed changes in the reverse order
+func (c *TransactionCoordinator) compensate(ptxs []*participantTx, err error) error {
+	for idx := len(ptxs) - 1; idx >= 0; idx-- {
+		if ptx := ptxs[idx]; ptx.committed {
+			if ptx.compensate == nil {
+				err = joinError(err, fmt.Errorf("changes committed to %s can't be compensated", ptx.db.Dialector.Name()))
+			} else if compensateErr := ptx.compensate(ptx.db); compensateErr != nil {
+				err = joinError(err, fmt.Errorf("failed to compensate %s: %w", ptx.db.Dialector.Name(), compensateErr))
+			}
+		}
+	}
+	return err
+}
+
+// joinError join errors like AddError
+func joinError(err, newErr error) error {
+	if err == nil {
+		return newErr
+	} else if newErr == nil {
+		return err
+	}
+	return fmt.Errorf("%v; %w", err, newErr)
+}
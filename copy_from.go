@@ -0,0 +1,135 @@
+package gorm
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+// defaultCopyFromBatchSize batch size used when falling back to INSERT and CreateBatchSize is not set
+const defaultCopyFromBatchSize = 1000
+
+// CopyFrom loads rows into table with the COPY protocol if the connection pool implements CopyFromConnPool,
+// otherwise rows are inserted in batches of CreateBatchSize (1000 if not set), E.g:
+//
+//	db.CopyFrom("users", []string{"name", "age"}, [][]interface{}{{"jinzhu", 18}, {"hello", 20}})
+//
+// if a model is specified, table and columns are resolved from its schema and values are converted with field serializers,
+// hooks are skipped, and primary keys generated by the database are not assigned back
+func (db *DB) CopyFrom(table string, columns []string, rows [][]interface{}) (tx *DB) {
+	tx = db.getInstance()
+	stmt := tx.Statement
+
+	var fields []*schema.Field
+	if stmt.Model != nil {
+		if err := stmt.Parse(stmt.Model); err != nil {
+			tx.AddError(err)
+			return
+		}
+
+		if table == "" {
+			table = stmt.Table
+		}
+
+		fields = make([]*schema.Field, len(columns))
+		resolved := make([]string, len(columns))
+		for idx, column := range columns {
+			resolved[idx] = column
+			if field := stmt.Schema.LookUpField(column); field != nil {
+				fields[idx], resolved[idx] = field, field.DBName
+			}
+		}
+		columns = resolved
+	} else if table == "" {
+		table = stmt.Table
+	}
+
+	if table == "" || len(columns) == 0 {
+		tx.AddError(fmt.Errorf("%w: table and columns are required", ErrInvalidData))
+		return
+	}
+
+	values := make([][]interface{}, len(rows))
+	for idx, row := range rows {
+		if len(row) != len(columns) {
+			tx.AddError(fmt.Errorf("%w: row %d has %d values, expected %d", ErrInvalidData, idx, len(row), len(columns)))
+			return
+		}
+
+		values[idx] = make([]interface{}, len(row))
+		for i, v := range row {
+			var field *schema.Field
+			if fields != nil {
+				field = fields[i]
+			}
+
+			value, err := tx.copyFromValue(field, v)
+			if err != nil {
+				tx.AddError(err)
+				return
+			}
+			values[idx][i] = value
+		}
+	}
+
+	if len(values) == 0 {
+		return
+	}
+
+	if pool, ok := stmt.ConnPool.(CopyFromConnPool); ok && !tx.DryRun {
+		curTime := time.Now()
+		rowsAffected, err := pool.CopyFrom(stmt.Context, table, columns, values)
+		tx.AddError(err)
+		tx.RowsAffected = rowsAffected
+
+		tx.Logger.Trace(stmt.Context, curTime, func() (string, int64) {
+			var sql strings.Builder
+			sql.WriteString("COPY ")
+			stmt.QuoteTo(&sql, table)
+			sql.WriteString(" (")
+			for idx, column := range columns {
+				if idx > 0 {
+					sql.WriteByte(',')
+				}
+				stmt.QuoteTo(&sql, column)
+			}
+			sql.WriteString(") FROM STDIN")
+			return sql.String(), tx.RowsAffected
+		}, tx.Error)
+		return
+	}
+
+	batchSize := tx.CreateBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultCopyFromBatchSize
+	}
+
+	maps := make([]map[string]interface{}, len(values))
+	for idx, row := range values {
+		maps[idx] = make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			maps[idx][column] = row[i]
+		}
+	}
+
+	return tx.Table(table).Session(&Session{SkipHooks: true}).CreateInBatches(maps, batchSize)
+}
+
+// copyFromValue converts v to the value sent to the database
+func (db *DB) copyFromValue(field *schema.Field, v interface{}) (interface{}, error) {
+	if field != nil && field.Serializer != nil {
+		return field.Serializer.Value(db.Statement.Context, field, reflect.Value{}, v)
+	}
+
+	if valuer, ok := v.(driver.Valuer); ok {
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil, nil
+		}
+		return valuer.Value()
+	}
+	return v, nil
+}
@@ -0,0 +1,105 @@
+package gorm
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+)
+
+// ErrorClass 错误分类，重试逻辑根据分类决定是否重试。
+type ErrorClass int
+
+const (
+	// ErrorClassPermanent 永久性错误，重试无法成功
+	ErrorClassPermanent ErrorClass = iota
+	// ErrorClassTransient 暂时性错误，例如连接中断、锁等待超时
+	ErrorClassTransient
+	// ErrorClassSerializationFailure 可串行化隔离级别下的序列化失败，需要重试整个事务
+	ErrorClassSerializationFailure
+	// ErrorClassDeadlock 死锁，事务已被数据库回滚，需要重试整个事务
+	ErrorClassDeadlock
+)
+
+// Retryable 错误是否可以重试。
+func (class ErrorClass) Retryable() bool {
+	return class != ErrorClassPermanent
+}
+
+// String 返回错误分类的名称。
+func (class ErrorClass) String() string {
+	switch class {
+	case ErrorClassTransient:
+		return "transient"
+	case ErrorClassSerializationFailure:
+		return "serialization_failure"
+	case ErrorClassDeadlock:
+		return "deadlock"
+	default:
+		return "permanent"
+	}
+}
+
+// errorCodeClasses 各方言常见的可重试错误码。
+var errorCodeClasses = map[string]map[int]ErrorClass{
+	"mysql": {
+		1205: ErrorClassTransient, // ER_LOCK_WAIT_TIMEOUT
+		1213: ErrorClassDeadlock,  // ER_LOCK_DEADLOCK
+		2006: ErrorClassTransient, // CR_SERVER_GONE_ERROR
+		2013: ErrorClassTransient, // CR_SERVER_LOST
+	},
+	"sqlserver": {
+		1205: ErrorClassDeadlock,  // deadlock victim
+		1222: ErrorClassTransient, // lock request time out
+		3960: ErrorClassSerializationFailure,
+	},
+	"sqlite": {
+		5: ErrorClassTransient, // SQLITE_BUSY
+		6: ErrorClassTransient, // SQLITE_LOCKED
+	},
+}
+
+// ClassifySQLState 根据 SQLSTATE 对错误分类，适用于 postgres 等返回 SQLSTATE 的数据库。
+func ClassifySQLState(state string) ErrorClass {
+	switch {
+	case state == "40001":
+		return ErrorClassSerializationFailure
+	case state == "40P01":
+		return ErrorClassDeadlock
+	case state == "55P03", state == "57P01", strings.HasPrefix(state, "08"):
+		// lock not available, admin shutdown, connection exception
+		return ErrorClassTransient
+	default:
+		return ErrorClassPermanent
+	}
+}
+
+// ClassifyErrorCode 根据方言的错误码对错误分类，供方言实现 RetryableErrorClassifier 时使用。
+func ClassifyErrorCode(dialect string, code int) ErrorClass {
+	if class, ok := errorCodeClasses[dialect][code]; ok {
+		return class
+	}
+	return ErrorClassPermanent
+}
+
+// ClassifyError 对错误分类，优先使用实现了 RetryableErrorClassifier 的方言，
+// 否则保守地只将 driver.ErrBadConn 与可识别的 SQLSTATE 视为可重试。
+func (db *DB) ClassifyError(err error) ErrorClass {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrorClassPermanent
+	}
+
+	if classifier, ok := db.Dialector.(RetryableErrorClassifier); ok {
+		return classifier.ClassifyError(err)
+	}
+
+	if errors.Is(err, driver.ErrBadConn) {
+		return ErrorClassTransient
+	}
+
+	var stateErr interface{ SQLState() string }
+	if errors.As(err, &stateErr) {
+		return ClassifySQLState(stateErr.SQLState())
+	}
+	return ErrorClassPermanent
+}
@@ -2,6 +2,8 @@ package gorm
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
 
 	"gorm.io/gorm/logger"
 )
@@ -51,4 +53,75 @@ var (
 	ErrForeignKeyViolated = errors.New("violates foreign key constraint")
 	// ErrCheckConstraintViolated occurs when there is a check constraint violation
 	ErrCheckConstraintViolated = errors.New("violates check constraint")
+	// ErrNotScalarResult result has more than one column or row when scanning into scalar
+	ErrNotScalarResult = errors.New("result is not a scalar")
+	// ErrUnsupportedIsolationLevel unsupported transaction isolation level
+	ErrUnsupportedIsolationLevel = errors.New("unsupported isolation level")
+	// ErrFieldValueTooLong occurs when a value exceeds the field's declared size
+	ErrFieldValueTooLong = errors.New("field value too long")
+	// ErrInvalidIdentifier occurs when table name or raw column identifier doesn't match the safe pattern
+	ErrInvalidIdentifier = errors.New("invalid identifier")
+	// ErrSkipRow returned from BeforeCreate to exclude the row from the insert without failing the statement
+	ErrSkipRow = errors.New("skip row")
+	// ErrReadOnlyTransaction occurs when writing in the transaction begun by ReadOnlyTransaction
+	ErrReadOnlyTransaction = errors.New("write is not allowed in read-only transaction")
+	// ErrConstraintHandled set to db.Error after a ConstraintHandler recovered the violation, stops the remaining
+	// callbacks of the failed statement, it is cleared once the callbacks finished
+	ErrConstraintHandled = errors.New("constraint violation handled")
 )
+
+// ConstraintError constraint violation error with the violated constraint name, error translators could return it
+// wrapping ErrDuplicatedKey, ErrForeignKeyViolated or ErrCheckConstraintViolated to be handled by ConstraintHandlers
+type ConstraintError struct {
+	Constraint string
+	Err        error
+}
+
+func (e *ConstraintError) Error() string {
+	return fmt.Sprintf("%v: %s", e.Err, e.Constraint)
+}
+
+func (e *ConstraintError) Unwrap() error {
+	return e.Err
+}
+
+// ConstraintHandler handle the constraint violation of the statement, returns nil if recovered, for example,
+// retried with different values, or returns the error to replace the violation, for example, a domain error
+//
+// the failed statement may be executed in the default transaction, which is aborted by the violation on postgres,
+// enable SkipDefaultTransaction if the handler retries the statement, the default transaction is committed if recovered
+type ConstraintHandler func(stmt *Statement, err *ConstraintError) error
+
+// TranslatorChain ordered error translators, the first translator recognizing the error wins,
+// an error is recognized if the translator returns a different error, unrecognized errors pass through unchanged
+type TranslatorChain []ErrorTranslator
+
+// Translate translate the error with the translators in order
+func (chain TranslatorChain) Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	for _, translator := range chain {
+		if translator == nil {
+			continue
+		}
+
+		if translated := translator.Translate(err); translated != nil && !sameError(translated, err) {
+			return translated
+		}
+	}
+	return err
+}
+
+// sameError whether the translator returns the error unchanged, errors might be not comparable
+func sameError(a, b error) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+
+	if reflect.TypeOf(a).Comparable() {
+		return a == b
+	}
+	return reflect.DeepEqual(a, b)
+}
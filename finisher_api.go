@@ -26,7 +26,11 @@ func (db *DB) Create(value interface{}) (tx *DB) {
 	return tx.callbacks.Create().Execute(tx)
 }
 
-// CreateInBatches inserts value in batches of batchSize
+// CreateInBatches inserts value in batches of batchSize, primary keys are backfilled batch by batch.
+// In dry run, only the SQL of the first batch is built, and the count of batches can be got with
+//
+//	tx := db.Session(&gorm.Session{DryRun: true}).CreateInBatches(&users, 100)
+//	count, _ := tx.Get("gorm:create_batch_count")
 func (db *DB) CreateInBatches(value interface{}, batchSize int) (tx *DB) {
 	reflectValue := reflect.Indirect(reflect.ValueOf(value))
 
@@ -38,6 +42,13 @@ func (db *DB) CreateInBatches(value interface{}, batchSize int) (tx *DB) {
 		// the reflection length judgment of the optimized value
 		reflectLen := reflectValue.Len()
 
+		// only the first batch is built in dry run, the count of batches is reported by `gorm:create_batch_count`
+		if tx.DryRun && reflectLen > batchSize {
+			tx.Statement.Settings.Store("gorm:create_batch_count", (reflectLen+batchSize-1)/batchSize)
+			tx.Statement.Dest = reflectValue.Slice(0, batchSize).Interface()
+			return tx.callbacks.Create().Execute(tx)
+		}
+
 		callFc := func(tx *DB) error {
 			for i := 0; i < reflectLen; i += batchSize {
 				ends := i + batchSize
@@ -71,6 +82,27 @@ func (db *DB) CreateInBatches(value interface{}, batchSize int) (tx *DB) {
 	return
 }
 
+// CreateWithOutbox inserts value and the outbox row in a single transaction, both of them are created
+// with create callbacks, the primary keys are backfilled into value and outbox, if any of them failed, both will be rolled back
+//
+//	db.CreateWithOutbox(&order, &OutboxEvent{Topic: "order.created", Payload: payload})
+func (db *DB) CreateWithOutbox(value interface{}, outbox interface{}) (tx *DB) {
+	var rowsAffected int64
+	tx = db.getInstance()
+	tx.AddError(tx.Transaction(func(tx *DB) error {
+		for _, dest := range []interface{}{value, outbox} {
+			result := tx.Create(dest)
+			if result.Error != nil {
+				return result.Error
+			}
+			rowsAffected += result.RowsAffected
+		}
+		return nil
+	}))
+	tx.RowsAffected = rowsAffected
+	return
+}
+
 // Save updates value in database. If value doesn't contain a matching primary key, value is inserted.
 func (db *DB) Save(value interface{}) (tx *DB) {
 	tx = db.getInstance()
@@ -245,6 +277,20 @@ func (db *DB) FindInBatches(dest interface{}, batchSize int, fc func(tx *DB, bat
 	return tx
 }
 
+// FindEach finds records and scans them into dest one by one, fc is called after each record scanned, preloaded and
+// AfterFind hooked, dest is reused between records, so copy it if it is needed after fc returns, the rows will be
+// closed and the error will be returned when fc returns an error
+//
+//	var user User
+//	db.Where("age > ?", 18).FindEach(&user, func(tx *gorm.DB) error {
+//	  return export(user)
+//	})
+func (db *DB) FindEach(dest interface{}, fc func(tx *DB) error) *DB {
+	tx := db.getInstance().Set("gorm:find_each", fc)
+	tx.Statement.Dest = dest
+	return tx.callbacks.Query().Execute(tx)
+}
+
 func (db *DB) assignInterfacesToValue(values ...interface{}) {
 	for _, value := range values {
 		switch v := value.(type) {
@@ -399,6 +445,212 @@ func (db *DB) FirstOrCreate(dest interface{}, conds ...interface{}) (tx *DB) {
 	return tx
 }
 
+// CreateOrGet inserts value with ON CONFLICT DO NOTHING, if the row already exists, the existing row
+// will be loaded into value in the same transaction, RowsAffected is 1 when inserted and 0 when loaded
+//
+// conflict columns are the columns of OnConflict clause, or unique fields having value, or primary keys
+//
+//	db.CreateOrGet(&User{Email: "jinzhu@example.org", Name: "jinzhu"})
+//	db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "email"}}}).CreateOrGet(&user)
+func (db *DB) CreateOrGet(value interface{}) (tx *DB) {
+	tx = db.getInstance()
+	if err := tx.Statement.Parse(value); err != nil {
+		tx.AddError(err)
+		return
+	}
+
+	reflectValue := reflect.Indirect(reflect.ValueOf(value))
+	if reflectValue.Kind() != reflect.Struct || !reflectValue.CanAddr() {
+		tx.AddError(ErrInvalidValue)
+		return
+	}
+
+	onConflict := clause.OnConflict{DoNothing: true}
+	if c, ok := tx.Statement.Clauses["ON CONFLICT"]; ok {
+		if oc, ok := c.Expression.(clause.OnConflict); ok {
+			onConflict.Columns, onConflict.TargetWhere = oc.Columns, oc.TargetWhere
+		}
+	}
+
+	var conflictFields []*schema.Field
+	for _, column := range onConflict.Columns {
+		if field := tx.Statement.Schema.LookUpField(column.Name); field != nil {
+			conflictFields = append(conflictFields, field)
+		}
+	}
+
+	if len(onConflict.Columns) == 0 {
+		for _, field := range tx.Statement.Schema.Fields {
+			if _, isZero := field.ValueOf(tx.Statement.Context, reflectValue); field.Unique && !isZero {
+				conflictFields = append(conflictFields, field)
+			}
+		}
+
+		if len(conflictFields) == 0 {
+			conflictFields = tx.Statement.Schema.PrimaryFields
+		}
+	}
+
+	if len(conflictFields) == 0 {
+		tx.AddError(ErrPrimaryKeyRequired)
+		return
+	}
+
+	var (
+		rowsAffected     int64
+		table, tableExpr = tx.Statement.Table, tx.Statement.TableExpr
+	)
+	tx.AddError(tx.Transaction(func(tx *DB) error {
+		result := tx.Clauses(onConflict).Create(value)
+		if result.Error != nil || result.RowsAffected > 0 {
+			rowsAffected = result.RowsAffected
+			return result.Error
+		}
+
+		conds := make([]clause.Expression, 0, len(conflictFields))
+		for _, field := range conflictFields {
+			fieldValue, _ := field.ValueOf(tx.Statement.Context, reflectValue)
+			conds = append(conds, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: fieldValue})
+		}
+
+		queryTx := tx.Session(&Session{NewDB: true}).getInstance()
+		queryTx.Statement.Table, queryTx.Statement.TableExpr = table, tableExpr
+
+		existing := reflect.New(reflectValue.Type())
+		if err := queryTx.Unscoped().Where(clause.And(conds...)).Take(existing.Interface()).Error; err != nil {
+			return err
+		}
+		reflectValue.Set(existing.Elem())
+		return nil
+	}))
+	tx.RowsAffected = rowsAffected
+	return
+}
+
+// CreateOrGetByKeys inserts value with ON CONFLICT DO NOTHING, if the row conflicts on any of the candidate unique keys,
+// the existing row matching any of the keys will be loaded into value, RowsAffected is 1 when inserted and 0 when loaded
+//
+// keys are lists of column or field names, unique fields, unique indexes and primary keys are the candidate keys if not
+// specified, keys having zero value are ignored. ErrInvalidData returned if rows of different keys are found
+//
+// the insert and the query run in a transaction, use READ COMMITTED, with REPEATABLE READ or higher isolation levels,
+// the row inserted by a concurrent transaction after the snapshot is invisible to the query and ErrRecordNotFound is
+// returned, it also returns ErrRecordNotFound if the existing row is deleted in between, retry it in such cases
+//
+//	db.CreateOrGetByKeys(&user, []string{"email"}, []string{"tenant_id", "username"})
+//	// INSERT INTO users ... ON CONFLICT DO NOTHING
+//	// SELECT * FROM users WHERE email = ? OR (tenant_id = ? AND username = ?) LIMIT 2
+func (db *DB) CreateOrGetByKeys(value interface{}, keys ...[]string) (tx *DB) {
+	tx = db.getInstance()
+	if err := tx.Statement.Parse(value); err != nil {
+		tx.AddError(err)
+		return
+	}
+
+	reflectValue := reflect.Indirect(reflect.ValueOf(value))
+	if reflectValue.Kind() != reflect.Struct || !reflectValue.CanAddr() {
+		tx.AddError(ErrInvalidValue)
+		return
+	}
+
+	var (
+		sch        = tx.Statement.Schema
+		candidates [][]*schema.Field
+	)
+	for _, key := range keys {
+		fields := make([]*schema.Field, 0, len(key))
+		for _, name := range key {
+			field := sch.LookUpField(name)
+			if field == nil || field.DBName == "" {
+				tx.AddError(fmt.Errorf("%w: unknown key column %s", ErrInvalidField, name))
+				return
+			}
+			fields = append(fields, field)
+		}
+		candidates = append(candidates, fields)
+	}
+
+	if len(keys) == 0 {
+		for _, field := range sch.Fields {
+			if field.Unique {
+				candidates = append(candidates, []*schema.Field{field})
+			}
+		}
+
+	INDEXES:
+		for _, idx := range sch.ParseIndexes() {
+			if idx.Class != "UNIQUE" || idx.Where != "" {
+				continue
+			}
+
+			fields := make([]*schema.Field, 0, len(idx.Fields))
+			for _, option := range idx.Fields {
+				if option.Field == nil || option.Expression != "" {
+					continue INDEXES
+				}
+				fields = append(fields, option.Field)
+			}
+			candidates = append(candidates, fields)
+		}
+
+		if len(sch.PrimaryFields) > 0 {
+			candidates = append(candidates, sch.PrimaryFields)
+		}
+	}
+
+	conds := make([]clause.Expression, 0, len(candidates))
+CANDIDATES:
+	for _, fields := range candidates {
+		eqs := make([]clause.Expression, 0, len(fields))
+		for _, field := range fields {
+			fieldValue, isZero := field.ValueOf(tx.Statement.Context, reflectValue)
+			if isZero {
+				continue CANDIDATES
+			}
+			eqs = append(eqs, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: fieldValue})
+		}
+		conds = append(conds, clause.And(eqs...))
+	}
+
+	if len(conds) == 0 {
+		tx.AddError(fmt.Errorf("%w: no unique key has value", ErrPrimaryKeyRequired))
+		return
+	}
+
+	var (
+		rowsAffected     int64
+		table, tableExpr = tx.Statement.Table, tx.Statement.TableExpr
+	)
+	tx.AddError(tx.Transaction(func(tx *DB) error {
+		// conflicts on any unique key are skipped without the conflict target
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(value)
+		if result.Error != nil || result.RowsAffected > 0 {
+			rowsAffected = result.RowsAffected
+			return result.Error
+		}
+
+		queryTx := tx.Session(&Session{NewDB: true}).getInstance()
+		queryTx.Statement.Table, queryTx.Statement.TableExpr = table, tableExpr
+
+		existings := reflect.New(reflect.SliceOf(reflectValue.Type()))
+		if err := queryTx.Unscoped().Where(clause.Or(conds...)).Limit(2).Find(existings.Interface()).Error; err != nil {
+			return err
+		}
+
+		switch existings.Elem().Len() {
+		case 0:
+			return ErrRecordNotFound
+		case 1:
+			reflectValue.Set(existings.Elem().Index(0))
+			return nil
+		default:
+			return fmt.Errorf("%w: rows of different unique keys found", ErrInvalidData)
+		}
+	}))
+	tx.RowsAffected = rowsAffected
+	return
+}
+
 // Update updates column with value using callbacks. Reference: https://gorm.io/docs/update.html#Update-Changed-Fields
 func (db *DB) Update(column string, value interface{}) (tx *DB) {
 	tx = db.getInstance()
@@ -427,6 +679,48 @@ func (db *DB) UpdateColumns(values interface{}) (tx *DB) {
 	return tx.callbacks.Update().Execute(tx)
 }
 
+// UpdateInBulk updates many rows with different values in one statement, rows are matched by the primary key of model
+//
+//	db.Model(&User{}).UpdateInBulk([]clause.KeyedAssignments{
+//	  {Key: 1, Values: map[string]interface{}{"name": "a"}},
+//	  {Key: 2, Values: map[string]interface{}{"name": "b", "age": 18}},
+//	})
+//	// UPDATE users SET age=CASE id WHEN 2 THEN 18 ELSE age END,name=CASE id WHEN 1 THEN 'a' WHEN 2 THEN 'b' ELSE name END WHERE id IN (1,2)
+func (db *DB) UpdateInBulk(rows []clause.KeyedAssignments) (tx *DB) {
+	tx = db.getInstance()
+
+	keys := make([]interface{}, 0, len(rows))
+	for _, row := range rows {
+		if len(row.Values) > 0 {
+			keys = append(keys, row.Key)
+		}
+	}
+
+	if len(keys) == 0 {
+		tx.AddError(ErrEmptySlice)
+		return tx
+	}
+
+	if tx.Statement.Model == nil {
+		tx.AddError(ErrModelValueRequired)
+		return tx
+	}
+
+	if err := tx.Statement.Parse(tx.Statement.Model); err != nil {
+		tx.AddError(err)
+		return tx
+	}
+
+	primaryField := tx.Statement.Schema.PrioritizedPrimaryField
+	if primaryField == nil || len(tx.Statement.Schema.PrimaryFields) > 1 {
+		tx.AddError(fmt.Errorf("%w: bulk update requires a single primary key", ErrPrimaryKeyRequired))
+		return tx
+	}
+
+	key := clause.Column{Table: clause.CurrentTable, Name: primaryField.DBName}
+	return tx.Where(clause.IN{Column: key, Values: keys}).Updates(clause.BulkAssignments(key, rows))
+}
+
 // Delete deletes value matching given conditions. If value contains primary key it is included in the conditions. If
 // value includes a deleted_at field, then Delete performs a soft delete instead by setting deleted_at with the current
 // time if null.
@@ -441,6 +735,73 @@ func (db *DB) Delete(value interface{}, conds ...interface{}) (tx *DB) {
 	return tx.callbacks.Delete().Execute(tx)
 }
 
+// DeleteInBatches deletes records matching given conditions in batches of batchSize until none remain, which avoids
+// long locks and huge transactions of deleting large amount of rows. Each batch is committed in its own transaction,
+// wrap it with Transaction to commit them at once. Batches are deleted with `DELETE ... LIMIT` if the dialect supports,
+// otherwise with a subquery on the primary key, RowsAffected is the total deleted rows
+//
+//	db.Where("created_at < ?", expiredAt).DeleteInBatches(&Log{}, 1000)
+//	// DELETE FROM logs WHERE created_at < ? LIMIT 1000
+//	// DELETE FROM logs WHERE logs.id IN (SELECT id FROM logs WHERE created_at < ? ORDER BY logs.id LIMIT 1000)
+func (db *DB) DeleteInBatches(value interface{}, batchSize int, conds ...interface{}) (tx *DB) {
+	tx = db.getInstance()
+	if batchSize <= 0 {
+		tx.AddError(fmt.Errorf("%w: batch size should be positive, got %d", ErrInvalidData, batchSize))
+		return tx
+	}
+
+	// the subquery is always a condition, check the missing conditions in advance
+	if _, ok := tx.Statement.Clauses["WHERE"]; !ok && len(conds) == 0 && !tx.AllowGlobalUpdate {
+		tx.AddError(ErrMissingWhereClause)
+		return tx
+	}
+
+	var batchDB *DB
+	if utils.Contains(tx.callbacks.Delete().Clauses, "LIMIT") {
+		batchDB = tx.Limit(batchSize).Session(&Session{})
+	} else {
+		if err := tx.Statement.Parse(value); err != nil {
+			tx.AddError(err)
+			return tx
+		}
+
+		primaryField := tx.Statement.Schema.PrioritizedPrimaryField
+		if primaryField == nil || len(tx.Statement.Schema.PrimaryFields) > 1 {
+			tx.AddError(fmt.Errorf("%w: deleting in batches requires a single primary key", ErrPrimaryKeyRequired))
+			return tx
+		}
+
+		column := clause.Column{Table: clause.CurrentTable, Name: primaryField.DBName}
+		subQuery := tx.Session(&Session{}).Model(value).Select(primaryField.DBName)
+		if len(conds) > 0 {
+			subQuery = subQuery.Where(conds[0], conds[1:]...)
+		}
+		subQuery = subQuery.Order(clause.OrderByColumn{Column: column}).Limit(batchSize)
+
+		batchDB = tx.Session(&Session{}).getInstance()
+		delete(batchDB.Statement.Clauses, "WHERE")
+		batchDB = batchDB.Where("? IN (?)", column, subQuery).Session(&Session{})
+		conds = nil
+	}
+
+	var rowsAffected int64
+	for {
+		result := batchDB.Delete(value, conds...)
+		rowsAffected += result.RowsAffected
+		if result.Error != nil {
+			tx.AddError(result.Error)
+			break
+		}
+
+		if result.RowsAffected < int64(batchSize) {
+			break
+		}
+	}
+
+	tx.RowsAffected = rowsAffected
+	return tx
+}
+
 func (db *DB) Count(count *int64) (tx *DB) {
 	tx = db.getInstance()
 	if tx.Statement.Model == nil {
@@ -503,6 +864,28 @@ func (db *DB) Count(count *int64) (tx *DB) {
 	return
 }
 
+// Exists checks whether any record matches the conditions without fetching rows, E.g:
+//
+//	exists, err := db.Model(&User{}).Exists("name = ?", "jinzhu")
+//	// SELECT EXISTS(SELECT 1 FROM `users` WHERE name = "jinzhu" AND `users`.`deleted_at` IS NULL LIMIT 1)
+func (db *DB) Exists(conds ...interface{}) (exists bool, err error) {
+	tx := db.Limit(1)
+	if len(conds) > 0 {
+		if exprs := tx.Statement.BuildCondition(conds[0], conds[1:]...); len(exprs) > 0 {
+			tx.Statement.AddClause(clause.Where{Exprs: exprs})
+		}
+	}
+
+	if tx.Statement.Model == nil {
+		tx.Statement.Model = tx.Statement.Dest
+	}
+	tx.Statement.Selects = nil
+	tx.Statement.AddClause(clause.Select{Expression: clause.Expr{SQL: "1"}})
+
+	err = tx.Session(&Session{NewDB: true}).Raw("SELECT EXISTS(?)", tx).Scan(&exists).Error
+	return
+}
+
 func (db *DB) Row() *sql.Row {
 	tx := db.getInstance().Set("rows", false)
 	tx = tx.callbacks.Row().Execute(tx)
@@ -549,6 +932,48 @@ func (db *DB) Scan(dest interface{}) (tx *DB) {
 	return
 }
 
+// ScanScalar scans a single-column single-row result into the scalar dest, E.g:
+//
+//	var count int64
+//	db.Raw("SELECT count(*) FROM users WHERE age > ?", 18).ScanScalar(&count)
+//
+// returns ErrRecordNotFound when no row found, ErrNotScalarResult if the result has more than one column or row
+func (db *DB) ScanScalar(dest interface{}) (tx *DB) {
+	config := *db.Config
+	currentLogger, newLogger := config.Logger, logger.Recorder.New()
+	config.Logger = newLogger
+
+	tx = db.getInstance()
+	tx.Config = &config
+
+	if rv := reflect.ValueOf(dest); rv.Kind() != reflect.Ptr || rv.IsNil() {
+		tx.AddError(fmt.Errorf("%w: scalar dest should be a non-nil pointer, got %T", ErrInvalidValue, dest))
+	} else if rows, err := tx.Rows(); err == nil {
+		if columns, err := rows.Columns(); err != nil {
+			tx.AddError(err)
+		} else if len(columns) != 1 {
+			tx.AddError(fmt.Errorf("%w: got %d columns", ErrNotScalarResult, len(columns)))
+		} else if rows.Next() {
+			tx.RowsAffected = 1
+			if tx.AddError(rows.Scan(dest)) == nil && rows.Next() {
+				tx.AddError(fmt.Errorf("%w: got more than one row", ErrNotScalarResult))
+			}
+		} else {
+			tx.RowsAffected = 0
+			if tx.AddError(rows.Err()) == nil {
+				tx.AddError(ErrRecordNotFound)
+			}
+		}
+		tx.AddError(rows.Close())
+	}
+
+	currentLogger.Trace(tx.Statement.Context, newLogger.BeginAt, func() (string, int64) {
+		return newLogger.SQL, tx.RowsAffected
+	}, tx.Error)
+	tx.Logger = currentLogger
+	return
+}
+
 // Pluck queries a single column from a model, returning in the slice dest. E.g.:
 //
 //	var ages []int64
@@ -661,6 +1086,60 @@ func (db *DB) Transaction(fc func(tx *DB) error, opts ...*sql.TxOptions) (err er
 	return
 }
 
+var (
+	// readOnlyTransactionSQLs statements issued after beginning the read-only transaction,
+	// used if the dialector doesn't implement ReadOnlyTransactionDialectorInterface
+	readOnlyTransactionSQLs = map[string]string{
+		"postgres": "SET TRANSACTION READ ONLY",
+	}
+	// readOnlyTxOptionUnsupported dialects whose drivers reject sql.TxOptions with ReadOnly,
+	// used if the dialector doesn't implement ReadOnlyTransactionDialectorInterface
+	readOnlyTxOptionUnsupported = map[string]bool{
+		"sqlserver": true,
+	}
+)
+
+// readOnlyTransaction returns whether sql.TxOptions with ReadOnly is supported, and the statement issued after beginning
+func readOnlyTransaction(dialector Dialector) (txOption bool, readOnlySQL string) {
+	if d, ok := dialector.(ReadOnlyTransactionDialectorInterface); ok {
+		return d.SupportReadOnlyTxOption(), d.ReadOnlyTransactionSQL()
+	}
+
+	name := dialector.Name()
+	return !readOnlyTxOptionUnsupported[name], readOnlyTransactionSQLs[name]
+}
+
+// ReadOnlyTransaction start a read-only transaction as a block, Create, Update and Delete in fc return
+// ErrReadOnlyTransaction. The transaction is begun with sql.TxOptions{ReadOnly: true} and `SET TRANSACTION READ ONLY`
+// is issued where the dialect supports, so that raw writes are rejected by the database as well. Nested in another
+// transaction, only the writes of Create, Update and Delete are rejected
+//
+//	db.ReadOnlyTransaction(func(tx *gorm.DB) error {
+//		return tx.Where("created_at > ?", since).Find(&orders).Error
+//	})
+func (db *DB) ReadOnlyTransaction(fc func(tx *DB) error) error {
+	var (
+		tx                    = db.Set("gorm:read_only_transaction", true)
+		txOption, readOnlySQL = readOnlyTransaction(tx.Dialector)
+		opts                  []*sql.TxOptions
+	)
+
+	committer, nested := tx.Statement.ConnPool.(TxCommitter)
+	nested = nested && committer != nil
+	if txOption {
+		opts = append(opts, &sql.TxOptions{ReadOnly: true})
+	}
+
+	return tx.Transaction(func(tx *DB) error {
+		if readOnlySQL != "" && !nested {
+			if err := tx.Exec(readOnlySQL).Error; err != nil {
+				return err
+			}
+		}
+		return fc(tx)
+	}, opts...)
+}
+
 // Begin begins a transaction with any transaction options opts
 func (db *DB) Begin(opts ...*sql.TxOptions) *DB {
 	var (
@@ -674,6 +1153,16 @@ func (db *DB) Begin(opts ...*sql.TxOptions) *DB {
 		opt = opts[0]
 	}
 
+	if v, ok := tx.Statement.Settings.Load("gorm:isolation_level"); ok {
+		if level, ok := v.(sql.IsolationLevel); ok {
+			if opt == nil {
+				opt = &sql.TxOptions{Isolation: level}
+			} else if opt.Isolation == sql.LevelDefault {
+				opt = &sql.TxOptions{Isolation: level, ReadOnly: opt.ReadOnly}
+			}
+		}
+	}
+
 	ctx := tx.Statement.Context
 	if _, ok := ctx.Deadline(); !ok {
 		if db.Config.DefaultTransactionTimeout > 0 {
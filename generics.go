@@ -540,6 +540,7 @@ func (c chainG[T]) Build(builder clause.Builder) {
 
 			subdb.Statement.SQL.Reset()
 			subdb.Statement.Vars = stmt.Vars
+			subdb.Statement.bindVars = stmt.bindVars
 			if strings.Contains(sql, "@") {
 				clause.NamedExpr{SQL: sql, Vars: vars}.Build(subdb.Statement)
 			} else {
@@ -547,11 +548,13 @@ func (c chainG[T]) Build(builder clause.Builder) {
 			}
 		} else {
 			subdb.Statement.Vars = append(stmt.Vars, subdb.Statement.Vars...)
-			subdb.callbacks.Query().Execute(subdb)
+			subdb.Statement.bindVars = stmt.bindVars
+			subdb.callbacks.Query().execute(subdb, true)
 		}
 
 		builder.WriteString(subdb.Statement.SQL.String())
 		stmt.Vars = subdb.Statement.Vars
+		stmt.bindVars = subdb.Statement.bindVars
 	}
 }
 
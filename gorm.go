@@ -3,6 +3,7 @@ package gorm
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"reflect"
 	"sort"
@@ -18,6 +19,14 @@ import (
 // for Config.cacheStore store PreparedStmtDB key
 const preparedStmtDBKey = "preparedStmt"
 
+// for Config.cacheStore store the root cacheStore of a naming strategy cache
+const namingRootKey = "namingRoot"
+
+// namingCacheKey for Config.cacheStore store the schema cache of a session naming strategy
+type namingCacheKey struct {
+	namer schema.Namer
+}
+
 // Config GORM config
 type Config struct {
 	// GORM perform single create, update, delete operations in transactions by default to ensure database data integrity
@@ -60,9 +69,28 @@ type Config struct {
 	TranslateError bool
 	// PropagateUnscoped propagate Unscoped to every other nested statement
 	PropagateUnscoped bool
+	// ValidateFieldSize check string/bytes values against field's `size` before creating or updating
+	ValidateFieldSize bool
+	// ErrorWithSQL wrap execution errors with the explained SQL, vars are filtered by the logger's ParamsFilter
+	ErrorWithSQL bool
+	// ValidateIdentifiers check table name and raw column identifiers against a safe pattern when building statements,
+	// table expressions like `Table("users AS u")` or `Table("(?) AS t", subQuery)` are SQL and not validated
+	ValidateIdentifiers bool
+	// DedupConditions collapse identical WHERE conditions added multiple times into one, e.g: by reusable scopes,
+	// conditions with side effects like `random() < ?` are collapsed too
+	DedupConditions bool
+	// CheckBindVars debug the Dialector's BindVarTo, counts the placeholders written for the vars and checks the numbered
+	// placeholders like $1, @p1 match the vars' positions, mismatches are logged as errors with the SQL
+	CheckBindVars bool
 
 	// ClauseBuilders clause builder
 	ClauseBuilders map[string]clause.ClauseBuilder
+	// ErrorTranslators translate errors before the ErrorTranslator of the Dialector when TranslateError enabled,
+	// composed as a TranslatorChain, e.g: translating the errors of a custom driver or plugin
+	ErrorTranslators []ErrorTranslator
+	// ConstraintHandlers constraint violation handlers by the constraint name, requires the ErrorTranslator
+	// translating the constraint violations into *ConstraintError
+	ConstraintHandlers map[string]ConstraintHandler
 	// ConnPool db conn pool
 	ConnPool ConnPool
 	// Dialector database dialector
@@ -132,6 +160,7 @@ type Session struct {
 	Logger                   logger.Interface
 	NowFunc                  func() time.Time
 	CreateBatchSize          int
+	NamingStrategy           schema.Namer
 }
 
 // Open 初始化数据库会话。
@@ -199,6 +228,10 @@ func Open(dialector Dialector, opts ...Option) (db *DB, err error) {
 		config.ClauseBuilders = map[string]clause.ClauseBuilder{}
 	}
 
+	if config.ConstraintHandlers == nil {
+		config.ConstraintHandlers = map[string]ConstraintHandler{}
+	}
+
 	if config.Dialector != nil {
 		err = config.Dialector.Initialize(db)
 		if err != nil {
@@ -211,7 +244,15 @@ func Open(dialector Dialector, opts ...Option) (db *DB, err error) {
 			return
 		}
 
-		if config.TranslateError {
+		if dialector, ok := db.Dialector.(RowAliasDialectorInterface); ok {
+			onDuplicateKeyUpdate := clause.OnDuplicateKeyUpdate{}
+			if dialector.SupportRowAlias() {
+				onDuplicateKeyUpdate.RowAlias = clause.DefaultRowAlias
+			}
+			db.ClauseBuilders["ON CONFLICT"] = onDuplicateKeyUpdate.Build
+		}
+
+		if config.TranslateError && len(config.ErrorTranslators) == 0 {
 			if _, ok := db.Dialector.(ErrorTranslator); !ok {
 				config.Logger.Warn(context.Background(), "The TranslateError option is enabled, but the Dialector %s does not implement ErrorTranslator.", db.Dialector.Name())
 			}
@@ -275,7 +316,13 @@ func (db *DB) Session(config *Session) *DB {
 		txConfig.PropagateUnscoped = true
 	}
 
-	if config.Context != nil || config.PrepareStmt || config.SkipHooks {
+	if config.NamingStrategy != nil {
+		txConfig.NamingStrategy = config.NamingStrategy
+		// schemas parsed with a different namer must not be shared with the global schema cache
+		txConfig.cacheStore = db.namingCacheStore(config.NamingStrategy)
+	}
+
+	if config.Context != nil || config.PrepareStmt || config.SkipHooks || config.NamingStrategy != nil {
 		tx.Statement = tx.Statement.clone()
 		tx.Statement.DB = tx
 	}
@@ -351,6 +398,50 @@ func (db *DB) WithContext(ctx context.Context) *DB {
 	return db.Session(&Session{Context: ctx})
 }
 
+// WithOperation tag following operations with a logical operation name, the name is included in trace logs
+// and available in Statement.Context with logger.OperationFromContext, E.g:
+//
+//	db.WithOperation("CreateOrder").Create(&order)
+func (db *DB) WithOperation(name string) *DB {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return db.WithContext(logger.ContextWithOperation(ctx, name))
+}
+
+// namingCacheStore returns the schema cache shared by sessions using namer, the cache lives in the
+// root cacheStore, so it grows once per distinct comparable namer and schemas are never mixed between namers
+func (db *DB) namingCacheStore(namer schema.Namer) *sync.Map {
+	if reflect.DeepEqual(namer, db.NamingStrategy) {
+		return db.cacheStore
+	}
+
+	if !reflect.TypeOf(namer).Comparable() {
+		cacheStore := &sync.Map{}
+		if v, ok := db.cacheStore.Load(preparedStmtDBKey); ok {
+			cacheStore.Store(preparedStmtDBKey, v)
+		}
+		return cacheStore
+	}
+
+	// nested sessions look up namers in the root cacheStore, so caches are not nested
+	root := db.cacheStore
+	if v, ok := root.Load(namingRootKey); ok {
+		root = v.(*sync.Map)
+	}
+
+	v, loaded := root.LoadOrStore(namingCacheKey{namer: namer}, &sync.Map{})
+	cacheStore := v.(*sync.Map)
+	if !loaded {
+		cacheStore.Store(namingRootKey, root)
+	}
+	if v, ok := db.cacheStore.Load(preparedStmtDBKey); ok {
+		cacheStore.LoadOrStore(preparedStmtDBKey, v)
+	}
+	return cacheStore
+}
+
 // Debug start debug mode
 func (db *DB) Debug() (tx *DB) {
 	tx = db.getInstance()
@@ -389,14 +480,51 @@ func (db *DB) Callback() *callbacks {
 }
 
 // AddError add error to db
+//
+// the error is translated by the ErrorTranslators and the ErrorTranslator of the Dialector first if TranslateError enabled, then the translated *ConstraintError
+// is passed to the handler registered with the violated constraint name in ConstraintHandlers, the error returned by
+// the handler replaces the original one, and if the handler returns nil, the error is recovered and won't be added
+// to db.Error, but still returned to stop the current operation, db.Error is set to ErrConstraintHandled until the
+// remaining callbacks of the statement finished, so they won't run on the failed statement
+//
+// violations of the statements executed inside the handler, e.g: the retry, are not handled again
 func (db *DB) AddError(err error) error {
 	if err != nil {
 		if db.Config.TranslateError {
-			if errTranslator, ok := db.Dialector.(ErrorTranslator); ok {
+			if len(db.ErrorTranslators) > 0 {
+				chain := make(TranslatorChain, 0, len(db.ErrorTranslators)+1)
+				chain = append(chain, db.ErrorTranslators...)
+				if errTranslator, ok := db.Dialector.(ErrorTranslator); ok {
+					chain = append(chain, errTranslator)
+				}
+				err = chain.Translate(err)
+			} else if errTranslator, ok := db.Dialector.(ErrorTranslator); ok {
 				err = errTranslator.Translate(err)
 			}
 		}
 
+		var constraintErr *ConstraintError
+		if len(db.ConstraintHandlers) > 0 && errors.As(err, &constraintErr) {
+			if handler, ok := db.ConstraintHandlers[constraintErr.Constraint]; ok && !isHandlingConstraint(db.Statement.Context) {
+				ctx := db.Statement.Context
+				if ctx == nil {
+					ctx = context.Background()
+				}
+				db.Statement.Context = context.WithValue(ctx, constraintHandlingKey{}, constraintErr.Constraint)
+				handled := handler(db.Statement, constraintErr)
+				db.Statement.Context = ctx
+
+				if handled != nil {
+					err = handled
+				} else {
+					if db.Error == nil {
+						db.Error = ErrConstraintHandled
+					}
+					return err
+				}
+			}
+		}
+
 		if db.Error == nil {
 			db.Error = err
 		} else {
@@ -406,6 +534,14 @@ func (db *DB) AddError(err error) error {
 	return db.Error
 }
 
+// constraintHandlingKey context key of the constraint being handled by ConstraintHandlers
+type constraintHandlingKey struct{}
+
+// isHandlingConstraint whether the statement is executed inside a ConstraintHandler
+func isHandlingConstraint(ctx context.Context) bool {
+	return ctx != nil && ctx.Value(constraintHandlingKey{}) != nil
+}
+
 // DB returns `*sql.DB`
 func (db *DB) DB() (*sql.DB, error) {
 	connPool := db.ConnPool
@@ -539,5 +675,5 @@ func (db *DB) ToSQL(queryFn func(tx *DB) *DB) string {
 	tx := queryFn(db.Session(&Session{DryRun: true, SkipDefaultTransaction: true}).getInstance())
 	stmt := tx.Statement
 
-	return db.Dialector.Explain(stmt.SQL.String(), stmt.Vars...)
+	return stmt.explain(stmt.SQL.String(), stmt.Vars...)
 }
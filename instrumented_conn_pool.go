@@ -0,0 +1,187 @@
+package gorm
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"time"
+)
+
+// StatementStats stats of a statement executed by the InstrumentedConnPool
+type StatementStats struct {
+	SQL      string
+	Vars     []interface{}
+	Duration time.Duration
+	// RowsAffected rows affected by ExecContext, it is -1 for queries as the returned rows are unknown until they are scanned
+	RowsAffected int64
+	Error        error
+	// Transaction whether the statement is executed in a transaction
+	Transaction bool
+}
+
+// InstrumentedConnPool wraps the ConnPool, reports the SQL, duration and rows affected of every statement
+type InstrumentedConnPool struct {
+	ConnPool
+	OnStatement func(StatementStats)
+}
+
+// NewInstrumentedConnPool wraps the connPool to call onStatement after every ExecContext, QueryContext and QueryRowContext, e.g:
+//
+//	db.Statement.ConnPool = gorm.NewInstrumentedConnPool(db.Statement.ConnPool, func(stats gorm.StatementStats) {
+//		metrics.Observe(stats.SQL, stats.Duration, stats.RowsAffected)
+//	})
+//
+// transactions begun with it are instrumented too, a Tx is wrapped as a Tx so it could be used inside a transaction
+func NewInstrumentedConnPool(connPool ConnPool, onStatement func(StatementStats)) ConnPool {
+	pool := &InstrumentedConnPool{ConnPool: connPool, OnStatement: onStatement}
+	if tx, ok := connPool.(Tx); ok {
+		return &InstrumentedTx{Tx: tx, InstrumentedConnPool: pool}
+	}
+	return pool
+}
+
+func (db *InstrumentedConnPool) report(query string, args []interface{}, begin time.Time, rowsAffected int64, err error, isTransaction bool) {
+	if db.OnStatement != nil {
+		db.OnStatement(StatementStats{
+			SQL:          query,
+			Vars:         args,
+			Duration:     time.Since(begin),
+			RowsAffected: rowsAffected,
+			Error:        err,
+			Transaction:  isTransaction,
+		})
+	}
+}
+
+func (db *InstrumentedConnPool) execContext(ctx context.Context, conn ConnPool, isTransaction bool, query string, args ...interface{}) (sql.Result, error) {
+	begin := time.Now()
+	result, err := conn.ExecContext(ctx, query, args...)
+	rowsAffected := int64(-1)
+	if err == nil && result != nil {
+		if rows, rowsErr := result.RowsAffected(); rowsErr == nil {
+			rowsAffected = rows
+		}
+	}
+	db.report(query, args, begin, rowsAffected, err, isTransaction)
+	return result, err
+}
+
+func (db *InstrumentedConnPool) queryContext(ctx context.Context, conn ConnPool, isTransaction bool, query string, args ...interface{}) (*sql.Rows, error) {
+	begin := time.Now()
+	rows, err := conn.QueryContext(ctx, query, args...)
+	db.report(query, args, begin, -1, err, isTransaction)
+	return rows, err
+}
+
+func (db *InstrumentedConnPool) queryRowContext(ctx context.Context, conn ConnPool, isTransaction bool, query string, args ...interface{}) *sql.Row {
+	begin := time.Now()
+	row := conn.QueryRowContext(ctx, query, args...)
+	var err error
+	if row != nil {
+		err = row.Err()
+	}
+	db.report(query, args, begin, -1, err, isTransaction)
+	return row
+}
+
+// GetDBConn returns the underlying *sql.DB connection
+func (db *InstrumentedConnPool) GetDBConn() (*sql.DB, error) {
+	if sqldb, ok := db.ConnPool.(*sql.DB); ok {
+		return sqldb, nil
+	}
+
+	if dbConnector, ok := db.ConnPool.(GetDBConnector); ok && dbConnector != nil {
+		return dbConnector.GetDBConn()
+	}
+
+	return nil, ErrInvalidDB
+}
+
+// BeginTx begins a transaction with the underlying ConnPool, the returned transaction is instrumented
+func (db *InstrumentedConnPool) BeginTx(ctx context.Context, opt *sql.TxOptions) (ConnPool, error) {
+	if beginner, ok := db.ConnPool.(TxBeginner); ok {
+		tx, err := beginner.BeginTx(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		return &InstrumentedTx{Tx: tx, InstrumentedConnPool: db}, nil
+	}
+
+	beginner, ok := db.ConnPool.(ConnPoolBeginner)
+	if !ok {
+		return nil, ErrInvalidTransaction
+	}
+
+	connPool, err := beginner.BeginTx(ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+	if tx, ok := connPool.(Tx); ok {
+		return &InstrumentedTx{Tx: tx, InstrumentedConnPool: db}, nil
+	}
+	return nil, ErrInvalidTransaction
+}
+
+func (db *InstrumentedConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return db.execContext(ctx, db.ConnPool, false, query, args...)
+}
+
+func (db *InstrumentedConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return db.queryContext(ctx, db.ConnPool, false, query, args...)
+}
+
+func (db *InstrumentedConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return db.queryRowContext(ctx, db.ConnPool, false, query, args...)
+}
+
+func (db *InstrumentedConnPool) Ping() error {
+	if pinger, ok := db.ConnPool.(interface{ Ping() error }); ok {
+		return pinger.Ping()
+	}
+
+	conn, err := db.GetDBConn()
+	if err != nil {
+		return err
+	}
+	return conn.Ping()
+}
+
+// InstrumentedTx transaction of the InstrumentedConnPool
+type InstrumentedTx struct {
+	Tx
+	InstrumentedConnPool *InstrumentedConnPool
+}
+
+func (tx *InstrumentedTx) GetDBConn() (*sql.DB, error) {
+	return tx.InstrumentedConnPool.GetDBConn()
+}
+
+func (tx *InstrumentedTx) Commit() error {
+	if tx.Tx != nil && !reflect.ValueOf(tx.Tx).IsNil() {
+		return tx.Tx.Commit()
+	}
+	return ErrInvalidTransaction
+}
+
+func (tx *InstrumentedTx) Rollback() error {
+	if tx.Tx != nil && !reflect.ValueOf(tx.Tx).IsNil() {
+		return tx.Tx.Rollback()
+	}
+	return ErrInvalidTransaction
+}
+
+func (tx *InstrumentedTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return tx.InstrumentedConnPool.execContext(ctx, tx.Tx, true, query, args...)
+}
+
+func (tx *InstrumentedTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.InstrumentedConnPool.queryContext(ctx, tx.Tx, true, query, args...)
+}
+
+func (tx *InstrumentedTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return tx.InstrumentedConnPool.queryRowContext(ctx, tx.Tx, true, query, args...)
+}
+
+func (tx *InstrumentedTx) Ping() error {
+	return tx.InstrumentedConnPool.Ping()
+}
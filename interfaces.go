@@ -39,12 +39,29 @@ type ConnPool interface {
 	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
 }
 
+// PipelineConnPool 管道连接池接口，在一次网络往返中执行多条语句。
+type PipelineConnPool interface {
+	ExecPipeline(ctx context.Context, queries []PipelineQuery) []PipelineResult
+}
+
+// CopyFromConnPool 支持COPY协议的连接池接口（例如pgx），用于批量导入数据。
+type CopyFromConnPool interface {
+	CopyFrom(ctx context.Context, table string, columns []string, rows [][]interface{}) (int64, error)
+}
+
 // SavePointerDialectorInterface 保存指针接口。
 type SavePointerDialectorInterface interface {
 	SavePoint(tx *DB, name string) error
 	RollbackTo(tx *DB, name string) error
 }
 
+// PreparedTransactionDialectorInterface 两阶段提交接口，例如 postgres 的 PREPARE TRANSACTION。
+type PreparedTransactionDialectorInterface interface {
+	PrepareTransaction(tx *DB, id string) error
+	CommitPrepared(db *DB, id string) error
+	RollbackPrepared(db *DB, id string) error
+}
+
 // TxBeginner 事务开始器接口。
 type TxBeginner interface {
 	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
@@ -55,6 +72,136 @@ type ConnPoolBeginner interface {
 	BeginTx(ctx context.Context, opts *sql.TxOptions) (ConnPool, error)
 }
 
+// MergeDialectorInterface MERGE 语句方言接口。
+type MergeDialectorInterface interface {
+	SupportMerge() bool
+}
+
+// MergeDoNothingDialectorInterface MERGE 语句 DO NOTHING 动作方言接口，由 MergeDialectorInterface 的方言实现，
+// 不支持时使用 DO NOTHING 的 MERGE 返回 ErrUnsupportedDriver，未实现该接口时 sqlserver、oracle 视为不支持。
+type MergeDoNothingDialectorInterface interface {
+	SupportMergeDoNothing() bool
+}
+
+// QualifyDialectorInterface QUALIFY 子句方言接口，不支持时 clause.Qualify 会被改写为子查询。
+type QualifyDialectorInterface interface {
+	SupportQualify() bool
+}
+
+// ReturningClauseSupporter RETURNING 方言接口，用于按数据库版本判断是否支持 RETURNING，
+// 例如 MariaDB 10.5 起支持 RETURNING，而 MySQL 不支持。
+type ReturningClauseSupporter interface {
+	SupportReturning() bool
+}
+
+// IdentifierFolder 标识符大小写折叠方言接口，返回方言折叠后的标识符，
+// 例如 oracle 将未加引号的标识符折叠为大写，RETURNING 返回的列名可能与模型的列名大小写不同。
+type IdentifierFolder interface {
+	FoldIdentifier(name string) string
+}
+
+// LimitDialectorInterface 分页语法方言接口，返回方言的分页语法，以及 LIMIT/OFFSET 的数量能否使用绑定变量。
+// 方言同时实现 LimitRewriter 时，只在 RewriteLimit 返回 nil 时使用。
+type LimitDialectorInterface interface {
+	LimitStyle() clause.LimitStyle
+	LimitBindVar() bool
+}
+
+// LimitRewriter 分页重写方言接口，用于没有 LIMIT/OFFSET 语法的数据库，例如 sqlserver 的 OFFSET ... FETCH、旧版 oracle 的 ROWNUM，
+// limit、offset 未设置时为 nil，返回的表达式在构建查询时替换 LIMIT 子句，返回 nil 时使用标准的 LIMIT 子句，
+// 方言注册了 LIMIT 的 ClauseBuilder 时，替换后的子句仍由该 ClauseBuilder 构建。
+// 同时实现 LimitDialectorInterface 时 LimitRewriter 优先，返回 nil 时才按 LimitStyle 构建 LIMIT 子句。
+type LimitRewriter interface {
+	RewriteLimit(stmt *Statement, limit, offset *int) clause.Expression
+}
+
+// RowAliasDialectorInterface ON DUPLICATE KEY UPDATE 方言接口，实现该接口的方言使用 clause.OnDuplicateKeyUpdate 构建 ON CONFLICT 子句，
+// 支持行别名时使用 `INSERT ... AS new ON DUPLICATE KEY UPDATE col=new.col`，否则使用 VALUES(col)，
+// 例如 MySQL 8.0.20 起支持行别名，旧版本及 MariaDB 不支持，可以使用 clause.SupportRowAlias 按服务端版本判断。
+type RowAliasDialectorInterface interface {
+	SupportRowAlias() bool
+}
+
+// RowLockingDialectorInterface 行锁方言接口，返回方言是否支持 FOR UPDATE、FOR SHARE 等锁强度，
+// 不支持时 clause.Locking 返回错误，例如 sqlserver 使用表提示，oracle 只支持 FOR UPDATE。
+type RowLockingDialectorInterface interface {
+	SupportLockingStrength(strength string) bool
+}
+
+// CollateDialectorInterface 排序规则方言接口，返回方言是否支持在比较表达式后使用 COLLATE，
+// 未实现该接口时 clause.Collate 按方言名称判断。
+type CollateDialectorInterface interface {
+	SupportCollate() bool
+}
+
+// CreateTableAsDialectorInterface 查询建表方言接口，返回方言将查询结果物化为新表的语法，
+// 例如 CREATE TABLE ... AS 或 sqlserver 的 SELECT ... INTO，未实现该接口时 clause.CreateTableAs 按方言名称判断。
+type CreateTableAsDialectorInterface interface {
+	CreateTableAsSyntax() clause.CreateTableAsSyntax
+}
+
+// BoolLiteralDialectorInterface 布尔字面量方言接口，返回方言是否支持 TRUE/FALSE 字面量，不支持时 clause.Bool 使用 1/0，
+// 未实现该接口时按方言名称判断，例如 sqlserver、oracle 不支持。
+type BoolLiteralDialectorInterface interface {
+	SupportBoolLiteral() bool
+}
+
+// NullsOrderDialectorInterface 空值排序方言接口，返回方言是否支持 NULLS FIRST/LAST，不支持时 clause.OrderBy 使用 CASE WHEN 模拟，
+// 未实现该接口时按方言名称判断。
+type NullsOrderDialectorInterface interface {
+	SupportNullsOrder() bool
+}
+
+// OrdinalGroupByDialectorInterface 分组序号方言接口，返回方言是否支持 GROUP BY 1 这样按选择列序号分组，
+// 不支持时 clause.GroupBy 输出警告，未实现该接口时按方言名称判断。
+type OrdinalGroupByDialectorInterface interface {
+	SupportOrdinalGroupBy() bool
+}
+
+// ConditionalValuesDialectorInterface 条件插入方言接口，返回 `INSERT ... SELECT ? WHERE ...` 选择值时需要的 FROM 子句，
+// 例如 mysql、oracle 的 " FROM DUAL"，不需要时返回空字符串，未实现该接口时 clause.ConditionalValues 按方言名称判断。
+type ConditionalValuesDialectorInterface interface {
+	ConditionalValuesFrom() string
+}
+
+// TypeCastDialectorInterface 类型转换方言接口，用于无法推断参数类型的数据库，例如 postgres 将 UNION ALL 选择的参数推断为 text，
+// 实现该接口的方言在批量条件插入时将值转换为字段类型，批量插入时将全为 NULL 的列转换为字段类型，
+// CastTo 返回 sql 转换为 dataType 的表达式，例如 `?::numeric`、`NULL::numeric`。
+type TypeCastDialectorInterface interface {
+	CastTo(sql, dataType string) string
+}
+
+// RecursiveCTEDialectorInterface 递归公用表表达式方言接口，返回方言是否支持 WITH RECURSIVE，
+// 不支持时 clause.With 返回错误，未实现该接口时按方言名称判断。
+type RecursiveCTEDialectorInterface interface {
+	SupportRecursiveCTE() bool
+}
+
+// ExtremumDialectorInterface 最值函数方言接口，返回 clause.AssignmentGreatest、clause.AssignmentLeast 使用的函数，
+// 例如 sqlite 的 MAX、MIN，返回空字符串时使用 CASE WHEN 比较，未实现该接口时按方言名称判断，默认使用 GREATEST、LEAST。
+type ExtremumDialectorInterface interface {
+	ExtremumFunctions() (greatest, least string)
+}
+
+// JSONAggDialectorInterface JSON 聚合方言接口，返回 JSONArrayAgg、JSONObject 使用的数组聚合函数与对象构建函数，
+// 例如 postgres 的 json_agg、json_build_object，未实现该接口时按方言名称判断。
+type JSONAggDialectorInterface interface {
+	JSONAggFunctions() (arrayAgg, object string)
+}
+
+// ReadOnlyTransactionDialectorInterface 只读事务方言接口，返回驱动是否支持 sql.TxOptions 的 ReadOnly，
+// 以及开始只读事务后执行的语句，例如 postgres 的 SET TRANSACTION READ ONLY，不需要时返回空字符串，
+// 未实现该接口时 ReadOnlyTransaction 按方言名称判断。
+type ReadOnlyTransactionDialectorInterface interface {
+	SupportReadOnlyTxOption() bool
+	ReadOnlyTransactionSQL() string
+}
+
+// IsolationLevelChecker 事务隔离级别检查器接口。
+type IsolationLevelChecker interface {
+	SupportIsolationLevel(level sql.IsolationLevel) bool
+}
+
 // TxCommitter 事务提交器接口。
 type TxCommitter interface {
 	Commit() error
@@ -88,6 +235,11 @@ type Rows interface {
 	Close() error
 }
 
+// RetryableErrorClassifier 错误分类方言接口，判断错误是否为可重试的暂时性错误、序列化失败或死锁。
+type RetryableErrorClassifier interface {
+	ClassifyError(err error) ErrorClass
+}
+
 // ErrorTranslator 错误翻译器接口。
 type ErrorTranslator interface {
 	Translate(err error) error
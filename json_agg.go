@@ -0,0 +1,93 @@
+package gorm
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// jsonAggFunctions json aggregation function names of dialects, [array aggregation, object builder],
+// used if the dialector doesn't implement JSONAggDialectorInterface
+var jsonAggFunctions = map[string][2]string{
+	"postgres": {"json_agg", "json_build_object"},
+	"mysql":    {"JSON_ARRAYAGG", "JSON_OBJECT"},
+	"sqlite":   {"json_group_array", "json_object"},
+}
+
+func jsonAggFunction(builder clause.Builder, idx int) (string, bool) {
+	if stmt, ok := builder.(*Statement); ok {
+		if dialector, ok := stmt.Dialector.(JSONAggDialectorInterface); ok {
+			arrayAgg, object := dialector.JSONAggFunctions()
+			return [2]string{arrayAgg, object}[idx], true
+		}
+
+		name := stmt.Dialector.Name()
+		if fns, ok := jsonAggFunctions[name]; ok {
+			return fns[idx], true
+		}
+		stmt.AddError(fmt.Errorf("%w: json aggregation is not supported by %s", ErrUnsupportedDriver, name))
+	}
+	return "", false
+}
+
+// JSONArrayAggExpr aggregate values into a JSON array
+type JSONArrayAggExpr struct {
+	Value interface{}
+}
+
+// JSONArrayAgg aggregate values into a JSON array, json_agg for postgres, JSON_ARRAYAGG for mysql,
+// json_group_array for sqlite, value could be a column, an expression or a value
+//
+//	db.Model(&User{}).Select("? AS pets", gorm.JSONArrayAgg(gorm.JSONObject("name", clause.Column{Name: "name"})))
+func JSONArrayAgg(value interface{}) JSONArrayAggExpr {
+	return JSONArrayAggExpr{Value: value}
+}
+
+// Build build json array aggregation
+func (expr JSONArrayAggExpr) Build(builder clause.Builder) {
+	if fn, ok := jsonAggFunction(builder, 0); ok {
+		builder.WriteString(fn)
+		builder.WriteByte('(')
+		builder.AddVar(builder, expr.Value)
+		builder.WriteByte(')')
+	}
+}
+
+// JSONObjectExpr build a JSON object from key value pairs
+type JSONObjectExpr struct {
+	Keys   []string
+	Values []interface{}
+}
+
+// JSONObject build a JSON object from key value pairs, json_build_object for postgres, JSON_OBJECT for mysql,
+// json_object for sqlite, keys are written as string literals, values could be columns, expressions or values
+//
+//	gorm.JSONObject("name", clause.Column{Name: "name"}, "age", clause.Column{Name: "age"})
+func JSONObject(pairs ...interface{}) JSONObjectExpr {
+	var expr JSONObjectExpr
+	for i := 0; i+1 < len(pairs); i += 2 {
+		expr.Keys = append(expr.Keys, fmt.Sprint(pairs[i]))
+		expr.Values = append(expr.Values, pairs[i+1])
+	}
+	return expr
+}
+
+// Build build json object
+func (expr JSONObjectExpr) Build(builder clause.Builder) {
+	if fn, ok := jsonAggFunction(builder, 1); ok {
+		builder.WriteString(fn)
+		builder.WriteByte('(')
+		for idx, key := range expr.Keys {
+			if idx > 0 {
+				builder.WriteByte(',')
+			}
+
+			builder.WriteByte('\'')
+			builder.WriteString(strings.ReplaceAll(key, "'", "''"))
+			builder.WriteString("',")
+			builder.AddVar(builder, expr.Values[idx])
+		}
+		builder.WriteByte(')')
+	}
+}
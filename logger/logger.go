@@ -163,32 +163,55 @@ func (l *logger) Trace(ctx context.Context, begin time.Time, fc func() (string,
 	}
 
 	elapsed := time.Since(begin)
+	location := func() string {
+		if operation := OperationFromContext(ctx); operation != "" {
+			return utils.FileWithLineNum() + " [op:" + operation + "]"
+		}
+		return utils.FileWithLineNum()
+	}
+
 	switch {
 	case err != nil && l.LogLevel >= Error && (!errors.Is(err, ErrRecordNotFound) || !l.IgnoreRecordNotFoundError):
 		sql, rows := fc()
 		if rows == -1 {
-			l.Printf(l.traceErrStr, utils.FileWithLineNum(), err, float64(elapsed.Nanoseconds())/1e6, "-", sql)
+			l.Printf(l.traceErrStr, location(), err, float64(elapsed.Nanoseconds())/1e6, "-", sql)
 		} else {
-			l.Printf(l.traceErrStr, utils.FileWithLineNum(), err, float64(elapsed.Nanoseconds())/1e6, rows, sql)
+			l.Printf(l.traceErrStr, location(), err, float64(elapsed.Nanoseconds())/1e6, rows, sql)
 		}
 	case elapsed > l.SlowThreshold && l.SlowThreshold != 0 && l.LogLevel >= Warn:
 		sql, rows := fc()
 		slowLog := fmt.Sprintf("SLOW SQL >= %v", l.SlowThreshold)
 		if rows == -1 {
-			l.Printf(l.traceWarnStr, utils.FileWithLineNum(), slowLog, float64(elapsed.Nanoseconds())/1e6, "-", sql)
+			l.Printf(l.traceWarnStr, location(), slowLog, float64(elapsed.Nanoseconds())/1e6, "-", sql)
 		} else {
-			l.Printf(l.traceWarnStr, utils.FileWithLineNum(), slowLog, float64(elapsed.Nanoseconds())/1e6, rows, sql)
+			l.Printf(l.traceWarnStr, location(), slowLog, float64(elapsed.Nanoseconds())/1e6, rows, sql)
 		}
 	case l.LogLevel == Info:
 		sql, rows := fc()
 		if rows == -1 {
-			l.Printf(l.traceStr, utils.FileWithLineNum(), float64(elapsed.Nanoseconds())/1e6, "-", sql)
+			l.Printf(l.traceStr, location(), float64(elapsed.Nanoseconds())/1e6, "-", sql)
 		} else {
-			l.Printf(l.traceStr, utils.FileWithLineNum(), float64(elapsed.Nanoseconds())/1e6, rows, sql)
+			l.Printf(l.traceStr, location(), float64(elapsed.Nanoseconds())/1e6, rows, sql)
 		}
 	}
 }
 
+type operationCtxKey struct{}
+
+// ContextWithOperation returns a copy of ctx with the logical operation name, which is included in trace logs
+func ContextWithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationCtxKey{}, operation)
+}
+
+// OperationFromContext returns the logical operation name of ctx, returns empty string if not set
+func OperationFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	operation, _ := ctx.Value(operationCtxKey{}).(string)
+	return operation
+}
+
 // ParamsFilter filter params
 func (l *logger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
 	if l.Config.ParameterizedQueries {
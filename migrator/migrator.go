@@ -268,6 +268,8 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 						createTableSQL += " " + idx.Option
 					}
 
+					createTableSQL += m.IndexStorageOptions(idx)
+
 					createTableSQL += ","
 					values = append(values, clause.Column{Name: idx.Name}, tx.Migrator().(BuildIndexOptionsInterface).BuildIndexOptions(idx.Fields, stmt))
 				}
@@ -289,13 +291,24 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 			}
 
 			for _, uni := range stmt.Schema.ParseUniqueConstraints() {
-				createTableSQL += "CONSTRAINT ? UNIQUE (?),"
-				values = append(values, clause.Column{Name: uni.Name}, clause.Expr{SQL: stmt.Quote(uni.Field.DBName)})
+				if uni.IsIndex() {
+					defer func(uni schema.UniqueConstraint) {
+						if err == nil {
+							err = m.createUniqueIndex(tx, m.CurrentTable(stmt), &uni)
+						}
+					}(uni)
+					continue
+				}
+				uni = m.supportedUniqueConstraint(uni)
+				sql, vars := uni.Build()
+				createTableSQL += sql + ","
+				values = append(values, vars...)
 			}
 
 			for _, chk := range stmt.Schema.ParseCheckConstraints() {
-				createTableSQL += "CONSTRAINT ? CHECK (?),"
-				values = append(values, clause.Column{Name: chk.Name}, clause.Expr{SQL: chk.Constraint})
+				sql, vars := chk.Build()
+				createTableSQL += sql + ","
+				values = append(values, vars...)
 			}
 
 			createTableSQL = strings.TrimSuffix(createTableSQL, ",")
@@ -597,6 +610,13 @@ func (m Migrator) MigrateColumnUnique(value interface{}, field *schema.Field, co
 		// We're currently only receiving boolean values on `Unique` tag,
 		// so the UniqueConstraint name is fixed
 		constraint := m.DB.NamingStrategy.UniqueName(stmt.Table, field.DBName)
+		if uni, ok := stmt.Schema.ParseUniqueConstraints()[constraint]; ok && uni.IsIndex() {
+			// partial unique constraint or unique constraint declared with asIndex is created as index, the column itself isn't unique
+			if !m.DB.Migrator().HasIndex(value, constraint) {
+				return m.createUniqueIndex(m.DB, m.CurrentTable(stmt), &uni)
+			}
+			return nil
+		}
 		if unique && !field.Unique {
 			return m.DB.Migrator().DropConstraint(value, constraint)
 		}
@@ -758,6 +778,15 @@ func (m Migrator) CreateConstraint(value interface{}, name string) error {
 			if stmt.TableExpr != nil {
 				vars[0] = stmt.TableExpr
 			}
+
+			if uni, ok := constraint.(*schema.UniqueConstraint); ok {
+				if uni.IsIndex() {
+					return m.createUniqueIndex(m.DB, vars[0], uni)
+				}
+				supported := m.supportedUniqueConstraint(*uni)
+				constraint = &supported
+			}
+
 			sql, values := constraint.Build()
 			return m.DB.Exec("ALTER TABLE ? ADD "+sql, append(vars, values...)...).Error
 		}
@@ -765,12 +794,77 @@ func (m Migrator) CreateConstraint(value interface{}, name string) error {
 	})
 }
 
+// createUniqueIndex create unique constraint as unique index, constraint with predicate is created as partial unique index,
+// CONCURRENTLY is only used on postgres outside of transactions as postgres doesn't allow it in a transaction block
+func (m Migrator) createUniqueIndex(db *gorm.DB, table interface{}, uni *schema.UniqueConstraint) error {
+	if uni.Where != "" && !m.partialIndexSupported(uni.Name) {
+		return nil
+	}
+
+	supported := m.supportedUniqueConstraint(*uni)
+	uni = &supported
+
+	_, inTransaction := db.Statement.ConnPool.(gorm.TxCommitter)
+	sql, vars := uni.BuildIndex(table, uni.Concurrently && m.Dialector.Name() == "postgres" && !inTransaction)
+	return db.Exec(sql, vars...).Error
+}
+
+// partialIndexSupported whether partial index is supported, declared by PartialIndexInterface, or supported by
+// dialects other than mysql and tidb by default, the partial unique constraint is skipped with a warning otherwise
+func (m Migrator) partialIndexSupported(name string) bool {
+	if checker, ok := m.Dialector.(PartialIndexInterface); ok {
+		if checker.SupportPartialIndex() {
+			return true
+		}
+	} else if dialect := m.Dialector.Name(); dialect != "mysql" && dialect != "tidb" {
+		return true
+	}
+
+	m.DB.Logger.Warn(m.DB.Statement.Context, "partial unique constraint %s is not supported by %s, skipped", name, m.Dialector.Name())
+	return false
+}
+
+// storageOptionsSupported whether storage parameters and tablespace of indexes and unique constraints are supported,
+// declared by StorageOptionsInterface or only postgres by default, they are ignored with a warning otherwise
+func (m Migrator) storageOptionsSupported(name string) bool {
+	if checker, ok := m.Dialector.(StorageOptionsInterface); ok {
+		if checker.SupportStorageOptions() {
+			return true
+		}
+	} else if m.Dialector.Name() == "postgres" {
+		return true
+	}
+
+	m.DB.Logger.Warn(m.DB.Statement.Context, "storage parameters and tablespace of %s are not supported by %s, ignored", name, m.Dialector.Name())
+	return false
+}
+
+// IndexStorageOptions returns ` WITH (...) TABLESPACE ...` of the index if storage options are supported, drivers
+// building their own CREATE INDEX statement, e.g: postgres, could append it to keep storage options of the index
+func (m Migrator) IndexStorageOptions(idx *schema.Index) string {
+	if idx.HasStorageOptions() && m.storageOptionsSupported(idx.Name) {
+		return idx.StorageOptions()
+	}
+	return ""
+}
+
+// supportedUniqueConstraint returns the unique constraint without storage options if they are not supported
+func (m Migrator) supportedUniqueConstraint(uni schema.UniqueConstraint) schema.UniqueConstraint {
+	if uni.HasStorageOptions() && !m.storageOptionsSupported(uni.Name) {
+		uni.StorageParams, uni.Tablespace = nil, ""
+	}
+	return uni
+}
+
 // DropConstraint drop constraint
 func (m Migrator) DropConstraint(value interface{}, name string) error {
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		constraint, table := m.GuessConstraintInterfaceAndTable(stmt, name)
 		if constraint != nil {
 			name = constraint.GetName()
+			if uni, ok := constraint.(*schema.UniqueConstraint); ok && uni.IsIndex() {
+				return m.DB.Migrator().DropIndex(value, name)
+			}
 		}
 		return m.DB.Exec("ALTER TABLE ? DROP CONSTRAINT ?", clause.Table{Name: table}, clause.Column{Name: name}).Error
 	})
@@ -784,6 +878,12 @@ func (m Migrator) HasConstraint(value interface{}, name string) bool {
 		constraint, table := m.GuessConstraintInterfaceAndTable(stmt, name)
 		if constraint != nil {
 			name = constraint.GetName()
+			if uni, ok := constraint.(*schema.UniqueConstraint); ok && uni.IsIndex() {
+				if m.DB.Migrator().HasIndex(value, name) {
+					count = 1
+				}
+				return nil
+			}
 		}
 
 		return m.DB.Raw(
@@ -817,6 +917,16 @@ func (m Migrator) BuildIndexOptions(opts []schema.IndexOption, stmt *gorm.Statem
 	return
 }
 
+// PartialIndexInterface dialector could declare whether partial index (CREATE INDEX ... WHERE) is supported
+type PartialIndexInterface interface {
+	SupportPartialIndex() bool
+}
+
+// StorageOptionsInterface dialector could declare whether storage parameters and tablespace of indexes are supported
+type StorageOptionsInterface interface {
+	SupportStorageOptions() bool
+}
+
 // BuildIndexOptionsInterface build index options interface
 type BuildIndexOptionsInterface interface {
 	BuildIndexOptions([]schema.IndexOption, *gorm.Statement) []interface{}
@@ -850,6 +960,8 @@ func (m Migrator) CreateIndex(value interface{}, name string) error {
 				createIndexSQL += " " + idx.Option
 			}
 
+			createIndexSQL += m.IndexStorageOptions(idx)
+
 			return m.DB.Exec(createIndexSQL, values...).Error
 		}
 
@@ -0,0 +1,118 @@
+package gorm
+
+import (
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// PipelineQuery statement queued in the pipeline
+type PipelineQuery struct {
+	SQL  string
+	Vars []interface{}
+}
+
+// PipelineResult result of a statement executed in the pipeline
+type PipelineResult struct {
+	RowsAffected int64
+	Error        error
+}
+
+// Pipeline collects built statements and executes them together
+type Pipeline struct {
+	db      *DB
+	queries []PipelineQuery
+	errs    []error
+}
+
+// Pipeline queues independent operations and flushes them in one network round-trip if the connection pool
+// implements PipelineConnPool, otherwise they are executed sequentially, E.g:
+//
+//	results := db.Pipeline().Create(&user).Create(&pet).Flush()
+//
+// hooks are skipped, associations are not saved as statements are built with DryRun, create them with their own Create,
+// and primary keys generated by the database are not assigned back to the values
+func (db *DB) Pipeline() *Pipeline {
+	return &Pipeline{db: db.Session(&Session{})}
+}
+
+// Create builds the create statement of value and queues it, associations of value are not saved
+func (p *Pipeline) Create(value interface{}) *Pipeline {
+	tx := p.db.Session(&Session{DryRun: true, SkipHooks: true, Initialized: true}).Omit(clause.Associations)
+	// returned rows are not read when executing in the pipeline
+	for _, name := range tx.callbacks.Create().Clauses {
+		if name != "RETURNING" {
+			tx.Statement.BuildClauses = append(tx.Statement.BuildClauses, name)
+		}
+	}
+
+	tx = tx.Create(value)
+	p.queries = append(p.queries, PipelineQuery{SQL: tx.Statement.SQL.String(), Vars: tx.Statement.Vars})
+	p.errs = append(p.errs, tx.Error)
+	return p
+}
+
+// Flush executes queued statements, returns results in the order they were queued
+func (p *Pipeline) Flush() []PipelineResult {
+	var (
+		db        = p.db.getInstance()
+		ctx       = db.Statement.Context
+		results   = make([]PipelineResult, len(p.queries))
+		queries   = make([]PipelineQuery, 0, len(p.queries))
+		positions = make([]int, 0, len(p.queries))
+	)
+
+	for idx, query := range p.queries {
+		if p.errs[idx] != nil {
+			results[idx].Error = p.errs[idx]
+		} else {
+			queries = append(queries, query)
+			positions = append(positions, idx)
+		}
+	}
+	p.queries, p.errs = nil, nil
+
+	if len(queries) == 0 {
+		return results
+	}
+
+	curTime := time.Now()
+	if pool, ok := db.Statement.ConnPool.(PipelineConnPool); ok {
+		for idx, result := range pool.ExecPipeline(ctx, queries) {
+			if idx < len(positions) {
+				results[positions[idx]] = result
+			}
+		}
+
+		for idx, query := range queries {
+			result := results[positions[idx]]
+			db.Logger.Trace(ctx, curTime, func() (string, int64) {
+				return p.explain(query), result.RowsAffected
+			}, result.Error)
+		}
+		return results
+	}
+
+	for idx, query := range queries {
+		curTime = time.Now()
+		result := &results[positions[idx]]
+		if res, err := db.Statement.ConnPool.ExecContext(ctx, query.SQL, query.Vars...); err != nil {
+			result.Error = err
+		} else {
+			result.RowsAffected, _ = res.RowsAffected()
+		}
+
+		db.Logger.Trace(ctx, curTime, func() (string, int64) {
+			return p.explain(query), result.RowsAffected
+		}, result.Error)
+	}
+	return results
+}
+
+func (p *Pipeline) explain(query PipelineQuery) string {
+	sql, vars := query.SQL, query.Vars
+	if filter, ok := p.db.Logger.(ParamsFilter); ok {
+		sql, vars = filter.ParamsFilter(p.db.Statement.Context, query.SQL, query.Vars...)
+	}
+	return p.db.Dialector.Explain(sql, vars...)
+}
@@ -225,9 +225,19 @@ func Scan(rows Rows, db *DB, mode ScanMode) {
 
 			// Not Pluck
 			if sch != nil {
+				// columns returned by RETURNING might be folded to upper or lower case by the dialect
+				lookUpField := sch.LookUpField
+				if _, ok := db.Statement.Clauses["RETURNING"]; ok {
+					if folder, ok := db.Dialector.(IdentifierFolder); ok {
+						lookUpField = func(name string) *schema.Field {
+							return sch.LookUpFieldFold(name, folder.FoldIdentifier)
+						}
+					}
+				}
+
 				matchedFieldCount := make(map[string]int, len(columns))
 				for idx, column := range columns {
-					if field := sch.LookUpField(column); field != nil && field.Readable {
+					if field := lookUpField(column); field != nil && field.Readable {
 						fields[idx] = field
 						if count, ok := matchedFieldCount[column]; ok {
 							// handle duplicate fields
@@ -1,7 +1,9 @@
 package schema
 
 import (
+	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"gorm.io/gorm/clause"
@@ -22,7 +24,7 @@ func (chk *CheckConstraint) GetName() string { return chk.Name }
 
 // Build 构建检查约束的SQL。
 func (chk *CheckConstraint) Build() (sql string, vars []interface{}) {
-	return "CONSTRAINT ? CHECK (?)", []interface{}{clause.Column{Name: chk.Name}, clause.Expr{SQL: chk.Constraint}}
+	return "CONSTRAINT ? CHECK (?)", []interface{}{clause.Column{Name: chk.Name}, clause.BoolExpr{SQL: chk.Constraint}}
 }
 
 // ParseCheckConstraints 解析模式中的检查约束。
@@ -46,17 +48,53 @@ func (schema *Schema) ParseCheckConstraints() map[string]CheckConstraint {
 }
 
 // UniqueConstraint 结构体，用于存储唯一约束相关的信息。
+//
+// Where 为 `where` 标签中的谓词，仅对满足条件的行唯一（部分索引），
+// 该谓词会原样拼接进 SQL，只能来自开发者定义的标签，不可来自用户输入。
+//
+// AsIndex 来自 `asIndex` 标签，以独立的 CREATE UNIQUE INDEX 代替表约束创建，
+// `asIndex:concurrently` 在 postgres 的事务之外使用 CONCURRENTLY 创建索引。
+//
+// StorageParams 与 Tablespace 来自 `storage` 与 `tablespace` 标签，多个存储参数以空格分隔，
+// 例如 `unique;storage:fillfactor=70;tablespace:fast_ssd`，仅 postgres 支持。
 type UniqueConstraint struct {
-	Name  string
-	Field *Field
+	Name          string
+	Field         *Field
+	Where         string // status <> 'archived'
+	AsIndex       bool
+	Concurrently  bool
+	StorageParams []string // fillfactor=70
+	Tablespace    string
 }
 
 // GetName 获取唯一约束的名称。
 func (uni *UniqueConstraint) GetName() string { return uni.Name }
 
+// IsIndex 唯一约束是否以唯一索引的形式创建，部分唯一约束总是以索引创建。
+func (uni *UniqueConstraint) IsIndex() bool { return uni.AsIndex || uni.Where != "" }
+
+// HasStorageOptions 唯一约束是否设置了存储参数或表空间。
+func (uni *UniqueConstraint) HasStorageOptions() bool {
+	return len(uni.StorageParams) > 0 || uni.Tablespace != ""
+}
+
 // Build 构建唯一约束的SQL。
 func (uni *UniqueConstraint) Build() (sql string, vars []interface{}) {
-	return "CONSTRAINT ? UNIQUE (?)", []interface{}{clause.Column{Name: uni.Name}, clause.Column{Name: uni.Field.DBName}}
+	sql = "CONSTRAINT ? UNIQUE (?)" + buildStorageOptions(uni.StorageParams, uni.Tablespace, " USING INDEX TABLESPACE ")
+	return sql, []interface{}{clause.Column{Name: uni.Name}, clause.Column{Name: uni.Field.DBName}}
+}
+
+// BuildIndex 构建唯一索引形式的SQL，concurrently 为 true 时添加 CONCURRENTLY。
+func (uni *UniqueConstraint) BuildIndex(table interface{}, concurrently bool) (sql string, vars []interface{}) {
+	sql = "CREATE UNIQUE INDEX ? ON ? (?)"
+	if concurrently {
+		sql = "CREATE UNIQUE INDEX CONCURRENTLY ? ON ? (?)"
+	}
+	sql += buildStorageOptions(uni.StorageParams, uni.Tablespace, " TABLESPACE ")
+	if uni.Where != "" {
+		sql += " WHERE " + uni.Where
+	}
+	return sql, []interface{}{clause.Column{Name: uni.Name}, table, clause.Column{Name: uni.Field.DBName}}
 }
 
 // ParseUniqueConstraints 解析模式中的唯一约束。
@@ -65,8 +103,203 @@ func (schema *Schema) ParseUniqueConstraints() map[string]UniqueConstraint {
 	for _, field := range schema.Fields {
 		if field.Unique {
 			name := schema.namer.UniqueName(schema.Table, field.DBName)
-			uniques[name] = UniqueConstraint{Name: name, Field: field}
+			asIndex, ok := field.TagSettings["ASINDEX"]
+			storageParams, tablespace, _ := parseStorageOptions(field.TagSettings)
+			uniques[name] = UniqueConstraint{
+				Name:          name,
+				Field:         field,
+				Where:         field.TagSettings["WHERE"],
+				AsIndex:       ok,
+				Concurrently:  strings.EqualFold(asIndex, "CONCURRENTLY"),
+				StorageParams: storageParams,
+				Tablespace:    tablespace,
+			}
 		}
 	}
 	return uniques
 }
+
+// isBalancedPredicate 检查谓词中的括号与引号是否成对，且不包含语句分隔符。
+func isBalancedPredicate(predicate string) bool {
+	var (
+		depth int
+		quote rune
+	)
+
+	for _, r := range predicate {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			if depth--; depth < 0 {
+				return false
+			}
+		case r == ';':
+			return false
+		}
+	}
+	return depth == 0 && quote == 0
+}
+
+// referentialActions 外键约束支持的引用动作。
+var referentialActions = map[string]bool{
+	"CASCADE":     true,
+	"SET NULL":    true,
+	"SET DEFAULT": true,
+	"RESTRICT":    true,
+	"NO ACTION":   true,
+}
+
+// normalizeReferentialAction 规范化引用动作的大小写与空白，不支持的动作返回错误。
+func normalizeReferentialAction(action string) (string, error) {
+	if action = strings.ToUpper(strings.Join(strings.Fields(action), " ")); action == "" || referentialActions[action] {
+		return action, nil
+	}
+	return "", fmt.Errorf("unsupported referential action %q", action)
+}
+
+// ForeignKeyConstraint 结构体，用于存储外键约束相关的信息，支持复合外键。
+//
+// 既可以由关联关系（belongs to）推导，也可以通过 `foreignKeyConstraint` 标签显式声明：
+//
+//	OrgID     uint   `gorm:"foreignKeyConstraint:fk_members_org,orgs.id,onDelete:CASCADE"`
+//	OrgRegion string `gorm:"foreignKeyConstraint:fk_members_org,orgs.region"`
+//
+// 同名的标签按字段声明顺序合并为一个复合外键。
+type ForeignKeyConstraint struct {
+	Name           string
+	Columns        []string
+	ForeignTable   string
+	ForeignColumns []string
+	OnDelete       string
+	OnUpdate       string
+}
+
+// GetName 获取外键约束的名称。
+func (fk *ForeignKeyConstraint) GetName() string { return fk.Name }
+
+// Build 构建外键约束的SQL。
+func (fk *ForeignKeyConstraint) Build() (sql string, vars []interface{}) {
+	sql = "CONSTRAINT ? FOREIGN KEY ? REFERENCES ??"
+	if fk.OnDelete != "" {
+		sql += " ON DELETE " + fk.OnDelete
+	}
+
+	if fk.OnUpdate != "" {
+		sql += " ON UPDATE " + fk.OnUpdate
+	}
+
+	columns := make([]interface{}, 0, len(fk.Columns))
+	for _, column := range fk.Columns {
+		columns = append(columns, clause.Column{Name: column})
+	}
+
+	foreignColumns := make([]interface{}, 0, len(fk.ForeignColumns))
+	for _, column := range fk.ForeignColumns {
+		foreignColumns = append(foreignColumns, clause.Column{Name: column})
+	}
+	return sql, []interface{}{clause.Table{Name: fk.Name}, columns, clause.Table{Name: fk.ForeignTable}, foreignColumns}
+}
+
+// ParseForeignKeyConstraints 解析模式中的外键约束，包括关联关系推导的和标签显式声明的，结果按名称排序。
+func (schema *Schema) ParseForeignKeyConstraints() ([]ForeignKeyConstraint, error) {
+	var (
+		names       []string
+		constraints = map[string]*ForeignKeyConstraint{}
+	)
+
+	add := func(fk *ForeignKeyConstraint) error {
+		var err error
+		if fk.OnDelete, err = normalizeReferentialAction(fk.OnDelete); err != nil {
+			return fmt.Errorf("invalid on delete action for foreign key constraint %s: %w", fk.Name, err)
+		}
+		if fk.OnUpdate, err = normalizeReferentialAction(fk.OnUpdate); err != nil {
+			return fmt.Errorf("invalid on update action for foreign key constraint %s: %w", fk.Name, err)
+		}
+
+		exist, ok := constraints[fk.Name]
+		if !ok {
+			names = append(names, fk.Name)
+			constraints[fk.Name] = fk
+			return nil
+		}
+
+		if exist.ForeignTable != fk.ForeignTable {
+			return fmt.Errorf("foreign key constraint %s references both %s and %s", fk.Name, exist.ForeignTable, fk.ForeignTable)
+		}
+		for _, action := range [][2]*string{{&exist.OnDelete, &fk.OnDelete}, {&exist.OnUpdate, &fk.OnUpdate}} {
+			if *action[0] == "" {
+				*action[0] = *action[1]
+			} else if *action[1] != "" && *action[0] != *action[1] {
+				return fmt.Errorf("foreign key constraint %s has conflicting actions %s and %s", fk.Name, *action[0], *action[1])
+			}
+		}
+		exist.Columns = append(exist.Columns, fk.Columns...)
+		exist.ForeignColumns = append(exist.ForeignColumns, fk.ForeignColumns...)
+		return nil
+	}
+
+	for _, rel := range schema.Relationships.Relations {
+		if rel.Field.IgnoreMigration {
+			continue
+		}
+
+		if constraint := rel.ParseConstraint(); constraint != nil && constraint.Schema == schema && len(constraint.ForeignKeys) > 0 {
+			fk := &ForeignKeyConstraint{
+				Name:         constraint.Name,
+				ForeignTable: constraint.ReferenceSchema.Table,
+				OnDelete:     constraint.OnDelete,
+				OnUpdate:     constraint.OnUpdate,
+			}
+			for idx, field := range constraint.ForeignKeys {
+				fk.Columns = append(fk.Columns, field.DBName)
+				fk.ForeignColumns = append(fk.ForeignColumns, constraint.References[idx].DBName)
+			}
+			if err := add(fk); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, field := range schema.Fields {
+		str := field.TagSettings["FOREIGNKEYCONSTRAINT"]
+		if str == "" || field.DBName == "" {
+			continue
+		}
+
+		parts := strings.Split(str, ",")
+		if len(parts) < 2 || !regEnLetterAndMidline.MatchString(parts[0]) {
+			return nil, fmt.Errorf("invalid foreign key constraint %q for field %s, expects name,table.column", str, field.Name)
+		}
+
+		idx := strings.LastIndexByte(parts[1], '.')
+		if idx <= 0 || idx == len(parts[1])-1 {
+			return nil, fmt.Errorf("invalid foreign key reference %q for field %s, expects table.column", parts[1], field.Name)
+		}
+
+		settings := ParseTagSetting(strings.Join(parts[2:], ","), ",")
+		if err := add(&ForeignKeyConstraint{
+			Name:           parts[0],
+			Columns:        []string{field.DBName},
+			ForeignTable:   parts[1][:idx],
+			ForeignColumns: []string{parts[1][idx+1:]},
+			OnDelete:       settings["ONDELETE"],
+			OnUpdate:       settings["ONUPDATE"],
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(names)
+	results := make([]ForeignKeyConstraint, 0, len(names))
+	for _, name := range names {
+		results = append(results, *constraints[name])
+	}
+	return results, nil
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"testing"
 
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/schema"
 	"gorm.io/gorm/utils/tests"
 )
@@ -59,6 +60,9 @@ func TestParseUniqueConstraints(t *testing.T) {
 	type UserUnique struct {
 		Name1 string `gorm:"unique"`
 		Name2 string `gorm:"uniqueIndex"`
+		Email string `gorm:"unique;where:status <> 'archived'"`
+		Name3 string `gorm:"unique;asIndex"`
+		Name4 string `gorm:"unique;asIndex:concurrently"`
 	}
 
 	user, err := schema.Parse(&UserUnique{}, &sync.Map{}, schema.NamingStrategy{})
@@ -72,13 +76,164 @@ func TestParseUniqueConstraints(t *testing.T) {
 			Name:  "uni_user_uniques_name1",
 			Field: &schema.Field{Name: "Name1", Unique: true},
 		},
+		"uni_user_uniques_email": {
+			Name:  "uni_user_uniques_email",
+			Field: &schema.Field{Name: "Email", Unique: true},
+			Where: "status <> 'archived'",
+		},
+		"uni_user_uniques_name3": {
+			Name:    "uni_user_uniques_name3",
+			Field:   &schema.Field{Name: "Name3", Unique: true},
+			AsIndex: true,
+		},
+		"uni_user_uniques_name4": {
+			Name:         "uni_user_uniques_name4",
+			Field:        &schema.Field{Name: "Name4", Unique: true},
+			AsIndex:      true,
+			Concurrently: true,
+		},
 	}
 	for k, result := range results {
 		v, ok := constraints[k]
 		if !ok {
 			t.Errorf("Failed to found unique constraint %v from parsed constraints %+v", k, constraints)
 		}
-		tests.AssertObjEqual(t, result, v, "Name")
+		tests.AssertObjEqual(t, result, v, "Name", "Where", "AsIndex", "Concurrently")
 		tests.AssertObjEqual(t, result.Field, v.Field, "Name", "Unique", "UniqueIndex")
 	}
+
+	for k, isIndex := range map[string]bool{"uni_user_uniques_name1": false, "uni_user_uniques_email": true, "uni_user_uniques_name3": true} {
+		if uni := constraints[k]; uni.IsIndex() != isIndex {
+			t.Errorf("unique constraint %v should be created as index: %v", k, isIndex)
+		}
+	}
+
+	uni := constraints["uni_user_uniques_email"]
+	if sql, vars := uni.BuildIndex(clause.Table{Name: "users"}, true); sql != "CREATE UNIQUE INDEX CONCURRENTLY ? ON ? (?) WHERE status <> 'archived'" || len(vars) != 3 {
+		t.Errorf("invalid unique index sql, got %v %v", sql, vars)
+	}
+}
+
+func TestParseUniqueConstraintsWithInvalidPredicate(t *testing.T) {
+	type UserInvalidUnique struct {
+		Email string `gorm:"unique;where:(status <> 'archived'"`
+	}
+
+	if _, err := schema.Parse(&UserInvalidUnique{}, &sync.Map{}, schema.NamingStrategy{}); err == nil {
+		t.Errorf("should return error for unbalanced unique predicate")
+	}
+}
+
+func TestParseUniqueConstraintsWithStorageOptions(t *testing.T) {
+	type UserUniqueStorage struct {
+		Name  string `gorm:"unique;storage:fillfactor=70;tablespace:fast_ssd"`
+		Email string `gorm:"unique;asIndex;storage:fillfactor=80 deduplicate_items=off;tablespace:fast_ssd"`
+		Phone string `gorm:"unique"`
+	}
+
+	user, err := schema.Parse(&UserUniqueStorage{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("failed to parse user unique, got error %v", err)
+	}
+
+	constraints := user.ParseUniqueConstraints()
+
+	uni := constraints["uni_user_unique_storages_name"]
+	if sql, _ := uni.Build(); sql != "CONSTRAINT ? UNIQUE (?) WITH (fillfactor=70) USING INDEX TABLESPACE fast_ssd" {
+		t.Errorf("invalid unique constraint sql, got %v", sql)
+	}
+
+	uni = constraints["uni_user_unique_storages_email"]
+	if sql, _ := uni.BuildIndex(clause.Table{Name: "users"}, false); sql != "CREATE UNIQUE INDEX ? ON ? (?) WITH (fillfactor=80,deduplicate_items=off) TABLESPACE fast_ssd" {
+		t.Errorf("invalid unique index sql, got %v", sql)
+	}
+
+	uni = constraints["uni_user_unique_storages_phone"]
+	if sql, _ := uni.Build(); sql != "CONSTRAINT ? UNIQUE (?)" || uni.HasStorageOptions() {
+		t.Errorf("unique constraint sql should be unchanged without storage options, got %v", sql)
+	}
+
+	type UserInvalidUniqueStorage struct {
+		Name string `gorm:"unique;tablespace:fast ssd"`
+	}
+
+	if _, err := schema.Parse(&UserInvalidUniqueStorage{}, &sync.Map{}, schema.NamingStrategy{}); err == nil {
+		t.Errorf("should return error for invalid tablespace")
+	}
+}
+
+func TestParseForeignKeyConstraints(t *testing.T) {
+	type FKOrg struct {
+		ID     uint   `gorm:"primaryKey"`
+		Region string `gorm:"primaryKey"`
+	}
+
+	type FKMember struct {
+		ID        uint
+		OrgID     uint
+		OrgRegion string
+		Org       FKOrg  `gorm:"foreignKey:OrgID,OrgRegion;references:ID,Region;constraint:OnDelete:cascade,OnUpdate:set  null"`
+		TeamID    uint   `gorm:"foreignKeyConstraint:fk_members_team,teams.id,onDelete:RESTRICT"`
+		TeamCode  string `gorm:"foreignKeyConstraint:fk_members_team,teams.code"`
+		OwnerID   uint   `gorm:"foreignKeyConstraint:fk_a_owner,public.users.id"`
+	}
+
+	member, err := schema.Parse(&FKMember{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("failed to parse member, got error %v", err)
+	}
+
+	constraints, err := member.ParseForeignKeyConstraints()
+	if err != nil {
+		t.Fatalf("failed to parse foreign key constraints, got error %v", err)
+	}
+
+	expects := []schema.ForeignKeyConstraint{
+		{Name: "fk_a_owner", Columns: []string{"owner_id"}, ForeignTable: "public.users", ForeignColumns: []string{"id"}},
+		{Name: "fk_fk_members_org", Columns: []string{"org_id", "org_region"}, ForeignTable: "fk_orgs", ForeignColumns: []string{"id", "region"}, OnDelete: "CASCADE", OnUpdate: "SET NULL"},
+		{Name: "fk_members_team", Columns: []string{"team_id", "team_code"}, ForeignTable: "teams", ForeignColumns: []string{"id", "code"}, OnDelete: "RESTRICT"},
+	}
+	if !reflect.DeepEqual(constraints, expects) {
+		t.Fatalf("invalid foreign key constraints, expects %+v, got %+v", expects, constraints)
+	}
+
+	sql, vars := constraints[2].Build()
+	if sql != "CONSTRAINT ? FOREIGN KEY ? REFERENCES ?? ON DELETE RESTRICT" {
+		t.Errorf("invalid foreign key constraint sql, got %v", sql)
+	}
+	expectVars := []interface{}{
+		clause.Table{Name: "fk_members_team"},
+		[]interface{}{clause.Column{Name: "team_id"}, clause.Column{Name: "team_code"}},
+		clause.Table{Name: "teams"},
+		[]interface{}{clause.Column{Name: "id"}, clause.Column{Name: "code"}},
+	}
+	if !reflect.DeepEqual(vars, expectVars) {
+		t.Errorf("invalid foreign key constraint vars, expects %v, got %v", expectVars, vars)
+	}
+}
+
+func TestParseForeignKeyConstraintsWithInvalidTags(t *testing.T) {
+	type FKInvalidAction struct {
+		OrgID uint `gorm:"foreignKeyConstraint:fk_org,orgs.id,onDelete:DROP TABLE"`
+	}
+
+	type FKInvalidReference struct {
+		OrgID uint `gorm:"foreignKeyConstraint:fk_org,orgs"`
+	}
+
+	type FKConflictTable struct {
+		OrgID    uint   `gorm:"foreignKeyConstraint:fk_org,orgs.id"`
+		OrgAlias string `gorm:"foreignKeyConstraint:fk_org,teams.alias"`
+	}
+
+	for _, value := range []interface{}{&FKInvalidAction{}, &FKInvalidReference{}, &FKConflictTable{}} {
+		s, err := schema.Parse(value, &sync.Map{}, schema.NamingStrategy{})
+		if err != nil {
+			t.Fatalf("failed to parse %T, got error %v", value, err)
+		}
+
+		if _, err := s.ParseForeignKeyConstraints(); err == nil {
+			t.Errorf("should return error when parsing foreign key constraints of %T", value)
+		}
+	}
 }
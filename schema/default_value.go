@@ -0,0 +1,44 @@
+package schema
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var defaultValueFuncMap = sync.Map{}
+
+// DefaultValueFunc compute the default value of the field for each creating row, e.g: generated UUID
+type DefaultValueFunc func(ctx context.Context) interface{}
+
+// RegisterDefaultValueFunc register default value func used by `defaultFunc` tag
+func RegisterDefaultValueFunc(name string, fc DefaultValueFunc) {
+	defaultValueFuncMap.Store(strings.ToLower(name), fc)
+}
+
+// GetDefaultValueFunc get default value func
+func GetDefaultValueFunc(name string) (fc DefaultValueFunc, ok bool) {
+	v, ok := defaultValueFuncMap.Load(strings.ToLower(name))
+	if ok {
+		fc, ok = v.(DefaultValueFunc)
+	}
+	return fc, ok
+}
+
+func init() {
+	RegisterDefaultValueFunc("uuid", NewUUID)
+}
+
+// NewUUID generate a random (version 4) UUID string
+func NewUUID(ctx context.Context) interface{} {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
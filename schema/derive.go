@@ -0,0 +1,83 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"gorm.io/gorm/utils"
+)
+
+var deriverMap = sync.Map{}
+
+// DeriveFunc derive the value of field from the value of the source field
+type DeriveFunc func(ctx context.Context, source interface{}) (interface{}, error)
+
+// RegisterDeriver register deriver used by `deriver` tag
+func RegisterDeriver(name string, deriver DeriveFunc) {
+	deriverMap.Store(strings.ToLower(name), deriver)
+}
+
+// GetDeriver get deriver
+func GetDeriver(name string) (deriver DeriveFunc, ok bool) {
+	v, ok := deriverMap.Load(strings.ToLower(name))
+	if ok {
+		deriver, ok = v.(DeriveFunc)
+	}
+	return deriver, ok
+}
+
+func init() {
+	RegisterDeriver("slug", Slugify)
+}
+
+// Slugify lowercase letters and digits of the source, other characters are replaced with `-`
+func Slugify(ctx context.Context, source interface{}) (interface{}, error) {
+	var (
+		builder strings.Builder
+		dash    bool
+	)
+
+	for _, r := range strings.ToLower(fmt.Sprint(source)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && builder.Len() > 0 {
+				builder.WriteByte('-')
+			}
+			builder.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return builder.String(), nil
+}
+
+// parseDerivedFields setup derived fields, `derivedFrom:title;deriver:slug;deriveOnUpdate`
+func (schema *Schema) parseDerivedFields() {
+	for _, field := range schema.Fields {
+		source, ok := field.TagSettings["DERIVEDFROM"]
+		if !ok || field.DBName == "" {
+			continue
+		}
+
+		if field.DerivedFrom = schema.LookUpField(strings.TrimSpace(source)); field.DerivedFrom == nil {
+			schema.err = fmt.Errorf("invalid derivedFrom %s for field %s", source, field.Name)
+			continue
+		}
+
+		name := "slug"
+		if v, ok := field.TagSettings["DERIVER"]; ok {
+			name = strings.TrimSpace(v)
+		}
+
+		if field.Deriver, ok = GetDeriver(name); !ok {
+			schema.err = fmt.Errorf("invalid deriver %s for field %s", name, field.Name)
+			continue
+		}
+
+		field.DeriveOnUpdate = utils.CheckTruth(field.TagSettings["DERIVEONUPDATE"])
+		schema.DerivedFields = append(schema.DerivedFields, field)
+	}
+}
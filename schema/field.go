@@ -70,6 +70,7 @@ type Field struct {
 	HasDefaultValue        bool
 	DefaultValue           string
 	DefaultValueInterface  interface{}
+	DefaultValueFunc       DefaultValueFunc // computed in go for each creating row when the value is zero and no literal default
 	NotNull                bool
 	Unique                 bool
 	Comment                string
@@ -77,6 +78,13 @@ type Field struct {
 	Precision              int
 	Scale                  int
 	IgnoreMigration        bool
+	TriggerManaged         bool
+	Lazy                   bool // not selected by default, e.g: large blobs, select it explicitly to load
+	OnUpdate               string
+	DefaultExpr            string
+	DerivedFrom            *Field
+	Deriver                DeriveFunc
+	DeriveOnUpdate         bool
 	FieldType              reflect.Type
 	IndirectFieldType      reflect.Type
 	StructField            reflect.StructField
@@ -102,6 +110,22 @@ func (field *Field) BindName() string {
 	return strings.Join(field.BindNames, ".")
 }
 
+// checkUniqueTagSettings checks the predicate and storage options of the unique field
+func checkUniqueTagSettings(field *Field, tagSetting map[string]string) error {
+	if !field.Unique {
+		return nil
+	}
+
+	if where := tagSetting["WHERE"]; where != "" && !isBalancedPredicate(where) {
+		return fmt.Errorf("invalid unique predicate %s for field %s", where, field.Name)
+	}
+
+	if _, _, err := parseStorageOptions(tagSetting); err != nil {
+		return fmt.Errorf("invalid unique constraint for field %s: %w", field.Name, err)
+	}
+	return nil
+}
+
 // ParseField parses reflect.StructField to Field
 func (schema *Schema) ParseField(fieldStruct reflect.StructField) *Field {
 	var (
@@ -129,9 +153,17 @@ func (schema *Schema) ParseField(fieldStruct reflect.StructField) *Field {
 		NotNull:                utils.CheckTruth(tagSetting["NOT NULL"], tagSetting["NOTNULL"]),
 		Unique:                 utils.CheckTruth(tagSetting["UNIQUE"]),
 		Comment:                tagSetting["COMMENT"],
+		TriggerManaged:         utils.CheckTruth(tagSetting["TRIGGERMANAGED"]),
+		Lazy:                   utils.CheckTruth(tagSetting["LAZY"]) || strings.EqualFold(tagSetting["SELECT"], "false"),
+		OnUpdate:               strings.TrimSpace(tagSetting["ONUPDATE"]),
+		DefaultExpr:            strings.TrimSpace(tagSetting["DEFAULTEXPR"]),
 		AutoIncrementIncrement: DefaultAutoIncrementIncrement,
 	}
 
+	if err := checkUniqueTagSettings(field, tagSetting); err != nil {
+		schema.err = err
+	}
+
 	for field.IndirectFieldType.Kind() == reflect.Ptr {
 		field.IndirectFieldType = field.IndirectFieldType.Elem()
 	}
@@ -212,6 +244,12 @@ func (schema *Schema) ParseField(fieldStruct reflect.StructField) *Field {
 		field.DefaultValue = v
 	}
 
+	if name, ok := field.TagSettings["DEFAULTFUNC"]; ok {
+		if field.DefaultValueFunc, ok = GetDefaultValueFunc(strings.TrimSpace(name)); !ok {
+			schema.err = fmt.Errorf("invalid default value func %v for field %s", name, field.Name)
+		}
+	}
+
 	if num, ok := field.TagSettings["SIZE"]; ok {
 		if field.Size, err = strconv.Atoi(num); err != nil {
 			field.Size = -1
@@ -385,6 +423,12 @@ func (schema *Schema) ParseField(fieldStruct reflect.StructField) *Field {
 		}
 	}
 
+	// trigger managed columns are written by the database, never by GORM
+	if field.TriggerManaged {
+		field.Creatable = false
+		field.Updatable = false
+	}
+
 	// Normal anonymous field or having `EMBEDDED` tag
 	if _, ok := field.TagSettings["EMBEDDED"]; ok || (field.GORMDataType != Time && field.GORMDataType != Bytes && !isValuer &&
 		fieldStruct.Anonymous && (field.Creatable || field.Updatable || field.Readable)) {
@@ -2,19 +2,60 @@ package schema
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 )
 
+// regStorageParam match storage parameter like fillfactor=70
+var regStorageParam = regexp.MustCompile(`^[\w.]+=[\w.-]+$`)
+
 type Index struct {
-	Name    string
-	Class   string // UNIQUE | FULLTEXT | SPATIAL
-	Type    string // btree, hash, gist, spgist, gin, and brin
-	Where   string
-	Comment string
-	Option  string        // WITH PARSER parser_name
-	Fields  []IndexOption // Note: IndexOption's Field maybe the same
+	Name          string
+	Class         string // UNIQUE | FULLTEXT | SPATIAL
+	Type          string // btree, hash, gist, spgist, gin, and brin
+	Where         string
+	Comment       string
+	Option        string        // WITH PARSER parser_name
+	StorageParams []string      // fillfactor=70, postgres only
+	Tablespace    string        // postgres only
+	Fields        []IndexOption // Note: IndexOption's Field maybe the same
+}
+
+// HasStorageOptions whether the index has storage parameters or tablespace
+func (idx *Index) HasStorageOptions() bool {
+	return len(idx.StorageParams) > 0 || idx.Tablespace != ""
+}
+
+// StorageOptions returns ` WITH (...) TABLESPACE ...` of the index, it is blank if no options set
+func (idx *Index) StorageOptions() string {
+	return buildStorageOptions(idx.StorageParams, idx.Tablespace, " TABLESPACE ")
+}
+
+// parseStorageOptions parse storage parameters separated by spaces and tablespace from tag settings
+func parseStorageOptions(settings map[string]string) (params []string, tablespace string, err error) {
+	for _, param := range strings.Fields(settings["STORAGE"]) {
+		if !regStorageParam.MatchString(param) {
+			return nil, "", fmt.Errorf("invalid storage parameter %s", param)
+		}
+		params = append(params, param)
+	}
+
+	if tablespace = strings.TrimSpace(settings["TABLESPACE"]); tablespace != "" && !regEnLetterAndMidline.MatchString(tablespace) {
+		return nil, "", fmt.Errorf("invalid tablespace %s", tablespace)
+	}
+	return params, tablespace, nil
+}
+
+func buildStorageOptions(params []string, tablespace string, tablespacePrefix string) (sql string) {
+	if len(params) > 0 {
+		sql += " WITH (" + strings.Join(params, ",") + ")"
+	}
+	if tablespace != "" {
+		sql += tablespacePrefix + tablespace
+	}
+	return
 }
 
 type IndexOption struct {
@@ -61,6 +102,12 @@ func (schema *Schema) ParseIndexes() []*Index {
 				if idx.Option == "" {
 					idx.Option = index.Option
 				}
+				if len(idx.StorageParams) == 0 {
+					idx.StorageParams = index.StorageParams
+				}
+				if idx.Tablespace == "" {
+					idx.Tablespace = index.Tablespace
+				}
 
 				idx.Fields = append(idx.Fields, index.Fields...)
 				sort.Slice(idx.Fields, func(i, j int) bool {
@@ -137,18 +184,25 @@ func parseFieldIndexes(field *Field) (indexes []Index, err error) {
 					settings["CLASS"] = "UNIQUE"
 				}
 
+				storageParams, tablespace, err := parseStorageOptions(settings)
+				if err != nil {
+					return nil, fmt.Errorf("index %s of %s.%s: %w", name, field.Schema.Name, field.Name, err)
+				}
+
 				priority, err := strconv.Atoi(settings["PRIORITY"])
 				if err != nil {
 					priority = 10
 				}
 
 				indexes = append(indexes, Index{
-					Name:    name,
-					Class:   settings["CLASS"],
-					Type:    settings["TYPE"],
-					Where:   settings["WHERE"],
-					Comment: settings["COMMENT"],
-					Option:  settings["OPTION"],
+					Name:          name,
+					Class:         settings["CLASS"],
+					Type:          settings["TYPE"],
+					Where:         settings["WHERE"],
+					Comment:       settings["COMMENT"],
+					Option:        settings["OPTION"],
+					StorageParams: storageParams,
+					Tablespace:    tablespace,
 					Fields: []IndexOption{{
 						Field:      field,
 						Expression: settings["EXPRESSION"],
@@ -251,6 +251,54 @@ func TestParseIndexWithUniqueIndexAndUnique(t *testing.T) {
 	CheckIndices(t, expectedIndices, indices)
 }
 
+func TestParseIndexWithStorageOptions(t *testing.T) {
+	type IndexStorage struct {
+		Name  string `gorm:"index:,storage:fillfactor=70 deduplicate_items=off,tablespace:fast_ssd"`
+		Email string `gorm:"uniqueIndex:,tablespace:fast_ssd"`
+		Age   int    `gorm:"index"`
+	}
+
+	indexSchema, err := schema.Parse(&IndexStorage{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("failed to parse index storage, got error %v", err)
+	}
+
+	indices := indexSchema.ParseIndexes()
+	CheckIndices(t, []*schema.Index{
+		{
+			Name:          "idx_index_storages_name",
+			StorageParams: []string{"fillfactor=70", "deduplicate_items=off"},
+			Tablespace:    "fast_ssd",
+			Fields:        []schema.IndexOption{{Field: &schema.Field{Name: "Name"}}},
+		},
+		{
+			Name:       "idx_index_storages_email",
+			Class:      "UNIQUE",
+			Tablespace: "fast_ssd",
+			Fields:     []schema.IndexOption{{Field: &schema.Field{Name: "Email", UniqueIndex: "idx_index_storages_email"}}},
+		},
+		{
+			Name:   "idx_index_storages_age",
+			Fields: []schema.IndexOption{{Field: &schema.Field{Name: "Age"}}},
+		},
+	}, indices)
+
+	for idx, options := range []string{" WITH (fillfactor=70,deduplicate_items=off) TABLESPACE fast_ssd", " TABLESPACE fast_ssd", ""} {
+		if got := indices[idx].StorageOptions(); got != options {
+			t.Errorf("index %v storage options should be %q, got %q", indices[idx].Name, options, got)
+		}
+	}
+
+	type IndexInvalidStorage struct {
+		Name string `gorm:"index:,storage:fillfactor=70) WITH (x=1"`
+	}
+
+	invalidSchema, _ := schema.Parse(&IndexInvalidStorage{}, &sync.Map{}, schema.NamingStrategy{})
+	if indices := invalidSchema.ParseIndexes(); len(indices) != 0 {
+		t.Errorf("should not parse index with invalid storage parameters")
+	}
+}
+
 func CheckIndices(t *testing.T, expected, actual []*schema.Index) {
 	if len(expected) != len(actual) {
 		t.Errorf("expected %d indices, but got %d", len(expected), len(actual))
@@ -260,7 +308,7 @@ func CheckIndices(t *testing.T, expected, actual []*schema.Index) {
 	for i, ei := range expected {
 		t.Run(ei.Name, func(t *testing.T) {
 			ai := actual[i]
-			tests.AssertObjEqual(t, ai, ei, "Name", "Class", "Type", "Where", "Comment", "Option")
+			tests.AssertObjEqual(t, ai, ei, "Name", "Class", "Type", "Where", "Comment", "Option", "StorageParams", "Tablespace")
 
 			if len(ei.Fields) != len(ai.Fields) {
 				t.Errorf("expected index %q field length is %d but actual %d", ei.Name, len(ei.Fields), len(ai.Fields))
@@ -0,0 +1,368 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/jinzhu/now"
+	"gorm.io/gorm/logger"
+)
+
+// PrebuiltField the parsed result of a field, generated by GeneratePrebuilt,
+// used to build the schema without parsing struct tags and detecting data types
+type PrebuiltField struct {
+	Name                   string
+	Type                   string // field type, used to detect outdated prebuilt fields
+	Index                  []int  // index path in the model, negative means the embedded struct is a pointer
+	DBName                 string // explicit column name, empty means named by the Namer
+	ColumnPrefix           string // embedded prefix of columns named by the Namer
+	BindNames              []string
+	EmbeddedBindNames      []string
+	DataType               DataType
+	GORMDataType           DataType
+	PrimaryKey             bool
+	AutoIncrement          bool
+	AutoIncrementIncrement int64
+	Creatable              bool
+	Updatable              bool
+	Readable               bool
+	AutoCreateTime         TimeType
+	AutoUpdateTime         TimeType
+	HasDefaultValue        bool
+	DefaultValue           string
+	DefaultValueInterface  interface{}
+	NotNull                bool
+	Unique                 bool
+	Comment                string
+	Size                   int
+	Precision              int
+	Scale                  int
+	IgnoreMigration        bool
+	TriggerManaged         bool
+	Lazy                   bool
+	OnUpdate               string
+	DefaultExpr            string
+	TagSettings            map[string]string
+}
+
+var prebuiltStore sync.Map
+
+// RegisterPrebuilt register prebuilt fields of the model, Parse uses them instead of parsing struct tags,
+// fields are ignored if the model has been changed since they were generated
+func RegisterPrebuilt(model interface{}, fields []PrebuiltField) {
+	modelType := reflect.TypeOf(model)
+	for modelType.Kind() == reflect.Slice || modelType.Kind() == reflect.Array || modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	prebuiltStore.Store(modelType, fields)
+}
+
+// prebuiltFields builds fields from the registered prebuilt fields, the checks of ParseField are run on them,
+// schema.err is set if any of them failed
+func (schema *Schema) prebuiltFields() ([]*Field, bool) {
+	v, ok := prebuiltStore.Load(schema.ModelType)
+	if !ok {
+		return nil, false
+	}
+
+	var (
+		prebuilts   = v.([]PrebuiltField)
+		fields      = make([]*Field, 0, len(prebuilts))
+		covered     = map[int]bool{}
+		ownerSchema = map[int]*Schema{}
+	)
+
+	for _, pf := range prebuilts {
+		fieldStruct, ok := prebuiltStructField(schema.ModelType, pf.Index)
+		if !ok || fieldStruct.Name != pf.Name || fieldStruct.Type.String() != pf.Type {
+			logger.Default.Warn(context.Background(), "prebuilt fields of %v are outdated, please regenerate them", schema.ModelType)
+			return nil, false
+		}
+
+		field := &Field{
+			Name:                   pf.Name,
+			DBName:                 pf.DBName,
+			BindNames:              append([]string{}, pf.BindNames...),
+			EmbeddedBindNames:      append([]string{}, pf.EmbeddedBindNames...),
+			DataType:               pf.DataType,
+			GORMDataType:           pf.GORMDataType,
+			PrimaryKey:             pf.PrimaryKey,
+			AutoIncrement:          pf.AutoIncrement,
+			AutoIncrementIncrement: pf.AutoIncrementIncrement,
+			Creatable:              pf.Creatable,
+			Updatable:              pf.Updatable,
+			Readable:               pf.Readable,
+			AutoCreateTime:         pf.AutoCreateTime,
+			AutoUpdateTime:         pf.AutoUpdateTime,
+			HasDefaultValue:        pf.HasDefaultValue,
+			DefaultValue:           pf.DefaultValue,
+			DefaultValueInterface:  pf.DefaultValueInterface,
+			NotNull:                pf.NotNull,
+			Unique:                 pf.Unique,
+			Comment:                pf.Comment,
+			Size:                   pf.Size,
+			Precision:              pf.Precision,
+			Scale:                  pf.Scale,
+			IgnoreMigration:        pf.IgnoreMigration,
+			TriggerManaged:         pf.TriggerManaged,
+			Lazy:                   pf.Lazy,
+			OnUpdate:               pf.OnUpdate,
+			DefaultExpr:            pf.DefaultExpr,
+			FieldType:              fieldStruct.Type,
+			IndirectFieldType:      fieldStruct.Type,
+			StructField:            fieldStruct,
+			Tag:                    fieldStruct.Tag,
+			TagSettings:            make(map[string]string, len(pf.TagSettings)),
+			Schema:                 schema,
+		}
+		field.StructField.Index = append([]int{}, pf.Index...)
+
+		for k, v := range pf.TagSettings {
+			field.TagSettings[k] = v
+		}
+
+		for field.IndirectFieldType.Kind() == reflect.Ptr {
+			field.IndirectFieldType = field.IndirectFieldType.Elem()
+		}
+
+		// run the checks of ParseField, the prebuilt schema fails as the parsed one
+		if err := checkUniqueTagSettings(field, field.TagSettings); err != nil {
+			schema.err = err
+			return nil, true
+		}
+
+		if name, ok := field.TagSettings["DEFAULTFUNC"]; ok {
+			if field.DefaultValueFunc, ok = GetDefaultValueFunc(strings.TrimSpace(name)); !ok {
+				schema.err = fmt.Errorf("invalid default value func %v for field %s", name, field.Name)
+				return nil, true
+			}
+		}
+
+		if v, ok := reflect.New(field.IndirectFieldType).Interface().(SerializerInterface); ok {
+			field.Serializer = v
+		} else if name := field.TagSettings["JSON"]; name != "" {
+			if field.Serializer, ok = GetSerializer(name); !ok {
+				schema.err = fmt.Errorf("invalid serializer type %v", name)
+				return nil, true
+			}
+		} else if name := field.TagSettings["SERIALIZER"]; name != "" {
+			if field.Serializer, ok = GetSerializer(name); !ok {
+				schema.err = fmt.Errorf("invalid serializer type %v", name)
+				return nil, true
+			}
+		}
+
+		// time default values are not generated, parse them again
+		if field.HasDefaultValue && field.DefaultValueInterface == nil && field.GORMDataType == Time &&
+			field.DefaultValue != "" && !strings.EqualFold(field.DefaultValue, "null") && !strings.Contains(field.DefaultValue, "(") {
+			if t, err := now.Parse(field.DefaultValue); err == nil {
+				field.DefaultValueInterface = t
+			}
+		}
+
+		if embedded := len(pf.Index) > 1; embedded {
+			if field.DBName == "" && field.DataType != "" {
+				field.DBName = pf.ColumnPrefix + schema.namer.ColumnName(schema.Table, field.Name)
+			}
+
+			// relationships declared in embedded structs are guessed with the embedded schema
+			if field.DataType == "" {
+				idx := prebuiltIndex(pf.Index[0])
+				if _, ok := ownerSchema[idx]; !ok {
+					ownerSchema[idx] = schema.parseEmbeddedOwner(schema.ModelType.Field(idx))
+				}
+				field.OwnerSchema = ownerSchema[idx]
+			}
+		}
+
+		covered[prebuiltIndex(pf.Index[0])] = true
+		fields = append(fields, field)
+	}
+
+	for i := 0; i < schema.ModelType.NumField(); i++ {
+		if fieldStruct := schema.ModelType.Field(i); ast.IsExported(fieldStruct.Name) && !covered[i] {
+			logger.Default.Warn(context.Background(), "prebuilt fields of %v are outdated, please regenerate them", schema.ModelType)
+			return nil, false
+		}
+	}
+
+	return fields, true
+}
+
+func (schema *Schema) parseEmbeddedOwner(fieldStruct reflect.StructField) *Schema {
+	cacheStore := &sync.Map{}
+	cacheStore.Store(embeddedCacheKey, true)
+
+	fieldType := fieldStruct.Type
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	owner, err := getOrParse(reflect.New(fieldType).Interface(), cacheStore, embeddedNamer{Table: schema.Table, Namer: schema.namer})
+	if err != nil {
+		schema.err = err
+	}
+	return owner
+}
+
+func prebuiltIndex(idx int) int {
+	if idx < 0 {
+		return -idx - 1
+	}
+	return idx
+}
+
+func prebuiltStructField(modelType reflect.Type, index []int) (fieldStruct reflect.StructField, ok bool) {
+	typ := modelType
+	for _, idx := range index {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		if idx = prebuiltIndex(idx); typ.Kind() != reflect.Struct || idx >= typ.NumField() {
+			return fieldStruct, false
+		}
+		fieldStruct = typ.Field(idx)
+		typ = fieldStruct.Type
+	}
+	return fieldStruct, len(index) > 0
+}
+
+// NewPrebuiltFields parse the model and returns its prebuilt fields
+func NewPrebuiltFields(model interface{}) ([]PrebuiltField, error) {
+	modelType := reflect.TypeOf(model)
+	for modelType != nil && (modelType.Kind() == reflect.Slice || modelType.Kind() == reflect.Array || modelType.Kind() == reflect.Ptr) {
+		modelType = modelType.Elem()
+	}
+	if modelType == nil || modelType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %+v", ErrUnsupportedDataType, model)
+	}
+
+	namer := NamingStrategy{}
+	schema := &Schema{
+		Name:       modelType.Name(),
+		ModelType:  modelType,
+		Table:      namer.TableName(modelType.Name()),
+		cacheStore: &sync.Map{},
+		namer:      namer,
+	}
+	if schema.parseFields(); schema.err != nil {
+		return nil, schema.err
+	}
+
+	prebuilts := make([]PrebuiltField, 0, len(schema.Fields))
+	for _, field := range schema.Fields {
+		pf := PrebuiltField{
+			Name:                   field.Name,
+			Type:                   field.FieldType.String(),
+			Index:                  field.StructField.Index,
+			DBName:                 field.DBName,
+			BindNames:              field.BindNames,
+			EmbeddedBindNames:      field.EmbeddedBindNames,
+			DataType:               field.DataType,
+			GORMDataType:           field.GORMDataType,
+			PrimaryKey:             field.PrimaryKey,
+			AutoIncrement:          field.AutoIncrement,
+			AutoIncrementIncrement: field.AutoIncrementIncrement,
+			Creatable:              field.Creatable,
+			Updatable:              field.Updatable,
+			Readable:               field.Readable,
+			AutoCreateTime:         field.AutoCreateTime,
+			AutoUpdateTime:         field.AutoUpdateTime,
+			HasDefaultValue:        field.HasDefaultValue,
+			DefaultValue:           field.DefaultValue,
+			NotNull:                field.NotNull,
+			Unique:                 field.Unique,
+			Comment:                field.Comment,
+			Size:                   field.Size,
+			Precision:              field.Precision,
+			Scale:                  field.Scale,
+			IgnoreMigration:        field.IgnoreMigration,
+			TriggerManaged:         field.TriggerManaged,
+			Lazy:                   field.Lazy,
+			OnUpdate:               field.OnUpdate,
+			DefaultExpr:            field.DefaultExpr,
+			TagSettings:            field.TagSettings,
+		}
+
+		switch field.DefaultValueInterface.(type) {
+		case bool, int64, uint64, float64, string:
+			pf.DefaultValueInterface = field.DefaultValueInterface
+		}
+
+		// columns of embedded structs are named by the Namer when parsing, keep the prefix only
+		if _, ok := field.TagSettings["COLUMN"]; !ok && len(field.StructField.Index) > 1 && field.DBName != "" {
+			if name := namer.ColumnName(schema.Table, field.Name); strings.HasSuffix(field.DBName, name) {
+				pf.DBName = ""
+				pf.ColumnPrefix = strings.TrimSuffix(field.DBName, name)
+			}
+		}
+
+		prebuilts = append(prebuilts, pf)
+	}
+
+	return prebuilts, nil
+}
+
+// GeneratePrebuilt generates go source registering prebuilt fields of the models,
+// the source should be placed in the package pkgName where the models are declared, e.g:
+//
+//	src, err := schema.GeneratePrebuilt("models", &models.User{}, &models.Pet{})
+//	os.WriteFile("models/gorm_prebuilt.go", src, 0o644)
+func GeneratePrebuilt(pkgName string, models ...interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gorm.io/gorm/schema.GeneratePrebuilt. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\nimport \"gorm.io/gorm/schema\"\n\nfunc init() {\n", pkgName)
+
+	for _, model := range models {
+		prebuilts, err := NewPrebuiltFields(model)
+		if err != nil {
+			return nil, err
+		}
+
+		modelType := reflect.TypeOf(model)
+		for modelType.Kind() == reflect.Slice || modelType.Kind() == reflect.Array || modelType.Kind() == reflect.Ptr {
+			modelType = modelType.Elem()
+		}
+
+		fmt.Fprintf(&buf, "schema.RegisterPrebuilt((*%s)(nil), []schema.PrebuiltField{\n", modelType.Name())
+		for _, pf := range prebuilts {
+			buf.WriteString("{")
+			writePrebuiltField(&buf, pf)
+			buf.WriteString("},\n")
+		}
+		buf.WriteString("})\n")
+	}
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// writePrebuiltField writes non-zero attributes of the field as go source
+func writePrebuiltField(buf *bytes.Buffer, pf PrebuiltField) {
+	value := reflect.ValueOf(pf)
+	for i := 0; i < value.NumField(); i++ {
+		fieldValue := value.Field(i)
+		if fieldValue.IsZero() {
+			continue
+		}
+
+		name := value.Type().Field(i).Name
+		if name == "DefaultValueInterface" {
+			switch v := pf.DefaultValueInterface.(type) {
+			case bool, string:
+				fmt.Fprintf(buf, "%s: %#v, ", name, v)
+			default:
+				fmt.Fprintf(buf, "%s: %T(%#v), ", name, v, v)
+			}
+			continue
+		}
+		fmt.Fprintf(buf, "%s: %#v, ", name, fieldValue.Interface())
+	}
+}
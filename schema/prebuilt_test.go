@@ -0,0 +1,231 @@
+package schema_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"gorm.io/gorm/utils/tests"
+)
+
+type PrebuiltBase struct {
+	ID        uint
+	CreatedAt time.Time
+}
+
+type PrebuiltAuthor struct {
+	Name  string
+	Email string `gorm:"column:mail"`
+}
+
+type PrebuiltPost struct {
+	PrebuiltBase
+	Title       string         `gorm:"size:200;not null;comment:post title"`
+	Views       int            `gorm:"default:10"`
+	PublishedAt time.Time      `gorm:"default:2000-01-02 03:04:05"`
+	Tags        []string       `gorm:"serializer:json"`
+	Author      PrebuiltAuthor `gorm:"embedded;embeddedPrefix:author_"`
+	CompanyID   int
+	Company     tests.Company
+	Ignored     string `gorm:"-"`
+}
+
+type PrebuiltOutdated struct {
+	ID   uint
+	Name string
+}
+
+func TestPrebuiltFields(t *testing.T) {
+	namer := schema.NamingStrategy{TablePrefix: "t_", NameReplacer: strings.NewReplacer("Name", "Nm")}
+	expected, err := schema.Parse(&PrebuiltPost{}, &sync.Map{}, namer)
+	if err != nil {
+		t.Fatalf("failed to parse post, got error %v", err)
+	}
+
+	fields, err := schema.NewPrebuiltFields(&PrebuiltPost{})
+	if err != nil {
+		t.Fatalf("failed to build prebuilt fields, got error %v", err)
+	}
+	schema.RegisterPrebuilt(&PrebuiltPost{}, fields)
+
+	post, err := schema.Parse(&PrebuiltPost{}, &sync.Map{}, namer)
+	if err != nil {
+		t.Fatalf("failed to parse post with prebuilt fields, got error %v", err)
+	}
+
+	tests.AssertObjEqual(t, post, expected, "Name", "Table", "DBNames", "PrimaryFieldDBNames")
+	if post.PrioritizedPrimaryField == nil || post.PrioritizedPrimaryField.Name != "ID" {
+		t.Errorf("prioritized primary field should be ID, got %v", post.PrioritizedPrimaryField)
+	}
+
+	if len(post.Fields) != len(expected.Fields) || len(post.FieldsWithDefaultDBValue) != len(expected.FieldsWithDefaultDBValue) {
+		t.Fatalf("fields should be same, got %v, expects %v", len(post.Fields), len(expected.Fields))
+	}
+
+	for idx, field := range expected.Fields {
+		tests.AssertObjEqual(t, post.Fields[idx], field, "Name", "DBName", "BindNames", "EmbeddedBindNames", "DataType", "GORMDataType",
+			"PrimaryKey", "AutoIncrement", "Creatable", "Updatable", "Readable", "AutoCreateTime", "HasDefaultValue", "DefaultValue",
+			"DefaultValueInterface", "NotNull", "Comment", "Size", "TagSettings", "FieldType")
+		if !reflect.DeepEqual(post.Fields[idx].StructField.Index, field.StructField.Index) || (post.Fields[idx].Serializer == nil) != (field.Serializer == nil) {
+			t.Errorf("field %v should be same, got %#v, expects %#v", field.Name, post.Fields[idx].StructField, field.StructField)
+		}
+	}
+
+	if rel, ok := post.Relationships.Relations["Company"]; !ok || rel.Type != schema.BelongsTo {
+		t.Errorf("failed to parse relationship Company with prebuilt fields, got %#v", rel)
+	}
+
+	value := reflect.ValueOf(&PrebuiltPost{})
+	if err := post.LookUpField("author_nm").Set(context.Background(), value, "jinzhu"); err != nil {
+		t.Fatalf("failed to set embedded field, got error %v", err)
+	}
+	if v, _ := post.LookUpField("author_nm").ValueOf(context.Background(), value); v != "jinzhu" {
+		t.Errorf("embedded field value should be jinzhu, got %v", v)
+	}
+}
+
+func TestPrebuiltFieldsOutdated(t *testing.T) {
+	fields, err := schema.NewPrebuiltFields(&PrebuiltOutdated{})
+	if err != nil {
+		t.Fatalf("failed to build prebuilt fields, got error %v", err)
+	}
+
+	fields[1].Comment = "prebuilt"
+	schema.RegisterPrebuilt(&PrebuiltOutdated{}, fields)
+
+	outdated, _ := schema.Parse(&PrebuiltOutdated{}, &sync.Map{}, schema.NamingStrategy{})
+	if outdated.LookUpField("name").Comment != "prebuilt" {
+		t.Errorf("should use registered prebuilt fields")
+	}
+
+	fields[1].Type = "int"
+	schema.RegisterPrebuilt(&PrebuiltOutdated{}, fields)
+
+	outdated, _ = schema.Parse(&PrebuiltOutdated{}, &sync.Map{}, schema.NamingStrategy{})
+	if outdated.LookUpField("name").Comment != "" {
+		t.Errorf("should ignore outdated prebuilt fields")
+	}
+
+	schema.RegisterPrebuilt(&PrebuiltOutdated{}, fields[:1])
+
+	outdated, _ = schema.Parse(&PrebuiltOutdated{}, &sync.Map{}, schema.NamingStrategy{})
+	if outdated.LookUpField("name") == nil {
+		t.Errorf("should ignore prebuilt fields missing model fields")
+	}
+}
+
+type PrebuiltArticle struct {
+	ID        uint
+	Code      string `gorm:"unique;where:code <> '';storage:fillfactor=70"`
+	UUID      string `gorm:"defaultFunc:uuid"`
+	Title     string
+	Slug      string            `gorm:"derivedFrom:Title;deriveOnUpdate"`
+	Meta      map[string]string `gorm:"serializer:json"`
+	DeletedAt gorm.DeletedAt    `gorm:"index"`
+}
+
+type PrebuiltInvalid struct {
+	ID   uint
+	Code string
+}
+
+func TestPrebuiltSchemaEqualsParsed(t *testing.T) {
+	expected, err := schema.Parse(&PrebuiltArticle{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("failed to parse article, got error %v", err)
+	}
+
+	fields, err := schema.NewPrebuiltFields(&PrebuiltArticle{})
+	if err != nil {
+		t.Fatalf("failed to build prebuilt fields, got error %v", err)
+	}
+	schema.RegisterPrebuilt(&PrebuiltArticle{}, fields)
+
+	article, err := schema.Parse(&PrebuiltArticle{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("failed to parse article with prebuilt fields, got error %v", err)
+	}
+
+	tests.AssertObjEqual(t, article, expected, "Name", "Table", "DBNames", "PrimaryFieldDBNames")
+	if len(article.Fields) != len(expected.Fields) || len(article.DerivedFields) != len(expected.DerivedFields) ||
+		len(article.QueryClauses) != len(expected.QueryClauses) || len(article.DeleteClauses) != len(expected.DeleteClauses) ||
+		len(article.FieldsWithDefaultDBValue) != len(expected.FieldsWithDefaultDBValue) {
+		t.Fatalf("schemas should be same, got %+v, expects %+v", article, expected)
+	}
+
+	for idx, field := range expected.Fields {
+		got := article.Fields[idx]
+		tests.AssertObjEqual(t, got, field, "Name", "DBName", "BindNames", "EmbeddedBindNames", "DataType", "GORMDataType",
+			"PrimaryKey", "AutoIncrement", "AutoIncrementIncrement", "Creatable", "Updatable", "Readable", "AutoCreateTime",
+			"AutoUpdateTime", "HasDefaultValue", "DefaultValue", "DefaultValueInterface", "NotNull", "Unique", "Comment", "Size",
+			"Precision", "Scale", "IgnoreMigration", "TriggerManaged", "Lazy", "OnUpdate", "DefaultExpr", "DeriveOnUpdate",
+			"TagSettings", "FieldType")
+		if (got.DefaultValueFunc == nil) != (field.DefaultValueFunc == nil) || (got.Serializer == nil) != (field.Serializer == nil) ||
+			(got.Deriver == nil) != (field.Deriver == nil) || (got.DerivedFrom == nil) != (field.DerivedFrom == nil) {
+			t.Errorf("field %v should be same, got %+v, expects %+v", field.Name, got, field)
+		}
+	}
+
+	gotConstraints, expectedConstraints := article.ParseUniqueConstraints(), expected.ParseUniqueConstraints()
+	if len(gotConstraints) != len(expectedConstraints) {
+		t.Fatalf("unique constraints should be same, got %+v, expects %+v", gotConstraints, expectedConstraints)
+	}
+	for name, constraint := range expectedConstraints {
+		got, ok := gotConstraints[name]
+		if !ok || got.Field.Name != constraint.Field.Name {
+			t.Errorf("unique constraint %v should be same, got %+v, expects %+v", name, got, constraint)
+			continue
+		}
+		got.Field, constraint.Field = nil, nil
+		if !reflect.DeepEqual(got, constraint) {
+			t.Errorf("unique constraint %v should be same, got %+v, expects %+v", name, got, constraint)
+		}
+	}
+}
+
+func TestPrebuiltFieldsInvalid(t *testing.T) {
+	for name, tagSettings := range map[string]map[string]string{
+		"invalid default value func": {"DEFAULTFUNC": "unknown_default_func"},
+		"invalid serializer type":    {"SERIALIZER": "unknown_serializer"},
+		"invalid unique predicate":   {"UNIQUE": "UNIQUE", "WHERE": "(code <> ''"},
+		"invalid storage parameter":  {"UNIQUE": "UNIQUE", "STORAGE": "fillfactor=70) WITH (x=1"},
+	} {
+		fields, err := schema.NewPrebuiltFields(&PrebuiltInvalid{})
+		if err != nil {
+			t.Fatalf("failed to build prebuilt fields, got error %v", err)
+		}
+		fields[1].TagSettings = tagSettings
+		_, fields[1].Unique = tagSettings["UNIQUE"]
+		schema.RegisterPrebuilt(&PrebuiltInvalid{}, fields)
+
+		if _, err := schema.Parse(&PrebuiltInvalid{}, &sync.Map{}, schema.NamingStrategy{}); err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("prebuilt fields should fail with %v, got %v", name, err)
+		}
+	}
+}
+
+func TestGeneratePrebuilt(t *testing.T) {
+	src, err := schema.GeneratePrebuilt("schema_test", &PrebuiltPost{}, PrebuiltOutdated{})
+	if err != nil {
+		t.Fatalf("failed to generate prebuilt fields, got error %v", err)
+	}
+
+	for _, code := range []string{
+		"package schema_test",
+		`schema.RegisterPrebuilt((*PrebuiltPost)(nil), []schema.PrebuiltField{`,
+		`schema.RegisterPrebuilt((*PrebuiltOutdated)(nil), []schema.PrebuiltField{`,
+		`Name: "Views", Type: "int", Index: []int{2}`,
+		`DefaultValueInterface: int64(10)`,
+		`Name: "Email", Type: "string", Index: []int{5, 1}, DBName: "author_mail"`,
+		`Name: "Name", Type: "string", Index: []int{5, 0}, ColumnPrefix: "author_"`,
+	} {
+		if !strings.Contains(string(src), code) {
+			t.Errorf("generated source should contains %v, got %s", code, src)
+		}
+	}
+}
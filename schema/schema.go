@@ -44,6 +44,8 @@ type Schema struct {
 	FieldsByBindName          map[string]*Field // embedded fields is 'Embed.Field'
 	FieldsByDBName            map[string]*Field
 	FieldsWithDefaultDBValue  []*Field // fields with default value assigned by database
+	DerivedFields             []*Field // fields derived from other fields when saving
+	LazyFields                []*Field // fields not selected by default
 	Relationships             Relationships
 	CreateClauses             []clause.Interface
 	QueryClauses              []clause.Interface
@@ -85,6 +87,22 @@ func (schema Schema) LookUpField(name string) *Field {
 	return nil
 }
 
+// LookUpFieldFold looks for the field whose db name equals to name after folded by fold, it is used when the
+// dialect folds identifiers to upper or lower case
+func (schema *Schema) LookUpFieldFold(name string, fold func(string) string) *Field {
+	if field := schema.LookUpField(name); field != nil {
+		return field
+	}
+
+	folded := fold(name)
+	for _, dbName := range schema.DBNames {
+		if fold(dbName) == folded {
+			return schema.FieldsByDBName[dbName]
+		}
+	}
+	return nil
+}
+
 // LookUpFieldByBindName looks for the closest field in the embedded struct.
 //
 //	type Struct struct {
@@ -106,6 +124,86 @@ func (schema Schema) LookUpFieldByBindName(bindNames []string, name string) *Fie
 	return nil
 }
 
+// ColumnsFromFieldMask converts paths of a field mask to column names. Path segments are matched with the field
+// names ignoring case and underscores, nested paths reference fields of embedded structs, and a path of the embedded
+// struct selects all of its columns. Unknown paths are rejected, the columns could be used with Select, e.g:
+//
+//	columns, err := sch.ColumnsFromFieldMask([]string{"name", "address.city"}) // name, address_city
+//	db.Model(&user).Select(columns).Updates(&user)
+func (schema *Schema) ColumnsFromFieldMask(paths []string) ([]string, error) {
+	var (
+		columns  = make([]string, 0, len(paths))
+		selected = make(map[string]bool, len(paths))
+	)
+
+	for _, path := range paths {
+		var matched []*Field
+		if field := schema.LookUpField(path); field != nil && field.DBName != "" {
+			matched = append(matched, field)
+		} else if path != "" {
+			segments := strings.Split(path, ".")
+			for _, dbName := range schema.DBNames {
+				if field := schema.FieldsByDBName[dbName]; matchFieldMaskPath(field.EmbeddedBindNames, segments) {
+					matched = append(matched, field)
+				}
+			}
+		}
+
+		if len(matched) == 0 {
+			return nil, fmt.Errorf("unknown field mask path %q of %s", path, schema.Name)
+		}
+
+		for _, field := range matched {
+			if !selected[field.DBName] {
+				selected[field.DBName] = true
+				columns = append(columns, field.DBName)
+			}
+		}
+	}
+	return columns, nil
+}
+
+// matchFieldMaskPath whether the segments are the bind names or the prefix of them
+func matchFieldMaskPath(bindNames []string, segments []string) bool {
+	if len(segments) > len(bindNames) {
+		return false
+	}
+
+	for idx, segment := range segments {
+		if normalizeFieldMaskName(segment) != normalizeFieldMaskName(bindNames[idx]) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeFieldMaskName field mask paths are usually snake case, e.g: created_at -> createdat, CreatedAt -> createdat
+func normalizeFieldMaskName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// tableSettingCacheKey cache key of the table setting of the schema
+type tableSettingCacheKey struct {
+	schema *Schema
+	table  string
+	key    string
+}
+
+// TableSetting returns the setting of the table of the schema, e.g: whether the table is a sqlite WITHOUT ROWID table,
+// load is called only once per table, the result is cached in the cache store of the schema
+func (schema *Schema) TableSetting(table, key string, load func() interface{}) interface{} {
+	if schema.cacheStore == nil {
+		return load()
+	}
+
+	cacheKey := tableSettingCacheKey{schema: schema, table: table, key: key}
+	if v, ok := schema.cacheStore.Load(cacheKey); ok {
+		return v
+	}
+	v, _ := schema.cacheStore.LoadOrStore(cacheKey, load())
+	return v
+}
+
 type Tabler interface {
 	TableName() string
 }
@@ -114,6 +212,12 @@ type TablerWithNamer interface {
 	TableName(Namer) string
 }
 
+// DynamicTabler the table name depends on the instance, e.g: sharded tables, the schema is cached by the model type and
+// the table name, so the cache grows with each distinct table name returned, it should be a bounded set
+type DynamicTabler interface {
+	DynamicTableName() string
+}
+
 // Parse get data type from dialector
 // 解析数据类型。
 func Parse(dest interface{}, cacheStore *sync.Map, namer Namer) (*Schema, error) {
@@ -202,14 +306,11 @@ func ParseWithSpecialTableName(dest interface{}, cacheStore *sync.Map, namer Nam
 		return s, s.err
 	}
 
-	for i := 0; i < modelType.NumField(); i++ {
-		if fieldStruct := modelType.Field(i); ast.IsExported(fieldStruct.Name) {
-			if field := schema.ParseField(fieldStruct); field.EmbeddedSchema != nil {
-				schema.Fields = append(schema.Fields, field.EmbeddedSchema.Fields...)
-			} else {
-				schema.Fields = append(schema.Fields, field)
-			}
-		}
+	// use the fields generated by GeneratePrebuilt if registered, avoid parsing struct tags
+	if fields, ok := schema.prebuiltFields(); ok {
+		schema.Fields = fields
+	} else {
+		schema.parseFields()
 	}
 
 	for _, field := range schema.Fields {
@@ -286,11 +387,22 @@ func ParseWithSpecialTableName(dest interface{}, cacheStore *sync.Map, namer Nam
 	}
 
 	for _, field := range schema.Fields {
-		if field.DataType != "" && field.HasDefaultValue && field.DefaultValueInterface == nil {
+		if field.DataType != "" && ((field.HasDefaultValue && field.DefaultValueInterface == nil && field.DefaultValueFunc == nil) || field.TriggerManaged || field.DefaultExpr != "") {
 			schema.FieldsWithDefaultDBValue = append(schema.FieldsWithDefaultDBValue, field)
 		}
+
+		// primary keys are always selected
+		if field.Lazy {
+			if field.PrimaryKey || field.DBName == "" {
+				field.Lazy = false
+			} else {
+				schema.LazyFields = append(schema.LazyFields, field)
+			}
+		}
 	}
 
+	schema.parseDerivedFields()
+
 	if field := schema.PrioritizedPrimaryField; field != nil {
 		switch field.GORMDataType {
 		case Int, Uint:
@@ -351,6 +463,12 @@ func ParseWithSpecialTableName(dest interface{}, cacheStore *sync.Map, namer Nam
 
 			fieldValue := reflect.New(field.IndirectFieldType)
 			fieldInterface := fieldValue.Interface()
+			if name, ok := field.TagSettings["SOFTDELETE"]; ok {
+				if fieldInterface, ok = GetSoftDelete(strings.TrimSpace(name)); !ok {
+					schema.err = fmt.Errorf("invalid soft delete %v for field %s", name, field.Name)
+					continue
+				}
+			}
 			if fc, ok := fieldInterface.(CreateClausesInterface); ok {
 				field.Schema.CreateClauses = append(field.Schema.CreateClauses, fc.CreateClauses(field)...)
 			}
@@ -372,6 +490,19 @@ func ParseWithSpecialTableName(dest interface{}, cacheStore *sync.Map, namer Nam
 	return schema, schema.err
 }
 
+// parseFields parses the exported fields of the model, fields of embedded structs are flattened
+func (schema *Schema) parseFields() {
+	for i := 0; i < schema.ModelType.NumField(); i++ {
+		if fieldStruct := schema.ModelType.Field(i); ast.IsExported(fieldStruct.Name) {
+			if field := schema.ParseField(fieldStruct); field.EmbeddedSchema != nil {
+				schema.Fields = append(schema.Fields, field.EmbeddedSchema.Fields...)
+			} else {
+				schema.Fields = append(schema.Fields, field)
+			}
+		}
+	}
+}
+
 // This unrolling is needed to show to the compiler the exact set of methods
 // that can be used on the modelType.
 // Prior to go1.22 any use of MethodByName would cause the linker to
@@ -1,11 +1,16 @@
 package schema_test
 
 import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
 	"strings"
 	"sync"
 	"testing"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/schema"
 	"gorm.io/gorm/utils/tests"
 )
@@ -350,3 +355,172 @@ func TestCompositePrimaryKeyWithAutoIncrement(t *testing.T) {
 		t.Fatalf("PrioritizedPrimaryField of non autoincrement composite key should be nil")
 	}
 }
+
+func TestPrimaryKeyIn(t *testing.T) {
+	type Product struct {
+		ProductID    uint   `gorm:"primaryKey"`
+		LanguageCode string `gorm:"primaryKey"`
+		Name         string
+	}
+
+	user, err := schema.Parse(&tests.User{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("failed to parse user, got error %v", err)
+	}
+
+	users := []*tests.User{{Model: gorm.Model{ID: 1}}, {Model: gorm.Model{ID: 0}}, {Model: gorm.Model{ID: 3}}}
+	expr, err := schema.PrimaryKeyIn(context.Background(), user, users)
+	if err != nil {
+		t.Fatalf("failed to build primary key in, got error %v", err)
+	}
+
+	expected := clause.IN{Column: clause.Column{Table: "users", Name: "id"}, Values: []interface{}{uint(1), uint(3)}}
+	if !reflect.DeepEqual(expr, expected) {
+		t.Errorf("expects %+v, got %+v", expected, expr)
+	}
+
+	product, err := schema.Parse(&Product{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("failed to parse product, got error %v", err)
+	}
+
+	expr, err = schema.PrimaryKeyIn(context.Background(), product, []Product{{ProductID: 1, LanguageCode: "en"}, {ProductID: 1, LanguageCode: "zh"}})
+	if err != nil {
+		t.Fatalf("failed to build primary key in, got error %v", err)
+	}
+
+	expected = clause.IN{
+		Column: []clause.Column{{Table: "products", Name: "product_id"}, {Table: "products", Name: "language_code"}},
+		Values: []interface{}{[]interface{}{uint(1), "en"}, []interface{}{uint(1), "zh"}},
+	}
+	if !reflect.DeepEqual(expr, expected) {
+		t.Errorf("expects %+v, got %+v", expected, expr)
+	}
+
+	if _, err := schema.PrimaryKeyIn(context.Background(), product, users); !errors.Is(err, schema.ErrUnsupportedDataType) {
+		t.Errorf("should failed to build primary key in with mismatched type, got %v", err)
+	}
+}
+
+func TestLookUpFieldFold(t *testing.T) {
+	user, err := schema.Parse(&tests.User{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("failed to parse user, got error %v", err)
+	}
+
+	if field := user.LookUpFieldFold("COMPANY_ID", strings.ToUpper); field == nil || field.Name != "CompanyID" {
+		t.Errorf("should look up field with folded db name, got %+v", field)
+	}
+
+	if field := user.LookUpFieldFold("name", strings.ToUpper); field == nil || field.Name != "Name" {
+		t.Errorf("should look up field with exact db name, got %+v", field)
+	}
+
+	if field := user.LookUpFieldFold("UNKNOWN", strings.ToUpper); field != nil {
+		t.Errorf("should not look up unknown field, got %+v", field)
+	}
+}
+
+func TestColumnsFromFieldMask(t *testing.T) {
+	type Address struct {
+		City    string
+		ZipCode string
+	}
+
+	type Customer struct {
+		gorm.Model
+		Name    string
+		Address Address `gorm:"embedded;embeddedPrefix:address_"`
+	}
+
+	customer, err := schema.Parse(&Customer{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("failed to parse customer, got error %v", err)
+	}
+
+	results := []struct {
+		Paths   []string
+		Columns []string
+	}{
+		{Paths: []string{"name", "updated_at"}, Columns: []string{"name", "updated_at"}},
+		{Paths: []string{"Name", "UpdatedAt", "ID"}, Columns: []string{"name", "updated_at", "id"}},
+		{Paths: []string{"address.city", "address.zip_code"}, Columns: []string{"address_city", "address_zip_code"}},
+		{Paths: []string{"address_city"}, Columns: []string{"address_city"}},
+		{Paths: []string{"address", "address.city", "name"}, Columns: []string{"address_city", "address_zip_code", "name"}},
+	}
+
+	for _, result := range results {
+		columns, err := customer.ColumnsFromFieldMask(result.Paths)
+		if err != nil {
+			t.Fatalf("failed to convert field mask %v, got error %v", result.Paths, err)
+		}
+		if !reflect.DeepEqual(columns, result.Columns) {
+			t.Errorf("columns of field mask %v should be %v, got %v", result.Paths, result.Columns, columns)
+		}
+	}
+
+	for _, paths := range [][]string{{"unknown"}, {"name", "address.country"}, {"address.city.name"}, {""}} {
+		if _, err := customer.ColumnsFromFieldMask(paths); err == nil {
+			t.Errorf("unknown field mask %v should be rejected", paths)
+		}
+	}
+}
+
+func TestParseDefaultValueFunc(t *testing.T) {
+	type Ticket struct {
+		ID     string `gorm:"primaryKey;defaultFunc:uuid"`
+		Code   string `gorm:"default:(random());defaultFunc:uuid"`
+		Status string `gorm:"default:open;defaultFunc:uuid"`
+	}
+
+	ticket, err := schema.Parse(&Ticket{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("failed to parse ticket, got error %v", err)
+	}
+
+	for _, name := range []string{"ID", "Code", "Status"} {
+		if ticket.LookUpField(name).DefaultValueFunc == nil {
+			t.Errorf("field %v should have default value func", name)
+		}
+	}
+
+	if len(ticket.FieldsWithDefaultDBValue) != 0 {
+		t.Errorf("fields with default value func should not have default db value, got %v", ticket.FieldsWithDefaultDBValue)
+	}
+
+	if v, ok := schema.NewUUID(context.Background()).(string); !ok || !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(v) {
+		t.Errorf("should generate uuid v4, got %v", v)
+	}
+
+	type InvalidTicket struct {
+		ID string `gorm:"defaultFunc:unknown"`
+	}
+
+	if _, err := schema.Parse(&InvalidTicket{}, &sync.Map{}, schema.NamingStrategy{}); err == nil || !strings.Contains(err.Error(), "invalid default value func") {
+		t.Errorf("should return error for unknown default value func, got %v", err)
+	}
+}
+
+func TestTableSetting(t *testing.T) {
+	user, err := schema.Parse(&tests.User{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("failed to parse user, got error %v", err)
+	}
+
+	var calls int
+	load := func() interface{} {
+		calls++
+		return true
+	}
+
+	for i := 0; i < 3; i++ {
+		if v := user.TableSetting("users", "without_rowid", load); v != true {
+			t.Errorf("should return loaded setting, got %v", v)
+		}
+	}
+
+	user.TableSetting("users_2024", "without_rowid", load)
+	if calls != 2 {
+		t.Errorf("setting should be loaded once per table, got %v calls", calls)
+	}
+}
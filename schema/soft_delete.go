@@ -0,0 +1,20 @@
+package schema
+
+import (
+	"strings"
+	"sync"
+)
+
+var softDeleteMap = sync.Map{}
+
+// RegisterSoftDelete register soft delete used by `softDelete` tag, the soft delete implements
+// QueryClausesInterface, UpdateClausesInterface or DeleteClausesInterface like gorm.DeletedAt,
+// its clauses are added to the schema for the tagged field
+func RegisterSoftDelete(name string, softDelete interface{}) {
+	softDeleteMap.Store(strings.ToLower(name), softDelete)
+}
+
+// GetSoftDelete get soft delete
+func GetSoftDelete(name string) (softDelete interface{}, ok bool) {
+	return softDeleteMap.Load(strings.ToLower(name))
+}
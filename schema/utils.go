@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
 	"gorm.io/gorm/utils"
 )
 
@@ -207,6 +208,51 @@ func ToQueryValues(table string, foreignKeys []string, foreignValues [][]interfa
 	return columns, queryValues
 }
 
+// PrimaryKeyIn build IN condition with primary key values of models, composite primary keys use tuple IN
+//
+//	expr, err := schema.PrimaryKeyIn(ctx, userSchema, users) // `users`.`id` IN (1,2,3)
+//	db.Where(expr).Delete(&User{})
+func PrimaryKeyIn(ctx context.Context, s *Schema, models interface{}) (clause.Expression, error) {
+	if len(s.PrimaryFields) == 0 {
+		return nil, fmt.Errorf("primary key required for %s", s)
+	}
+
+	reflectValue := reflect.Indirect(reflect.ValueOf(models))
+	switch reflectValue.Kind() {
+	case reflect.Struct:
+		reflectValue = reflect.Append(reflect.MakeSlice(reflect.SliceOf(reflectValue.Type()), 0, 1), reflectValue)
+	case reflect.Slice, reflect.Array:
+	default:
+		return nil, fmt.Errorf("%w: %+v", ErrUnsupportedDataType, models)
+	}
+
+	primaryValues := make([][]interface{}, 0, reflectValue.Len())
+	for i := 0; i < reflectValue.Len(); i++ {
+		elem := reflect.Indirect(reflectValue.Index(i))
+		if !elem.IsValid() || elem.Type() != s.ModelType {
+			return nil, fmt.Errorf("%w: element #%d of %s, expects %s", ErrUnsupportedDataType, i, reflectValue.Type(), s.ModelType)
+		}
+
+		values := make([]interface{}, len(s.PrimaryFields))
+		for idx, field := range s.PrimaryFields {
+			var zero bool
+			if values[idx], zero = field.ValueOf(ctx, elem); zero {
+				values = nil
+				break
+			}
+		}
+
+		if values == nil {
+			logger.Default.Warn(ctx, "skip element #%d of %s with zero primary key", i, reflectValue.Type())
+			continue
+		}
+		primaryValues = append(primaryValues, values)
+	}
+
+	column, values := ToQueryValues(s.Table, s.PrimaryFieldDBNames, primaryValues)
+	return clause.IN{Column: column, Values: values}, nil
+}
+
 type embeddedNamer struct {
 	Table string
 	Namer
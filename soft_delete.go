@@ -74,6 +74,11 @@ func (sd SoftDeleteQueryClause) MergeClause(*clause.Clause) {
 }
 
 func (sd SoftDeleteQueryClause) ModifyStatement(stmt *Statement) {
+	addSoftDeleteCondition(stmt, sd.Field, sd.ZeroValue)
+}
+
+// addSoftDeleteCondition add the condition of the soft delete field having the zero value to the statement
+func addSoftDeleteCondition(stmt *Statement, field *schema.Field, zeroValue interface{}) {
 	if _, ok := stmt.Clauses["soft_delete_enabled"]; !ok && !stmt.Statement.Unscoped {
 		if c, ok := stmt.Clauses["WHERE"]; ok {
 			if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) >= 1 {
@@ -89,7 +94,7 @@ func (sd SoftDeleteQueryClause) ModifyStatement(stmt *Statement) {
 		}
 
 		stmt.AddClause(clause.Where{Exprs: []clause.Expression{
-			clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: sd.Field.DBName}, Value: sd.ZeroValue},
+			clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: zeroValue},
 		}})
 		stmt.Clauses["soft_delete_enabled"] = clause.Clause{}
 	}
@@ -141,30 +146,117 @@ func (sd SoftDeleteDeleteClause) MergeClause(*clause.Clause) {
 
 func (sd SoftDeleteDeleteClause) ModifyStatement(stmt *Statement) {
 	if stmt.SQL.Len() == 0 && !stmt.Statement.Unscoped {
-		curTime := stmt.DB.NowFunc()
-		stmt.AddClause(clause.Set{{Column: clause.Column{Name: sd.Field.DBName}, Value: curTime}})
-		stmt.SetColumn(sd.Field.DBName, curTime, true)
+		buildSoftDelete(stmt, sd.Field, stmt.DB.NowFunc(), sd.ZeroValue)
+	}
+}
+
+// buildSoftDelete build the UPDATE statement setting the soft delete field to the deleted value
+func buildSoftDelete(stmt *Statement, field *schema.Field, deletedValue, zeroValue interface{}) {
+	stmt.AddClause(clause.Set{{Column: clause.Column{Name: field.DBName}, Value: deletedValue}})
+	stmt.SetColumn(field.DBName, deletedValue, true)
+
+	if stmt.Schema != nil {
+		_, queryValues := schema.GetIdentityFieldValuesMap(stmt.Context, stmt.ReflectValue, stmt.Schema.PrimaryFields)
+		column, values := schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, queryValues)
+
+		if len(values) > 0 {
+			stmt.AddClause(clause.Where{Exprs: []clause.Expression{clause.IN{Column: column, Values: values}}})
+		}
 
-		if stmt.Schema != nil {
-			_, queryValues := schema.GetIdentityFieldValuesMap(stmt.Context, stmt.ReflectValue, stmt.Schema.PrimaryFields)
-			column, values := schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, queryValues)
+		if stmt.ReflectValue.CanAddr() && stmt.Dest != stmt.Model && stmt.Model != nil {
+			_, queryValues = schema.GetIdentityFieldValuesMap(stmt.Context, reflect.ValueOf(stmt.Model), stmt.Schema.PrimaryFields)
+			column, values = schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, queryValues)
 
 			if len(values) > 0 {
 				stmt.AddClause(clause.Where{Exprs: []clause.Expression{clause.IN{Column: column, Values: values}}})
 			}
+		}
+	}
 
-			if stmt.ReflectValue.CanAddr() && stmt.Dest != stmt.Model && stmt.Model != nil {
-				_, queryValues = schema.GetIdentityFieldValuesMap(stmt.Context, reflect.ValueOf(stmt.Model), stmt.Schema.PrimaryFields)
-				column, values = schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, queryValues)
+	addSoftDeleteCondition(stmt, field, zeroValue)
+	stmt.AddClauseIfNotExists(clause.Update{})
+	stmt.Build(stmt.DB.Callback().Update().Clauses...)
+}
 
-				if len(values) > 0 {
-					stmt.AddClause(clause.Where{Exprs: []clause.Expression{clause.IN{Column: column, Values: values}}})
-				}
-			}
-		}
+func init() {
+	schema.RegisterSoftDelete("flag", SoftDeleteFlag{})
+}
 
-		SoftDeleteQueryClause(sd).ModifyStatement(stmt)
-		stmt.AddClauseIfNotExists(clause.Update{})
-		stmt.Build(stmt.DB.Callback().Update().Clauses...)
+// SoftDeleteFlag flag-style soft delete for the integer field with tag `softDelete:flag`, the flag of deleted records
+// is set to 1, and queries only find records having flag 0
+//
+//	type User struct {
+//		ID        uint
+//		IsDeleted int `gorm:"softDelete:flag"`
+//	}
+type SoftDeleteFlag struct{}
+
+func (SoftDeleteFlag) QueryClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{SoftDeleteFlagQueryClause{Field: f}}
+}
+
+func (SoftDeleteFlag) UpdateClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{SoftDeleteFlagUpdateClause{Field: f}}
+}
+
+func (SoftDeleteFlag) DeleteClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{SoftDeleteFlagDeleteClause{Field: f}}
+}
+
+type SoftDeleteFlagQueryClause struct {
+	Field *schema.Field
+}
+
+func (sd SoftDeleteFlagQueryClause) Name() string {
+	return ""
+}
+
+func (sd SoftDeleteFlagQueryClause) Build(clause.Builder) {
+}
+
+func (sd SoftDeleteFlagQueryClause) MergeClause(*clause.Clause) {
+}
+
+func (sd SoftDeleteFlagQueryClause) ModifyStatement(stmt *Statement) {
+	addSoftDeleteCondition(stmt, sd.Field, 0)
+}
+
+type SoftDeleteFlagUpdateClause struct {
+	Field *schema.Field
+}
+
+func (sd SoftDeleteFlagUpdateClause) Name() string {
+	return ""
+}
+
+func (sd SoftDeleteFlagUpdateClause) Build(clause.Builder) {
+}
+
+func (sd SoftDeleteFlagUpdateClause) MergeClause(*clause.Clause) {
+}
+
+func (sd SoftDeleteFlagUpdateClause) ModifyStatement(stmt *Statement) {
+	if stmt.SQL.Len() == 0 && !stmt.Statement.Unscoped {
+		addSoftDeleteCondition(stmt, sd.Field, 0)
+	}
+}
+
+type SoftDeleteFlagDeleteClause struct {
+	Field *schema.Field
+}
+
+func (sd SoftDeleteFlagDeleteClause) Name() string {
+	return ""
+}
+
+func (sd SoftDeleteFlagDeleteClause) Build(clause.Builder) {
+}
+
+func (sd SoftDeleteFlagDeleteClause) MergeClause(*clause.Clause) {
+}
+
+func (sd SoftDeleteFlagDeleteClause) ModifyStatement(stmt *Statement) {
+	if stmt.SQL.Len() == 0 && !stmt.Statement.Unscoped {
+		buildSoftDelete(stmt, sd.Field, 1, 0)
 	}
 }
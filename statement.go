@@ -18,6 +18,34 @@ import (
 	"gorm.io/gorm/utils"
 )
 
+var (
+	// safeTableRegexp table name, could be prefixed with schema
+	safeTableRegexp = regexp.MustCompile(`^[\w-]+(\.[\w-]+)*$`)
+	// safeRawColumnRegexp raw column identifier, could be prefixed with table or followed with ordering
+	safeRawColumnRegexp = regexp.MustCompile(`^[\w-]+(\.[\w-]+)*(\.\*)?(?i:\s+(asc|desc))?$|^\*$`)
+)
+
+// Placeholder placeholder style used to render dry run SQL, E.g:
+//
+//	stmt := db.Session(&gorm.Session{DryRun: true}).Set("gorm:placeholder", gorm.PlaceholderDollar).Where("name = ? AND age > ?", "jinzhu", 18).Find(&users).Statement
+//	stmt.SQL.String() // SELECT * FROM `users` WHERE (name = $1 AND age > $2)
+//
+// it is for display only, statements that are executed always use the dialector's placeholders
+type Placeholder string
+
+const (
+	PlaceholderQuestion Placeholder = "?"  // ?
+	PlaceholderDollar   Placeholder = "$"  // $1, $2
+	PlaceholderColon    Placeholder = ":"  // :1, :2
+	PlaceholderAtP      Placeholder = "@p" // @p1, @p2
+)
+
+var placeholderRegexps = map[Placeholder]*regexp.Regexp{
+	PlaceholderDollar: regexp.MustCompile(`\$(\d+)`),
+	PlaceholderColon:  regexp.MustCompile(`:(\d+)`),
+	PlaceholderAtP:    regexp.MustCompile(`@p(\d+)`),
+}
+
 // Statement statement
 type Statement struct {
 	*DB
@@ -48,6 +76,13 @@ type Statement struct {
 	assigns              []interface{}
 	scopes               []func(*DB) *DB
 	Result               *result
+	bindVars             bindVarStats
+}
+
+// bindVarStats placeholders written for the vars when CheckBindVars enabled
+type bindVarStats struct {
+	count      int
+	mismatches []string
 }
 
 type join struct {
@@ -85,6 +120,10 @@ func (stmt *Statement) WriteQuoted(value interface{}) {
 func (stmt *Statement) QuoteTo(writer clause.Writer, field interface{}) {
 	write := func(raw bool, str string) {
 		if raw {
+			if stmt.DB.ValidateIdentifiers && !safeRawColumnRegexp.MatchString(str) {
+				stmt.DB.AddError(fmt.Errorf("%w: %q", ErrInvalidIdentifier, str))
+				return
+			}
 			writer.WriteString(str)
 		} else {
 			stmt.DB.Dialector.QuoteTo(writer, str)
@@ -169,6 +208,146 @@ func (stmt *Statement) Quote(field interface{}) string {
 	return builder.String()
 }
 
+// LimitStyle returns the pagination syntax of the dialector and whether the count of LIMIT/OFFSET could be a bind var
+func (stmt *Statement) LimitStyle() (clause.LimitStyle, bool) {
+	if dialector, ok := stmt.DB.Dialector.(LimitDialectorInterface); ok {
+		return dialector.LimitStyle(), dialector.LimitBindVar()
+	}
+	return clause.LimitOffsetStyle, true
+}
+
+// SupportLockingStrength returns whether the row locking strength is supported by the dialector
+func (stmt *Statement) SupportLockingStrength(strength string) bool {
+	if dialector, ok := stmt.DB.Dialector.(RowLockingDialectorInterface); ok {
+		return dialector.SupportLockingStrength(strength)
+	}
+	return true
+}
+
+// SupportCollate returns whether inline COLLATE is supported, ok is false if the dialector doesn't tell
+func (stmt *Statement) SupportCollate() (supported bool, ok bool) {
+	if dialector, ok := stmt.DB.Dialector.(CollateDialectorInterface); ok {
+		return dialector.SupportCollate(), true
+	}
+	return false, false
+}
+
+// CreateTableAsSyntax returns the create table as syntax of the dialector, ok is false if the dialector doesn't tell
+func (stmt *Statement) CreateTableAsSyntax() (syntax clause.CreateTableAsSyntax, ok bool) {
+	if dialector, ok := stmt.DB.Dialector.(CreateTableAsDialectorInterface); ok {
+		return dialector.CreateTableAsSyntax(), true
+	}
+	return clause.CreateTableAsUnsupported, false
+}
+
+// SupportBoolLiteral returns whether TRUE/FALSE literals are supported, ok is false if the dialector doesn't tell
+func (stmt *Statement) SupportBoolLiteral() (supported bool, ok bool) {
+	if dialector, ok := stmt.DB.Dialector.(BoolLiteralDialectorInterface); ok {
+		return dialector.SupportBoolLiteral(), true
+	}
+	return false, false
+}
+
+// SupportNullsOrder returns whether NULLS FIRST/LAST is supported, ok is false if the dialector doesn't tell
+func (stmt *Statement) SupportNullsOrder() (supported bool, ok bool) {
+	if dialector, ok := stmt.DB.Dialector.(NullsOrderDialectorInterface); ok {
+		return dialector.SupportNullsOrder(), true
+	}
+	return false, false
+}
+
+// SupportOrdinalGroupBy returns whether ordinals in GROUP BY are supported, ok is false if the dialector doesn't tell
+func (stmt *Statement) SupportOrdinalGroupBy() (supported bool, ok bool) {
+	if dialector, ok := stmt.DB.Dialector.(OrdinalGroupByDialectorInterface); ok {
+		return dialector.SupportOrdinalGroupBy(), true
+	}
+	return false, false
+}
+
+// ConditionalValuesFrom returns the FROM clause to select values of conditional inserts, ok is false if the dialector doesn't tell
+func (stmt *Statement) ConditionalValuesFrom() (from string, ok bool) {
+	if dialector, ok := stmt.DB.Dialector.(ConditionalValuesDialectorInterface); ok {
+		return dialector.ConditionalValuesFrom(), true
+	}
+	return "", false
+}
+
+// SupportRecursiveCTE returns whether WITH RECURSIVE is supported, ok is false if the dialector doesn't tell
+func (stmt *Statement) SupportRecursiveCTE() (supported bool, ok bool) {
+	if dialector, ok := stmt.DB.Dialector.(RecursiveCTEDialectorInterface); ok {
+		return dialector.SupportRecursiveCTE(), true
+	}
+	return false, false
+}
+
+// ExtremumFunctions returns the greatest and least functions of the dialector, ok is false if the dialector doesn't tell
+func (stmt *Statement) ExtremumFunctions() (greatest, least string, ok bool) {
+	if dialector, ok := stmt.DB.Dialector.(ExtremumDialectorInterface); ok {
+		greatest, least = dialector.ExtremumFunctions()
+		return greatest, least, true
+	}
+	return "", "", false
+}
+
+// TopLimit returns the limit written as TOP n by the SELECT clause if the dialector uses TOP
+func (stmt *Statement) TopLimit() *int {
+	if style, _ := stmt.LimitStyle(); style != clause.TopStyle || !utils.Contains(stmt.BuildClauses, "SELECT") {
+		return nil
+	}
+
+	if c, ok := stmt.Clauses["LIMIT"]; ok {
+		if limit, ok := c.Expression.(clause.Limit); ok && limit.Limit != nil && *limit.Limit >= 0 {
+			return limit.Limit
+		}
+	}
+	return nil
+}
+
+// PrimaryKeyValues returns the primary key values of each record in ReflectValue, ordered by the primary fields of the schema,
+// e.g: the inserted primary keys in after create callbacks
+func (stmt *Statement) PrimaryKeyValues() (values [][]interface{}) {
+	if stmt.Schema == nil || len(stmt.Schema.PrimaryFields) == 0 {
+		return nil
+	}
+
+	recordValues := func(rv reflect.Value) []interface{} {
+		pks := make([]interface{}, 0, len(stmt.Schema.PrimaryFields))
+		for _, field := range stmt.Schema.PrimaryFields {
+			pk, _ := field.ValueOf(stmt.Context, rv)
+			pks = append(pks, pk)
+		}
+		return pks
+	}
+
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < stmt.ReflectValue.Len(); i++ {
+			if rv := reflect.Indirect(stmt.ReflectValue.Index(i)); rv.Kind() == reflect.Struct {
+				values = append(values, recordValues(rv))
+			}
+		}
+	case reflect.Struct:
+		values = append(values, recordValues(stmt.ReflectValue))
+	}
+	return values
+}
+
+// IsSubQuery returns whether the value is a subquery that could be built by AddVar, e.g: *gorm.DB
+func (stmt *Statement) IsSubQuery(value interface{}) bool {
+	_, ok := value.(interface{ getInstance() *DB })
+	return ok
+}
+
+// Setting returns the setting of the statement set with db.Set
+func (stmt *Statement) Setting(key string) (interface{}, bool) {
+	return stmt.Settings.Load(key)
+}
+
+// Warn log warning message with the logger of the DB
+func (stmt *Statement) Warn(msg string, data ...interface{}) {
+	stmt.DB.Logger.Warn(stmt.Context, msg, data...)
+}
+
 // AddVar add var
 func (stmt *Statement) AddVar(writer clause.Writer, vars ...interface{}) {
 	for idx, v := range vars {
@@ -179,6 +358,10 @@ func (stmt *Statement) AddVar(writer clause.Writer, vars ...interface{}) {
 		switch v := v.(type) {
 		case sql.NamedArg:
 			stmt.Vars = append(stmt.Vars, v.Value)
+			if stmt.DB != nil && stmt.CheckBindVars {
+				// named args are passed to the driver without placeholders
+				stmt.bindVars.count++
+			}
 		case clause.Column, clause.Table:
 			stmt.QuoteTo(writer, v)
 		case Valuer:
@@ -196,10 +379,10 @@ func (stmt *Statement) AddVar(writer clause.Writer, vars ...interface{}) {
 			v.Build(stmt)
 		case driver.Valuer:
 			stmt.Vars = append(stmt.Vars, v)
-			stmt.DB.Dialector.BindVarTo(writer, stmt, v)
+			stmt.bindVarTo(writer, v)
 		case []byte:
 			stmt.Vars = append(stmt.Vars, v)
-			stmt.DB.Dialector.BindVarTo(writer, stmt, v)
+			stmt.bindVarTo(writer, v)
 		case []interface{}:
 			if len(v) > 0 {
 				writer.WriteByte('(')
@@ -228,6 +411,7 @@ func (stmt *Statement) AddVar(writer clause.Writer, vars ...interface{}) {
 
 				subdb.Statement.SQL.Reset()
 				subdb.Statement.Vars = stmt.Vars
+				subdb.Statement.bindVars = stmt.bindVars
 				if strings.Contains(sql, "@") {
 					clause.NamedExpr{SQL: sql, Vars: vars}.Build(subdb.Statement)
 				} else {
@@ -235,11 +419,13 @@ func (stmt *Statement) AddVar(writer clause.Writer, vars ...interface{}) {
 				}
 			} else {
 				subdb.Statement.Vars = append(stmt.Vars, subdb.Statement.Vars...)
-				subdb.callbacks.Query().Execute(subdb)
+				subdb.Statement.bindVars = stmt.bindVars
+				subdb.callbacks.Query().execute(subdb, true)
 			}
 
 			writer.WriteString(subdb.Statement.SQL.String())
 			stmt.Vars = subdb.Statement.Vars
+			stmt.bindVars = subdb.Statement.bindVars
 		default:
 			switch rv := reflect.ValueOf(v); rv.Kind() {
 			case reflect.Slice, reflect.Array:
@@ -247,7 +433,7 @@ func (stmt *Statement) AddVar(writer clause.Writer, vars ...interface{}) {
 					writer.WriteString("(NULL)")
 				} else if rv.Type().Elem() == reflect.TypeOf(uint8(0)) {
 					stmt.Vars = append(stmt.Vars, v)
-					stmt.DB.Dialector.BindVarTo(writer, stmt, v)
+					stmt.bindVarTo(writer, v)
 				} else {
 					writer.WriteByte('(')
 					for i := 0; i < rv.Len(); i++ {
@@ -260,12 +446,90 @@ func (stmt *Statement) AddVar(writer clause.Writer, vars ...interface{}) {
 				}
 			default:
 				stmt.Vars = append(stmt.Vars, v)
-				stmt.DB.Dialector.BindVarTo(writer, stmt, v)
+				stmt.bindVarTo(writer, v)
 			}
 		}
 	}
 }
 
+// placeholder returns the placeholder style overridden with `gorm:placeholder` in dry run mode
+func (stmt *Statement) placeholder() (Placeholder, bool) {
+	if stmt.DB == nil || !stmt.DB.DryRun {
+		return "", false
+	}
+
+	if v, ok := stmt.Settings.Load("gorm:placeholder"); ok {
+		switch style := v.(type) {
+		case Placeholder:
+			return style, true
+		case string:
+			return Placeholder(style), true
+		}
+	}
+	return "", false
+}
+
+// bindVarTo write placeholder of v, overridden placeholder style only applies to dry run SQL which won't be executed
+func (stmt *Statement) bindVarTo(writer clause.Writer, v interface{}) {
+	if stmt.DB.CheckBindVars {
+		checker := &bindVarChecker{Writer: writer}
+		writer = checker
+		defer func() { stmt.checkBindVar(checker.placeholder.String()) }()
+	}
+
+	if style, ok := stmt.placeholder(); ok {
+		writer.WriteString(string(style))
+		if style != PlaceholderQuestion {
+			writer.WriteString(strconv.Itoa(len(stmt.Vars)))
+		}
+		return
+	}
+	stmt.DB.Dialector.BindVarTo(writer, stmt, v)
+}
+
+// bindVarChecker records the placeholder written by the Dialector
+type bindVarChecker struct {
+	clause.Writer
+	placeholder strings.Builder
+}
+
+func (w *bindVarChecker) WriteByte(c byte) error {
+	w.placeholder.WriteByte(c)
+	return w.Writer.WriteByte(c)
+}
+
+func (w *bindVarChecker) WriteString(s string) (int, error) {
+	w.placeholder.WriteString(s)
+	return w.Writer.WriteString(s)
+}
+
+// checkBindVar count the placeholder of the last var, numbered placeholder should be the position of the var
+func (stmt *Statement) checkBindVar(placeholder string) {
+	if placeholder == "" {
+		stmt.bindVars.mismatches = append(stmt.bindVars.mismatches, fmt.Sprintf("no placeholder written for var #%d", len(stmt.Vars)))
+		return
+	}
+
+	stmt.bindVars.count++
+	idx := len(placeholder)
+	for idx > 0 && placeholder[idx-1] >= '0' && placeholder[idx-1] <= '9' {
+		idx--
+	}
+	if idx < len(placeholder) {
+		if n, err := strconv.Atoi(placeholder[idx:]); err != nil || n != len(stmt.Vars) {
+			stmt.bindVars.mismatches = append(stmt.bindVars.mismatches, fmt.Sprintf("placeholder %s written for var #%d", placeholder, len(stmt.Vars)))
+		}
+	}
+}
+
+// explain returns sql with vars for logging and ToSQL
+func (stmt *Statement) explain(sql string, vars ...interface{}) string {
+	if style, ok := stmt.placeholder(); ok {
+		return logger.ExplainSQL(sql, placeholderRegexps[style], `'`, vars...)
+	}
+	return stmt.DB.Dialector.Explain(sql, vars...)
+}
+
 // AddClause add clause
 func (stmt *Statement) AddClause(v clause.Interface) {
 	if optimizer, ok := v.(StatementModifier); ok {
@@ -275,6 +539,9 @@ func (stmt *Statement) AddClause(v clause.Interface) {
 		c := stmt.Clauses[name]
 		c.Name = name
 		v.MergeClause(&c)
+		if where, ok := c.Expression.(clause.Where); ok && stmt.DB != nil && stmt.DB.DedupConditions {
+			c.Expression = where.Dedup()
+		}
 		stmt.Clauses[name] = c
 	}
 }
@@ -481,6 +748,11 @@ func (stmt *Statement) BuildCondition(query interface{}, args ...interface{}) []
 func (stmt *Statement) Build(clauses ...string) {
 	var firstClauseWritten bool
 
+	if stmt.DB.ValidateIdentifiers && stmt.Table != "" && !safeTableRegexp.MatchString(stmt.Table) {
+		stmt.DB.AddError(fmt.Errorf("%w: table %q", ErrInvalidIdentifier, stmt.Table))
+		return
+	}
+
 	for _, name := range clauses {
 		if c, ok := stmt.Clauses[name]; ok {
 			if firstClauseWritten {
@@ -501,7 +773,31 @@ func (stmt *Statement) Parse(value interface{}) (err error) {
 	return stmt.ParseWithSpecialTableName(value, "")
 }
 
+// dynamicTableName returns the table name of value if it implements schema.DynamicTabler, the schema will be
+// cached by the model type and the table name, so one struct type could map to many tables, and the schema
+// cache keeps an entry for every distinct table name
+func (stmt *Statement) dynamicTableName(value interface{}) (tableName string) {
+	if rv := reflect.ValueOf(value); !rv.IsValid() || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		return ""
+	}
+
+	tabler, ok := value.(schema.DynamicTabler)
+	if !ok {
+		return ""
+	}
+	tableName = tabler.DynamicTableName()
+
+	if s, err := schema.Parse(value, stmt.DB.cacheStore, stmt.DB.NamingStrategy); err != nil || s.Table == tableName {
+		return ""
+	}
+	return tableName
+}
+
 func (stmt *Statement) ParseWithSpecialTableName(value interface{}, specialTableName string) (err error) {
+	if specialTableName == "" {
+		specialTableName = stmt.dynamicTableName(value)
+	}
+
 	if stmt.Schema, err = schema.ParseWithSpecialTableName(value, stmt.DB.cacheStore, stmt.DB.NamingStrategy, specialTableName); err == nil && stmt.Table == "" {
 		if tables := strings.Split(stmt.Schema.Table, "."); len(tables) == 2 {
 			stmt.TableExpr = &clause.Expr{SQL: stmt.Quote(stmt.Schema.Table)}
@@ -540,6 +836,7 @@ func (stmt *Statement) clone() *Statement {
 		newStmt.SQL.WriteString(stmt.SQL.String())
 		newStmt.Vars = make([]interface{}, 0, len(stmt.Vars))
 		newStmt.Vars = append(newStmt.Vars, stmt.Vars...)
+		newStmt.bindVars = bindVarStats{count: stmt.bindVars.count, mismatches: append([]string(nil), stmt.bindVars.mismatches...)}
 	}
 
 	for k, c := range stmt.Clauses {
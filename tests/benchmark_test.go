@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"gorm.io/gorm"
 	. "gorm.io/gorm/utils/tests"
 )
 
@@ -82,3 +83,25 @@ func BenchmarkDelete(b *testing.B) {
 		DB.Delete(&user)
 	}
 }
+
+func BenchmarkCallbacks(b *testing.B) {
+	for _, withMatch := range []bool{false, true} {
+		b.Run(fmt.Sprintf("match=%v", withMatch), func(b *testing.B) {
+			db, _ := gorm.Open(nil, nil)
+			queryCallback := db.Callback().Query()
+			for i := 0; i < 10; i++ {
+				if withMatch && i%2 == 0 {
+					queryCallback.Match(func(db *gorm.DB) bool { return db.Statement.Table == "users" }).Register(fmt.Sprintf("c%d", i), func(*gorm.DB) {})
+				} else {
+					queryCallback.Register(fmt.Sprintf("c%d", i), func(*gorm.DB) {})
+				}
+			}
+
+			tx := db.Session(&gorm.Session{DryRun: true})
+			b.ResetTimer()
+			for x := 0; x < b.N; x++ {
+				tx.Find(&User{})
+			}
+		})
+	}
+}
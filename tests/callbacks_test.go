@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	. "gorm.io/gorm/utils/tests"
 )
 
 func assertCallbacks(v interface{}, fnames []string) (result bool, msg string) {
@@ -50,6 +53,7 @@ func TestCallbacks(t *testing.T) {
 		err     string
 		match   func(*gorm.DB) bool
 		h       func(*gorm.DB)
+		labels  []string
 	}
 
 	datas := []struct {
@@ -109,6 +113,30 @@ func TestCallbacks(t *testing.T) {
 			callbacks: []callback{{h: c1}, {h: c2, before: "c4", after: "c5"}, {h: c3, before: "c4", after: "*"}, {h: c4, after: "*"}, {h: c5, before: "*"}},
 			results:   []string{"c5", "c1", "c2", "c3", "c4"},
 		},
+		{
+			callbacks: []callback{{h: c4, after: "label:tracing"}, {h: c1, labels: []string{"tracing"}}, {h: c2}, {h: c3, labels: []string{"tracing", "metrics"}}},
+			results:   []string{"c1", "c2", "c3", "c4"},
+		},
+		{
+			callbacks: []callback{{h: c1}, {h: c2, labels: []string{"tracing"}}, {h: c3}, {h: c4, labels: []string{"tracing"}}, {h: c5, before: "label:tracing"}},
+			results:   []string{"c1", "c5", "c2", "c3", "c4"},
+		},
+		{
+			callbacks: []callback{{h: c1, after: "c2"}, {h: c2, before: "label:tracing"}, {h: c3, labels: []string{"tracing"}}, {h: c4, after: "label:missing"}},
+			results:   []string{"c2", "c1", "c3", "c4"},
+		},
+		{
+			callbacks: []callback{{h: c1, labels: []string{"a"}, after: "label:b"}, {h: c2, labels: []string{"b"}, after: "label:a"}},
+			err:       "conflicting",
+		},
+		{
+			callbacks: []callback{{h: c2, after: "label:t"}, {h: c3, after: "c2"}, {h: c1, labels: []string{"t"}}},
+			results:   []string{"c1", "c2", "c3"},
+		},
+		{
+			callbacks: []callback{{h: c1, labels: []string{"t"}}, {h: c2, before: "label:t"}, {h: c3, before: "c2", after: "c1"}},
+			err:       "conflicting",
+		},
 	}
 
 	for idx, data := range datas {
@@ -152,6 +180,12 @@ func TestCallbacks(t *testing.T) {
 				callMethod(v, "Remove", c.name)
 			} else if c.replace {
 				callMethod(v, "Replace", c.name, c.h)
+			} else if len(c.labels) > 0 {
+				args := []interface{}{c.name, c.h}
+				for _, label := range c.labels {
+					args = append(args, label)
+				}
+				callMethod(v, "RegisterWithLabels", args...)
 			} else {
 				callMethod(v, "Register", c.name, c.h)
 			}
@@ -207,6 +241,173 @@ func TestPluginCallbacks(t *testing.T) {
 	}
 }
 
+func TestCallbacksWithPriority(t *testing.T) {
+	db, _ := gorm.Open(nil, nil)
+	createCallback := db.Callback().Create()
+
+	createCallback.Register("c1", c1)
+	createCallback.RegisterWithPriority("c2", 10, c2)
+	createCallback.Register("c3", c3)
+	if ok, msg := assertCallbacks(createCallback, []string{"c1", "c3", "c2"}); !ok {
+		t.Errorf("callbacks tests failed, got %v", msg)
+	}
+
+	createCallback.RegisterWithPriority("c4", -10, c4)
+	createCallback.RegisterWithPriority("c5", -10, c5)
+	if ok, msg := assertCallbacks(createCallback, []string{"c4", "c5", "c1", "c3", "c2"}); !ok {
+		t.Errorf("callbacks tests failed, got %v", msg)
+	}
+
+	// before/after relationship takes precedence over priority
+	createCallback.Before("c3").RegisterWithPriority("c6", 20, c6)
+	if ok, msg := assertCallbacks(createCallback, []string{"c4", "c5", "c1", "c6", "c3", "c2"}); !ok {
+		t.Errorf("callbacks tests failed, got %v", msg)
+	}
+
+	// replaced callback keeps the priority
+	createCallback.Replace("c4", c1)
+	if ok, msg := assertCallbacks(createCallback, []string{"c1", "c5", "c1", "c6", "c3", "c2"}); !ok {
+		t.Errorf("callbacks tests failed, got %v", msg)
+	}
+
+	createCallback.Remove("c5")
+	if ok, msg := assertCallbacks(createCallback, []string{"c1", "c1", "c6", "c3", "c2"}); !ok {
+		t.Errorf("callbacks tests failed, got %v", msg)
+	}
+}
+
+func TestCallbacksCompileError(t *testing.T) {
+	db, _ := gorm.Open(nil, nil)
+	queryCallback := db.Callback().Query()
+
+	if err := queryCallback.Register("c1", c1); err != nil {
+		t.Fatalf("failed to register callback, got error %v", err)
+	}
+
+	if err := queryCallback.Before("c1").After("c3").Register("c2", c2); err != nil {
+		t.Fatalf("failed to register callback, got error %v", err)
+	}
+
+	if err := queryCallback.Before("c2").After("c1").Register("c3", c3); err == nil || !strings.Contains(err.Error(), "conflicting") {
+		t.Fatalf("should return conflicting error for cyclic callbacks, got %v", err)
+	}
+
+	if err := db.Session(&gorm.Session{DryRun: true}).Find(&User{}).Error; err == nil || !strings.Contains(err.Error(), "conflicting") {
+		t.Errorf("should return conflicting error when executing broken callbacks, got %v", err)
+	}
+
+	if err := queryCallback.Replace("c3", c4); err == nil {
+		t.Errorf("replacing callback should still return conflicting error, got %v", err)
+	}
+
+	if err := queryCallback.Remove("c3"); err != nil {
+		t.Fatalf("failed to remove callback, got error %v", err)
+	}
+
+	if ok, msg := assertCallbacks(queryCallback, []string{"c2", "c1"}); !ok {
+		t.Errorf("callbacks tests failed, got %v", msg)
+	}
+
+	if err := db.Session(&gorm.Session{DryRun: true}).Find(&User{}).Error; err != nil {
+		t.Errorf("should not return error after fixing callbacks, got %v", err)
+	}
+}
+
+func TestCallbacksIntrospection(t *testing.T) {
+	db, _ := gorm.Open(nil, nil)
+	createCallback := db.Callback().Create()
+
+	createCallback.Register("c1", c1)
+	createCallback.Register("c2", c2)
+	createCallback.Before("c2").Register("c3", c3)
+	createCallback.Match(func(*gorm.DB) bool { return false }).Register("c4", c4)
+
+	if names := createCallback.Callbacks(); fmt.Sprint(names) != "[c1 c3 c2 c4]" {
+		t.Errorf("callbacks should be [c1 c3 c2 c4], got %v", names)
+	}
+
+	createCallback.Remove("c3")
+	if names := createCallback.Callbacks(); fmt.Sprint(names) != "[c1 c2 c4]" {
+		t.Errorf("removed callbacks should be excluded, got %v", names)
+	}
+
+	if !createCallback.Exists("c1") || !createCallback.Exists("c4") || createCallback.Exists("c3") {
+		t.Errorf("c1 and c4 should exist, c3 should not exist")
+	}
+
+	names := createCallback.Callbacks()
+	names[0] = "c5"
+	if createCallback.Exists("c5") {
+		t.Errorf("returned names should not change the compiled callbacks")
+	}
+
+	if ok, msg := assertCallbacks(db.Callback().Query(), []string{}); !ok || len(db.Callback().Query().Callbacks()) != 0 {
+		t.Errorf("query callbacks should be empty, got %v", msg)
+	}
+}
+
+func TestCallbacksMatchWhenExecuting(t *testing.T) {
+	db, _ := gorm.Open(nil, nil)
+	queryCallback := db.Callback().Query()
+
+	var tables []string
+	queryCallback.Register("c1", func(db *gorm.DB) { tables = append(tables, "all:"+db.Statement.Table) })
+	queryCallback.Match(func(db *gorm.DB) bool {
+		return db.Statement.Table == "users"
+	}).Register("c2", func(db *gorm.DB) { tables = append(tables, "users:"+db.Statement.Table) })
+
+	tx := db.Session(&gorm.Session{DryRun: true})
+	tx.Find(&User{})
+	tx.Find(&Company{})
+	tx.Model(&User{}).Find(&[]map[string]interface{}{})
+
+	if fmt.Sprint(tables) != "[all:users users:users all:companies all:users users:users]" {
+		t.Errorf("match should be evaluated when executing, got %v", tables)
+	}
+}
+
+func TestCallbacksDuplicatedWarning(t *testing.T) {
+	writer := &bufferWriter{}
+	db, _ := gorm.Open(nil, &gorm.Config{Logger: logger.New(writer, logger.Config{LogLevel: logger.Warn})})
+	createCallback := db.Callback().Create()
+
+	duplicatedWarnings := func() (count int) {
+		for _, log := range writer.logs {
+			if strings.Contains(log, "duplicated callback") {
+				count++
+			}
+		}
+		return
+	}
+
+	createCallback.Before("*").Register("c1", c1)
+	createCallback.Register("c2", c2)
+	createCallback.Replace("c1", c3)
+	if ok, msg := assertCallbacks(createCallback, []string{"c3", "c2"}); !ok {
+		t.Errorf("callbacks tests failed, got %v", msg)
+	}
+
+	createCallback.Replace("c1", c4)
+	createCallback.Remove("c1")
+	createCallback.Register("c1", c5)
+	createCallback.Replace("c2", c6)
+	createCallback.Remove("c2")
+	if ok, msg := assertCallbacks(createCallback, []string{"c5"}); !ok {
+		t.Errorf("callbacks tests failed, got %v", msg)
+	}
+
+	if count := duplicatedWarnings(); count != 0 {
+		t.Errorf("should not warn duplicated callbacks when replacing and removing, got %v", writer.logs)
+	}
+
+	createCallback.Register("c1", c1)
+	createCallback.Register("c3", c3)
+	createCallback.Register("c4", c4)
+	if count := duplicatedWarnings(); count != 1 {
+		t.Errorf("should warn duplicated callback once, got %v", writer.logs)
+	}
+}
+
 func TestCallbacksGet(t *testing.T) {
 	db, _ := gorm.Open(nil, nil)
 	createCallback := db.Callback().Create()
@@ -252,3 +453,113 @@ func TestCallbacksRemove(t *testing.T) {
 		t.Errorf("callbacks tests failed, got %v", msg)
 	}
 }
+
+func TestClauseRewriter(t *testing.T) {
+	db, err := OpenTestConnection(&gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	var rewritten []string
+	db.Callback().RegisterClauseRewriter(func(stmt *gorm.Statement) {
+		operation := stmt.BuildClauses[0]
+		if operation == "WITH" {
+			operation = stmt.BuildClauses[1]
+		}
+		rewritten = append(rewritten, operation)
+		if operation == "SELECT" {
+			if _, ok := stmt.Clauses["LIMIT"]; !ok {
+				limit := 100
+				stmt.AddClause(clause.Limit{Limit: &limit})
+			}
+		}
+	})
+
+	user := User{Name: "clause_rewriter"}
+	user.ID = 1
+	db.Create(&user)
+
+	var users []User
+	if result := db.Find(&users); !strings.Contains(result.Statement.SQL.String(), "LIMIT 100") {
+		t.Errorf("should add default limit, got %v", result.Statement.SQL.String())
+	}
+
+	if result := db.Limit(10).Find(&users); !strings.Contains(result.Statement.SQL.String(), "LIMIT 10") || strings.Contains(result.Statement.SQL.String(), "LIMIT 100") {
+		t.Errorf("should keep user's limit, got %v", result.Statement.SQL.String())
+	}
+
+	subQuery := db.Model(&User{}).Select("id").Where("name = ?", "clause_rewriter")
+	if result := db.Where("id IN (?)", subQuery).Find(&users); strings.Count(result.Statement.SQL.String(), "LIMIT") != 1 {
+		t.Errorf("should not rewrite clauses of subqueries, got %v", result.Statement.SQL.String())
+	}
+
+	db.Model(&user).Update("name", "clause_rewriter_new")
+	db.Delete(&user)
+
+	if !reflect.DeepEqual(rewritten, []string{"INSERT", "SELECT", "SELECT", "SELECT", "UPDATE", "DELETE"}) {
+		t.Errorf("clause rewriter should run for all operations, got %v", rewritten)
+	}
+}
+
+func TestCallbacksExportDOT(t *testing.T) {
+	db, _ := gorm.Open(nil, nil)
+	createCallback := db.Callback().Create()
+
+	createCallback.Before("*").Register("c1", c1)
+	createCallback.Register("c2", c2)
+	createCallback.After("c2").Register("c3", c3)
+	createCallback.Before("c5").Register("c4", c4)
+
+	dot := createCallback.ExportDOT()
+	for _, line := range []string{
+		`"c1" [xlabel=first];`,
+		`"c5" [style=dashed];`,
+		`"c2" -> "c3" [label=after];`,
+		`"c4" -> "c5" [label=before];`,
+	} {
+		if !strings.Contains(dot, line) {
+			t.Errorf("DOT graph should contain %v, got %v", line, dot)
+		}
+	}
+
+	if strings.Contains(dot, "red") {
+		t.Errorf("DOT graph should not contain cycles, got %v", dot)
+	}
+
+	createCallback.Before("c2").After("c3").Register("c6", c6)
+	dot = createCallback.ExportDOT()
+	for _, line := range []string{
+		`"c6" -> "c2" [label=before, color=red];`,
+		`"c3" -> "c6" [label=after, color=red];`,
+		`"c2" -> "c3" [label=after, color=red];`,
+		`"c6" [color=red];`,
+	} {
+		if !strings.Contains(dot, line) {
+			t.Errorf("DOT graph should highlight cycle with %v, got %v", line, dot)
+		}
+	}
+
+	if strings.Contains(dot, `"c4" -> "c5" [label=before, color=red]`) {
+		t.Errorf("edges not in the cycle should not be highlighted, got %v", dot)
+	}
+}
+
+func TestCallbacksExportDOTWithLabels(t *testing.T) {
+	db, _ := gorm.Open(nil, nil)
+	createCallback := db.Callback().Create()
+
+	createCallback.RegisterWithLabels("c1", c1, "tracing")
+	createCallback.RegisterWithLabels("c2", c2, "tracing")
+	createCallback.After("label:tracing").RegisterWithLabels("c3", c3, "tracing")
+
+	dot := createCallback.ExportDOT()
+	for _, line := range []string{`"c1" -> "c3" [label=after];`, `"c2" -> "c3" [label=after];`} {
+		if !strings.Contains(dot, line) {
+			t.Errorf("DOT graph should contain %v, got %v", line, dot)
+		}
+	}
+
+	if strings.Contains(dot, "label:tracing") || strings.Contains(dot, `"c3" -> "c3"`) {
+		t.Errorf("DOT graph should resolve labels, got %v", dot)
+	}
+}
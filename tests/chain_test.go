@@ -0,0 +1,54 @@
+package tests_test
+
+import (
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+	. "gorm.io/gorm/utils/tests"
+)
+
+func TestChain(t *testing.T) {
+	user := GetUser("chain", Config{})
+	var pet Pet
+
+	result := DB.Chain().
+		Create(user).
+		Create(func() interface{} { pet = Pet{UserID: &user.ID, Name: "chain_pet"}; return &pet }).
+		Create(map[string]interface{}{"name": "chain_toy", "owner_type": "users", "owner_id": gorm.Returned(user, "ID")}, &Toy{}).
+		Updates(user, map[string]interface{}{"age": gorm.Returned(&pet, "ID")}).
+		Exec()
+	if result.Error != nil || result.RowsAffected != 4 {
+		t.Fatalf("failed to execute chain, got error %v, rows affected %v", result.Error, result.RowsAffected)
+	}
+
+	var result2 User
+	if err := DB.Preload("Pets").Preload("Toys").First(&result2, user.ID).Error; err != nil {
+		t.Fatalf("failed to find user, got error %v", err)
+	}
+
+	if len(result2.Pets) != 1 || result2.Pets[0].ID != pet.ID || len(result2.Toys) != 1 || result2.Age != pet.ID {
+		t.Errorf("chained statements should reference returned values, got %+v", result2)
+	}
+
+	user = GetUser("chain_rollback", Config{})
+	err := DB.Chain().
+		Create(user).
+		Then(func(tx *gorm.DB) *gorm.DB {
+			tx.AddError(errors.New("chain failed"))
+			return tx
+		}).
+		Exec().Error
+	if err == nil || err.Error() != "chain failed" {
+		t.Errorf("should return error of the failed statement, got %v", err)
+	}
+
+	var count int64
+	if DB.Model(&User{}).Where("name = ?", user.Name).Count(&count); count != 0 {
+		t.Errorf("chain should be rolled back, got %v", count)
+	}
+
+	if err := DB.Chain().Updates(&User{}, map[string]interface{}{"age": gorm.Returned(&User{}, "ID")}).Exec().Error; !errors.Is(err, gorm.ErrInvalidValue) {
+		t.Errorf("should return ErrInvalidValue for value not returned, got %v", err)
+	}
+}
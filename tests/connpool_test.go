@@ -3,8 +3,10 @@ package tests_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"gorm.io/driver/mysql"
@@ -172,3 +174,97 @@ func TestConnPoolWrapper(t *testing.T) {
 		t.Fatalf("Should be able to find committed record, but got %v", err)
 	}
 }
+
+func TestInstrumentedConnPool(t *testing.T) {
+	db, err := OpenTestConnection(&gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	var stats []gorm.StatementStats
+	onStatement := func(s gorm.StatementStats) { stats = append(stats, s) }
+	db.ConnPool = gorm.NewInstrumentedConnPool(db.ConnPool, onStatement)
+	db.Statement.ConnPool = db.ConnPool
+
+	if sqlDB, err := db.DB(); err != nil || sqlDB == nil || sqlDB.Ping() != nil {
+		t.Fatalf("should return the underlying sql.DB, got %v, error %v", sqlDB, err)
+	}
+
+	user := *GetUser("instrumented_conn_pool", Config{})
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("failed to create user, got error %v", err)
+	}
+
+	if len(stats) != 1 || !strings.HasPrefix(stats[0].SQL, "INSERT") || !stats[0].Transaction || stats[0].Duration <= 0 {
+		t.Fatalf("should report the insert executed in the default transaction, got %#v", stats)
+	}
+
+	stats = nil
+	if err := db.First(&User{}, "name = ?", user.Name).Error; err != nil {
+		t.Fatalf("failed to find user, got error %v", err)
+	}
+	if len(stats) != 1 || !strings.HasPrefix(stats[0].SQL, "SELECT") || stats[0].RowsAffected != -1 || stats[0].Transaction ||
+		len(stats[0].Vars) == 0 || stats[0].Vars[0] != user.Name {
+		t.Fatalf("should report the query, got %#v", stats)
+	}
+
+	stats = nil
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if _, ok := tx.Statement.ConnPool.(gorm.TxCommitter); !ok {
+			t.Fatalf("transaction should be a TxCommitter, got %T", tx.Statement.ConnPool)
+		}
+		if err := tx.Model(&user).Update("age", 30).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Transaction(func(tx2 *gorm.DB) error {
+			tx2.Model(&user).Update("age", 40)
+			return errors.New("rollback nested transaction")
+		}); err == nil {
+			t.Errorf("nested transaction should return error")
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to commit transaction, got error %v", err)
+	}
+
+	var sqls []string
+	for _, s := range stats {
+		if !s.Transaction {
+			t.Errorf("statements should be reported in transaction, got %#v", s)
+		}
+		sqls = append(sqls, strings.Fields(s.SQL)[0])
+	}
+	// savepoint statements are dialect specific, e.g: SAVE TRANSACTION of sqlserver
+	if len(sqls) != 4 || sqls[0] != "UPDATE" || sqls[2] != "UPDATE" || stats[0].RowsAffected != 1 {
+		t.Fatalf("should report the statements of nested transactions, got %#v", stats)
+	}
+
+	var result User
+	db.First(&result, user.ID)
+	if result.Age != 30 {
+		t.Errorf("nested transaction should be rolled back, got age %v", result.Age)
+	}
+
+	stats = nil
+	plain, _ := OpenTestConnection(&gorm.Config{})
+	if err := plain.Transaction(func(tx *gorm.DB) error {
+		tx.Statement.ConnPool = gorm.NewInstrumentedConnPool(tx.Statement.ConnPool, onStatement)
+		if _, ok := tx.Statement.ConnPool.(gorm.TxCommitter); !ok {
+			t.Fatalf("wrapped transaction should be a TxCommitter, got %T", tx.Statement.ConnPool)
+		}
+		return tx.Model(&user).Update("age", 50).Error
+	}); err != nil {
+		t.Fatalf("failed to commit the wrapped transaction, got error %v", err)
+	}
+
+	if len(stats) != 1 || !strings.HasPrefix(stats[0].SQL, "UPDATE") || !stats[0].Transaction {
+		t.Errorf("should report statements of the wrapped transaction, got %#v", stats)
+	}
+
+	var committed User
+	db.First(&committed, user.ID)
+	if committed.Age != 50 {
+		t.Errorf("wrapped transaction should be committed, got age %v", committed.Age)
+	}
+}
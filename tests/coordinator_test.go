@@ -0,0 +1,134 @@
+package tests_test
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	. "gorm.io/gorm/utils/tests"
+)
+
+type preparedDialector struct {
+	gorm.Dialector
+	calls *[]string
+}
+
+func (d preparedDialector) PrepareTransaction(tx *gorm.DB, id string) error {
+	*d.calls = append(*d.calls, "prepare")
+	return nil
+}
+
+func (d preparedDialector) CommitPrepared(db *gorm.DB, id string) error {
+	*d.calls = append(*d.calls, "commit prepared")
+	return nil
+}
+
+func (d preparedDialector) RollbackPrepared(db *gorm.DB, id string) error {
+	*d.calls = append(*d.calls, "rollback prepared")
+	return nil
+}
+
+type failedCommitTx struct {
+	*sql.Tx
+}
+
+func (tx *failedCommitTx) Commit() error {
+	tx.Tx.Rollback()
+	return errors.New("commit failed")
+}
+
+func TestTransactionCoordinator(t *testing.T) {
+	if DB.Dialector.Name() != "sqlite" {
+		t.Skip()
+	}
+
+	var calls []string
+	outboxDB, err := gorm.Open(preparedDialector{Dialector: sqlite.Open(filepath.Join(t.TempDir(), "gorm_outbox.db")), calls: &calls}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open outbox db, got error %v", err)
+	}
+	outboxDB.Migrator().DropTable(&Pet{})
+	outboxDB.AutoMigrate(&Pet{})
+
+	user := GetUser("coordinator", Config{})
+	pet := Pet{Name: "coordinator_pet"}
+	if err := gorm.NewTransactionCoordinator(DB, outboxDB).Transaction(func(txs ...*gorm.DB) error {
+		if err := txs[0].Create(user).Error; err != nil {
+			return err
+		}
+		return txs[1].Create(&pet).Error
+	}); err != nil {
+		t.Fatalf("failed to commit, got error %v", err)
+	}
+
+	if err := DB.First(&User{}, user.ID).Error; err != nil {
+		t.Errorf("user should be committed, got error %v", err)
+	}
+
+	if err := outboxDB.First(&Pet{}, pet.ID).Error; err != nil {
+		t.Errorf("pet should be committed, got error %v", err)
+	}
+
+	if strings.Join(calls, ",") != "prepare,commit prepared" {
+		t.Errorf("outbox db should be committed with two-phase commit, got %v", calls)
+	}
+
+	// rolls back all transactions
+	calls = nil
+	user = GetUser("coordinator_rollback", Config{})
+	pet = Pet{Name: "coordinator_rollback_pet"}
+	if err := gorm.NewTransactionCoordinator(DB, outboxDB).Transaction(func(txs ...*gorm.DB) error {
+		txs[0].Create(user)
+		txs[1].Create(&pet)
+		return errors.New("failed")
+	}); err == nil || err.Error() != "failed" {
+		t.Errorf("should return the error, got %v", err)
+	}
+
+	if err := DB.Where("name = ?", user.Name).First(&User{}).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("user should be rolled back, got error %v", err)
+	}
+
+	if err := outboxDB.Where("name = ?", pet.Name).First(&Pet{}).Error; !errors.Is(err, gorm.ErrRecordNotFound) || len(calls) != 0 {
+		t.Errorf("pet should be rolled back, got error %v, calls %v", err, calls)
+	}
+
+	// rolls back all transactions when failed to release the prepared transaction
+	calls = nil
+	user = GetUser("coordinator_release", Config{})
+	if err := gorm.NewTransactionCoordinator(DB, outboxDB).Transaction(func(txs ...*gorm.DB) error {
+		txs[1].Statement.ConnPool = &failedCommitTx{Tx: txs[1].Statement.ConnPool.(*sql.Tx)}
+		return txs[0].Create(user).Error
+	}); err == nil || err.Error() != "commit failed" {
+		t.Errorf("should return the commit error, got %v", err)
+	}
+
+	if err := DB.Where("name = ?", user.Name).First(&User{}).Error; !errors.Is(err, gorm.ErrRecordNotFound) || strings.Join(calls, ",") != "prepare,rollback prepared" {
+		t.Errorf("all transactions should be rolled back, got error %v, calls %v", err, calls)
+	}
+
+	// compensates committed transactions when failed to commit
+	compensated := false
+	user = GetUser("coordinator_compensate", Config{})
+	err = gorm.NewTransactionCoordinator().
+		Join(DB, func(db *gorm.DB) error {
+			compensated = true
+			return db.Unscoped().Delete(user).Error
+		}).
+		Join(DB.Session(&gorm.Session{}), nil).
+		Transaction(func(txs ...*gorm.DB) error {
+			txs[1].Statement.ConnPool = &failedCommitTx{Tx: txs[1].Statement.ConnPool.(*sql.Tx)}
+			return txs[0].Create(user).Error
+		})
+	if err == nil || !strings.Contains(err.Error(), "commit failed") || !compensated {
+		t.Errorf("should compensate committed transactions, got error %v, compensated %v", err, compensated)
+	}
+
+	if err := DB.Unscoped().Where("name = ?", user.Name).First(&User{}).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("user should be compensated, got error %v", err)
+	}
+}
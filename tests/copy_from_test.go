@@ -0,0 +1,58 @@
+package tests_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"gorm.io/gorm"
+	. "gorm.io/gorm/utils/tests"
+)
+
+type copyFromConnPool struct {
+	*sql.DB
+	table   string
+	columns []string
+	rows    [][]interface{}
+}
+
+func (p *copyFromConnPool) CopyFrom(ctx context.Context, table string, columns []string, rows [][]interface{}) (int64, error) {
+	p.table, p.columns, p.rows = table, columns, rows
+	return int64(len(rows)), nil
+}
+
+func TestCopyFrom(t *testing.T) {
+	rows := [][]interface{}{{"copy_from_1", 18}, {"copy_from_2", 20}, {"copy_from_3", 22}}
+	result := DB.Session(&gorm.Session{CreateBatchSize: 2}).CopyFrom("users", []string{"name", "age"}, rows)
+	if result.Error != nil || result.RowsAffected != 3 {
+		t.Fatalf("failed to copy rows with batched insert, rows affected %v, got error %v", result.RowsAffected, result.Error)
+	}
+
+	var users []User
+	DB.Where("name LIKE ?", "copy_from_%").Order("name").Find(&users)
+	if len(users) != 3 || users[0].Name != "copy_from_1" || users[2].Age != 22 {
+		t.Errorf("failed to copy rows, got %+v", users)
+	}
+
+	if err := DB.CopyFrom("users", []string{"name", "age"}, [][]interface{}{{"copy_from_4"}}).Error; err == nil {
+		t.Errorf("should return error when row length doesn't match columns")
+	}
+
+	sqlDB, err := DB.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql.DB, got error %v", err)
+	}
+
+	pool := &copyFromConnPool{DB: sqlDB}
+	db := DB.Session(&gorm.Session{Context: context.Background()})
+	db.Statement.ConnPool = pool
+
+	result = db.Model(&User{}).CopyFrom("", []string{"Name", "Age"}, [][]interface{}{{"copy_from_5", 30}})
+	if result.Error != nil || result.RowsAffected != 1 {
+		t.Fatalf("failed to copy rows with COPY protocol, rows affected %v, got error %v", result.RowsAffected, result.Error)
+	}
+
+	if pool.table != "users" || len(pool.columns) != 2 || pool.columns[0] != "name" || pool.columns[1] != "age" || len(pool.rows) != 1 {
+		t.Errorf("should copy rows with columns resolved from schema, got %v %v %v", pool.table, pool.columns, pool.rows)
+	}
+}
@@ -1,15 +1,21 @@
 package tests_test
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/jinzhu/now"
 	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 	. "gorm.io/gorm/utils/tests"
 )
 
@@ -100,6 +106,73 @@ func TestCreateInBatchesWithDefaultSize(t *testing.T) {
 	}
 }
 
+func TestCreateInBatchesWithLastInsertID(t *testing.T) {
+	if DB.Dialector.Name() != "sqlite" && !isMysql() {
+		t.Skip("This test case skipped, because LastInsertId is not supported")
+	}
+
+	// back-fill primary keys with LastInsertId of every chunk instead of RETURNING,
+	// sqlite returns the id of the last row inserted by a multi-row INSERT, mysql returns the first one
+	db, err := OpenTestConnection(&gorm.Config{CreateBatchSize: 2})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	db.Callback().Create().Replace("gorm:create", callbacks.Create(&callbacks.Config{LastInsertIDReversed: !isMysql()}))
+
+	users := []*User{
+		GetUser("create_in_batches_last_insert_id_1", Config{}),
+		GetUser("create_in_batches_last_insert_id_2", Config{}),
+		GetUser("create_in_batches_last_insert_id_3", Config{}),
+		GetUser("create_in_batches_last_insert_id_4", Config{}),
+		GetUser("create_in_batches_last_insert_id_5", Config{}),
+	}
+	if err := db.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	for _, user := range users {
+		var result User
+		if err := DB.First(&result, user.ID).Error; err != nil || result.Name != user.Name {
+			t.Errorf("primary key of %v should be back-filled across chunks, got id %v, found %+v, error %v", user.Name, user.ID, result.Name, err)
+		}
+	}
+
+	maps := []map[string]interface{}{
+		{"Name": "create_in_batches_last_insert_id_map_1"},
+		{"Name": "create_in_batches_last_insert_id_map_2"},
+		{"Name": "create_in_batches_last_insert_id_map_3"},
+	}
+	if err := db.Model(&User{}).Create(&maps).Error; err != nil {
+		t.Fatalf("failed to create from maps, got error %v", err)
+	}
+
+	for _, m := range maps {
+		var result User
+		if err := DB.First(&result, m["id"]).Error; err != nil || result.Name != m["Name"] {
+			t.Errorf("primary key of %v should be back-filled across chunks, got id %v, found %+v, error %v", m["Name"], m["id"], result.Name, err)
+		}
+	}
+}
+
+func TestCreateInBatchesDryRun(t *testing.T) {
+	users := []User{*GetUser("create_in_batches_dry_run_1", Config{}), *GetUser("create_in_batches_dry_run_2", Config{}), *GetUser("create_in_batches_dry_run_3", Config{})}
+
+	tx := DB.Session(&gorm.Session{DryRun: true, CreateBatchSize: 2}).Create(&users)
+	if sql := tx.Statement.SQL.String(); strings.Count(sql, "),(") != 1 || !strings.Contains(sql, "INSERT INTO") {
+		t.Errorf("should build the SQL of the first batch, got %v", sql)
+	}
+
+	if count, ok := tx.Get("gorm:create_batch_count"); !ok || count != 2 {
+		t.Errorf("batch count should be 2, got %v", count)
+	}
+
+	var total int64
+	DB.Model(&User{}).Where("name LIKE ?", "create_in_batches_dry_run_%").Count(&total)
+	if total != 0 {
+		t.Errorf("should not create users in dry run, got %v", total)
+	}
+}
+
 func TestCreateFromMap(t *testing.T) {
 	if err := DB.Model(&User{}).Create(map[string]interface{}{"Name": "create_from_map", "Age": 18}).Error; err != nil {
 		t.Fatalf("failed to create data from map, got error: %v", err)
@@ -791,3 +864,1144 @@ func TestCreateFromMapWithTable(t *testing.T) {
 		t.Errorf("failed to create data from map with table, @id != id")
 	}
 }
+
+func TestCreateSkipTriggerManagedColumns(t *testing.T) {
+	type TriggerManagedUser struct {
+		ID         uint
+		Name       string
+		SearchName string `gorm:"triggerManaged"`
+	}
+
+	dryDB := DB.Session(&gorm.Session{DryRun: true})
+
+	stmt := dryDB.Create(&TriggerManagedUser{Name: "trigger", SearchName: "ignored"}).Statement
+	if !regexp.MustCompile("INSERT INTO .trigger_managed_users. \\(.name.\\) VALUES").MatchString(stmt.SQL.String()) {
+		t.Errorf("trigger managed column should not be inserted, got %v", stmt.SQL.String())
+	}
+
+	if _, ok := stmt.Clauses["RETURNING"]; ok && !regexp.MustCompile("RETURNING .*search_name").MatchString(stmt.SQL.String()) {
+		t.Errorf("trigger managed column should be returned, got %v", stmt.SQL.String())
+	}
+
+	stmt = dryDB.Model(&TriggerManagedUser{ID: 1}).Updates(&TriggerManagedUser{Name: "trigger", SearchName: "ignored"}).Statement
+	if strings.Contains(stmt.SQL.String(), "search_name") {
+		t.Errorf("trigger managed column should not be updated, got %v", stmt.SQL.String())
+	}
+
+	stmt = dryDB.Model(&TriggerManagedUser{ID: 1}).Updates(map[string]interface{}{"name": "trigger", "search_name": "ignored"}).Statement
+	if strings.Contains(stmt.SQL.String(), "search_name") {
+		t.Errorf("trigger managed column should not be updated, got %v", stmt.SQL.String())
+	}
+}
+
+func TestCreateWithFieldSizeValidation(t *testing.T) {
+	type SizedUser struct {
+		ID   uint
+		Name string `gorm:"size:5"`
+		Code []byte `gorm:"size:2"`
+	}
+
+	db, err := OpenTestConnection(&gorm.Config{ValidateFieldSize: true})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	dryDB := db.Session(&gorm.Session{DryRun: true})
+
+	if err := dryDB.Create(&SizedUser{Name: "jinzhu"}).Error; !errors.Is(err, gorm.ErrFieldValueTooLong) || !strings.Contains(err.Error(), "Name") {
+		t.Errorf("should failed to create with too long name, got %v", err)
+	}
+
+	if err := dryDB.Create(&[]SizedUser{{Name: "ok"}, {Code: []byte("abc")}}).Error; !errors.Is(err, gorm.ErrFieldValueTooLong) || !strings.Contains(err.Error(), "Code") {
+		t.Errorf("should failed to create with too long code, got %v", err)
+	}
+
+	if err := dryDB.Model(&SizedUser{ID: 1}).Update("name", "jinzhu").Error; !errors.Is(err, gorm.ErrFieldValueTooLong) {
+		t.Errorf("should failed to update with too long name, got %v", err)
+	}
+
+	if err := dryDB.Create(&SizedUser{Name: "金州金州金", Code: []byte("ab")}).Error; err != nil {
+		t.Errorf("should create with valid size, got %v", err)
+	}
+
+	if err := DB.Session(&gorm.Session{DryRun: true}).Create(&SizedUser{Name: "jinzhu"}).Error; err != nil {
+		t.Errorf("field size validation should be opt-in, got %v", err)
+	}
+}
+
+func TestCreateWithNullTypes(t *testing.T) {
+	type NullTypeUser struct {
+		ID       uint
+		Name     string
+		Nickname sql.NullString  `gorm:"default:nick"`
+		Title    *sql.NullString `gorm:"default:title"`
+		Age      sql.NullInt64   `gorm:"default:18"`
+	}
+
+	DB.Migrator().DropTable(&NullTypeUser{})
+	if err := DB.AutoMigrate(&NullTypeUser{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	users := []NullTypeUser{
+		// unset fields use default values
+		{Name: "unset"},
+		// valid but empty values are inserted as is
+		{Name: "valid", Nickname: sql.NullString{Valid: true}, Title: &sql.NullString{Valid: true}, Age: sql.NullInt64{Valid: true}},
+		// invalid values behind pointers are inserted as NULL
+		{Name: "invalid", Title: &sql.NullString{}},
+	}
+
+	for _, user := range users {
+		if err := DB.Create(&user).Error; err != nil {
+			t.Fatalf("failed to create user %v, got error %v", user.Name, err)
+		}
+	}
+
+	if err := DB.Create(&[]NullTypeUser{{Name: "batch_unset"}, {Name: "batch_invalid", Title: &sql.NullString{}}}).Error; err != nil {
+		t.Fatalf("failed to create users in batch, got error %v", err)
+	}
+
+	expects := map[string]NullTypeUser{
+		"unset":         {Nickname: sql.NullString{String: "nick", Valid: true}, Title: &sql.NullString{String: "title", Valid: true}, Age: sql.NullInt64{Int64: 18, Valid: true}},
+		"valid":         {Nickname: sql.NullString{Valid: true}, Title: &sql.NullString{Valid: true}, Age: sql.NullInt64{Valid: true}},
+		"invalid":       {Nickname: sql.NullString{String: "nick", Valid: true}, Age: sql.NullInt64{Int64: 18, Valid: true}},
+		"batch_unset":   {Nickname: sql.NullString{String: "nick", Valid: true}, Title: &sql.NullString{String: "title", Valid: true}, Age: sql.NullInt64{Int64: 18, Valid: true}},
+		"batch_invalid": {Nickname: sql.NullString{String: "nick", Valid: true}, Age: sql.NullInt64{Int64: 18, Valid: true}},
+	}
+
+	for name, expect := range expects {
+		var result NullTypeUser
+		if err := DB.First(&result, "name = ?", name).Error; err != nil {
+			t.Fatalf("failed to find user %v, got error %v", name, err)
+		}
+
+		if result.Nickname != expect.Nickname || result.Age != expect.Age {
+			t.Errorf("user %v expects nickname %+v, age %+v, got %+v, %+v", name, expect.Nickname, expect.Age, result.Nickname, result.Age)
+		}
+
+		// NULL is scanned into a nil pointer
+		if (result.Title == nil) != (expect.Title == nil) || (result.Title != nil && *result.Title != *expect.Title) {
+			t.Errorf("user %v expects title %+v, got %+v", name, expect.Title, result.Title)
+		}
+	}
+}
+
+func TestCreateReturningColumnsOrder(t *testing.T) {
+	type ReturningOrderUser struct {
+		ID        uint
+		Code      string `gorm:"default:(lower(hex(randomblob(4))))"`
+		Name      string
+		Reference string `gorm:"default:(lower(hex(randomblob(4))))"`
+	}
+
+	for i := 0; i < 3; i++ {
+		stmt := DB.Session(&gorm.Session{DryRun: true}).Create(&ReturningOrderUser{Name: "returning"}).Statement
+		if _, ok := stmt.Clauses["RETURNING"]; ok && !regexp.MustCompile("RETURNING .id.,.code.,.reference.$").MatchString(stmt.SQL.String()) {
+			t.Errorf("RETURNING columns should follow the order of fields, got %v", stmt.SQL.String())
+		}
+	}
+}
+
+func TestCreateWithSkipReturning(t *testing.T) {
+	users := []User{*GetUser("skip_returning_1", Config{}), *GetUser("skip_returning_2", Config{})}
+
+	stmt := DB.Session(&gorm.Session{DryRun: true}).Set("gorm:skip_returning", true).Create(&users).Statement
+	if strings.Contains(stmt.SQL.String(), "RETURNING") {
+		t.Errorf("should not contain RETURNING, got %v", stmt.SQL.String())
+	}
+
+	stmt = DB.Session(&gorm.Session{DryRun: true}).Set("gorm:skip_returning", true).Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).Create(&users).Statement
+	if _, ok := stmt.Clauses["RETURNING"]; ok && !strings.Contains(stmt.SQL.String(), "RETURNING") {
+		t.Errorf("should keep RETURNING explicitly requested, got %v", stmt.SQL.String())
+	}
+
+	result := DB.Set("gorm:skip_returning", true).Create(&users)
+	if result.Error != nil || result.RowsAffected != 2 {
+		t.Fatalf("failed to create users, got error %v, rows affected %v", result.Error, result.RowsAffected)
+	}
+
+	var count int64
+	if DB.Model(&User{}).Where("name IN ?", []string{"skip_returning_1", "skip_returning_2"}).Count(&count); count != 2 {
+		t.Errorf("should create 2 users, got %v", count)
+	}
+}
+
+func TestCreateWithOutbox(t *testing.T) {
+	type OutboxEvent struct {
+		ID      uint
+		Topic   string
+		Payload string
+	}
+
+	DB.Migrator().DropTable(&OutboxEvent{})
+	if err := DB.AutoMigrate(&OutboxEvent{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	user := GetUser("create_with_outbox", Config{})
+	event := OutboxEvent{Topic: "user.created", Payload: user.Name}
+	result := DB.CreateWithOutbox(user, &event)
+	if result.Error != nil || result.RowsAffected != 2 {
+		t.Fatalf("failed to create with outbox, got error %v, rows affected %v", result.Error, result.RowsAffected)
+	}
+
+	if user.ID == 0 || event.ID == 0 {
+		t.Errorf("primary keys should be backfilled, got %v, %v", user.ID, event.ID)
+	}
+
+	var count int64
+	if DB.Model(&OutboxEvent{}).Where("id = ?", event.ID).Count(&count); count != 1 {
+		t.Errorf("should create outbox event, got %v", count)
+	}
+
+	user = GetUser("create_with_outbox_rollback", Config{})
+	if err := DB.CreateWithOutbox(user, &OutboxEvent{ID: event.ID, Topic: "user.created"}).Error; err == nil {
+		t.Fatalf("should fail to create invalid outbox event")
+	}
+
+	if DB.Model(&User{}).Where("name = ?", user.Name).Count(&count); count != 0 {
+		t.Errorf("primary row should be rolled back, got %v", count)
+	}
+}
+
+func TestCreateEmptySliceWithAllowEmptyCreate(t *testing.T) {
+	if err := DB.Create(&[]User{}).Error; !errors.Is(err, gorm.ErrEmptySlice) {
+		t.Errorf("should returns ErrEmptySlice by default, got %v", err)
+	}
+
+	result := DB.Set("gorm:allow_empty_create", true).Create(&[]User{})
+	if result.Error != nil || result.RowsAffected != 0 {
+		t.Errorf("empty slice create should be a no-op, got error %v, rows affected %v", result.Error, result.RowsAffected)
+	}
+
+	result = DB.Set("gorm:allow_empty_create", true).Model(&User{}).Create([]map[string]interface{}{})
+	if result.Error != nil || result.RowsAffected != 0 {
+		t.Errorf("empty slice of map create should be a no-op, got error %v, rows affected %v", result.Error, result.RowsAffected)
+	}
+}
+
+func TestCreateWithDefaultExpr(t *testing.T) {
+	if isMysql() {
+		t.Skipf("This test case skipped, because mysql can't select from the inserting table in VALUES")
+	}
+
+	type OrderedItem struct {
+		ID       uint
+		Name     string
+		Position int `gorm:"defaultExpr:(SELECT COALESCE(MAX(position), 0) + 10 FROM ordered_items)"`
+	}
+
+	DB.Migrator().DropTable(&OrderedItem{})
+	if err := DB.AutoMigrate(&OrderedItem{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	stmt := DB.Session(&gorm.Session{DryRun: true}).Create(&OrderedItem{Name: "dry_run"}).Statement
+	if !strings.Contains(stmt.SQL.String(), "(SELECT COALESCE(MAX(position), 0) + 10 FROM ordered_items)") {
+		t.Fatalf("default expression should be used in VALUES, got %v", stmt.SQL.String())
+	}
+
+	items := []OrderedItem{{Name: "first"}, {Name: "second"}}
+	for idx := range items {
+		if err := DB.Create(&items[idx]).Error; err != nil {
+			t.Fatalf("failed to create, got error %v", err)
+		}
+	}
+
+	explicit := OrderedItem{Name: "explicit", Position: 15}
+	if err := DB.Create(&explicit).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	var results []OrderedItem
+	DB.Order("id").Find(&results)
+	if len(results) != 3 || results[0].Position != 10 || results[1].Position != 20 || results[2].Position != 15 {
+		t.Errorf("positions should be computed by default expression, got %+v", results)
+	}
+
+	if _, ok := stmt.Clauses["RETURNING"]; ok {
+		if items[0].Position != 10 || items[1].Position != 20 {
+			t.Errorf("computed positions should be returned, got %+v", items)
+		}
+	}
+}
+
+func TestCreateWithDerivedField(t *testing.T) {
+	type Article struct {
+		ID    uint
+		Title string
+		Slug  string `gorm:"unique;derivedFrom:title;deriveOnUpdate"`
+		Code  string `gorm:"derivedFrom:Title;deriver:upper_code"`
+	}
+
+	schema.RegisterDeriver("upper_code", func(ctx context.Context, source interface{}) (interface{}, error) {
+		return strings.ToUpper(strings.ReplaceAll(source.(string), " ", "_")), nil
+	})
+
+	DB.Migrator().DropTable(&Article{})
+	if err := DB.AutoMigrate(&Article{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	article := Article{Title: "Hello, GORM World!"}
+	if err := DB.Create(&article).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if article.Slug != "hello-gorm-world" || article.Code != "HELLO,_GORM_WORLD!" {
+		t.Errorf("derived fields should be set, got %+v", article)
+	}
+
+	articles := []Article{{Title: "First Post"}, {Title: "Second Post", Slug: "custom-slug"}}
+	if err := DB.Create(&articles).Error; err != nil {
+		t.Fatalf("failed to create in batch, got error %v", err)
+	}
+
+	if articles[0].Slug != "first-post" || articles[1].Slug != "custom-slug" {
+		t.Errorf("derived fields should only be set when empty, got %+v", articles)
+	}
+
+	if err := DB.Create(&Article{Title: "hello gorm world"}).Error; err == nil {
+		t.Errorf("slug conflicts should be reported by the unique constraint")
+	}
+
+	if err := DB.Model(&articles[0]).Updates(map[string]interface{}{"title": "First Post Updated"}).Error; err != nil {
+		t.Fatalf("failed to update, got error %v", err)
+	}
+
+	var result Article
+	DB.First(&result, articles[0].ID)
+	if result.Slug != "first-post-updated" || result.Code != "FIRST_POST" {
+		t.Errorf("slug should be derived on update, got %+v", result)
+	}
+
+	if _, err := schema.Parse(&struct {
+		ID   uint
+		Slug string `gorm:"derivedFrom:missing"`
+	}{}, &sync.Map{}, schema.NamingStrategy{}); err == nil {
+		t.Errorf("should return error for missing source field")
+	}
+
+	derived, err := schema.Parse(&struct {
+		ID    uint
+		Title string
+		Slug  string `gorm:"derivedFrom:title;deriveOnUpdate:false"`
+	}{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil || derived.LookUpField("slug").DeriveOnUpdate {
+		t.Errorf("deriveOnUpdate:false should not derive on update, got error %v", err)
+	}
+}
+
+func TestCreateWithPartialReturning(t *testing.T) {
+	type PartialReturning struct {
+		ID      uint
+		Name    string
+		Age     int
+		Code    string `gorm:"default:(lower('GENERATED'))"`
+		Comment string
+	}
+
+	DB.Migrator().DropTable(&PartialReturning{})
+	if err := DB.AutoMigrate(&PartialReturning{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	supportReturning := false
+	if stmt := DB.Session(&gorm.Session{DryRun: true}).Create(&PartialReturning{}).Statement; strings.Contains(stmt.SQL.String(), "RETURNING") {
+		supportReturning = true
+	}
+
+	value := PartialReturning{Name: "partial_returning", Age: 18, Comment: "user_comment"}
+	if err := DB.Clauses(clause.Returning{Columns: []clause.Column{{Name: "code"}}}).Create(&value).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if value.Name != "partial_returning" || value.Age != 18 || value.Comment != "user_comment" {
+		t.Errorf("fields not in RETURNING should be untouched, got %+v", value)
+	}
+
+	if supportReturning && value.Code != "generated" {
+		t.Errorf("returned column should be scanned, got %+v", value)
+	}
+
+	values := []PartialReturning{{Name: "partial_returning_1", Age: 1}, {Name: "partial_returning_2", Age: 2, Comment: "user_comment"}}
+	if err := DB.Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "code"}}}).Create(&values).Error; err != nil {
+		t.Fatalf("failed to create in batch, got error %v", err)
+	}
+
+	for idx, v := range values {
+		if v.Name != fmt.Sprintf("partial_returning_%d", idx+1) || v.Age != idx+1 || v.ID == 0 {
+			t.Errorf("fields not in RETURNING should be untouched, got %+v", v)
+		}
+
+		if supportReturning && v.Code != "generated" {
+			t.Errorf("returned column should be scanned, got %+v", v)
+		}
+	}
+
+	if values[0].Comment != "" || values[1].Comment != "user_comment" {
+		t.Errorf("fields not in RETURNING should be untouched, got %+v", values)
+	}
+}
+
+type PathNode struct {
+	ID       uint
+	ParentID *uint
+	Parent   *PathNode
+	Name     string
+	Path     string     `gorm:"defaultExpr:(COALESCE((SELECT p.path FROM path_nodes p WHERE p.id = @parent_id), '') || '/' || @name)"`
+	Children []PathNode `gorm:"foreignKey:ParentID"`
+}
+
+func TestCreateSelfReferentialWithReturningPath(t *testing.T) {
+	if name := DB.Dialector.Name(); name != "sqlite" && name != "postgres" {
+		t.Skip()
+	}
+
+	DB.Migrator().DropTable(&PathNode{})
+	if err := DB.AutoMigrate(&PathNode{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	root := PathNode{Name: "root", Children: []PathNode{{Name: "a", Children: []PathNode{{Name: "a1"}}}, {Name: "b"}}}
+	if err := DB.Create(&root).Error; err != nil {
+		t.Fatalf("failed to create tree, got error %v", err)
+	}
+
+	if root.Path != "/root" || root.Children[0].Path != "/root/a" || root.Children[0].Children[0].Path != "/root/a/a1" || root.Children[1].Path != "/root/b" {
+		t.Errorf("computed paths should be returned, got %+v", root)
+	}
+
+	child := PathNode{Name: "c", ParentID: &root.Children[1].ID}
+	if err := DB.Create(&child).Error; err != nil || child.Path != "/root/b/c" {
+		t.Errorf("child path should be computed from the parent path, got %+v, error %v", child, err)
+	}
+
+	node := PathNode{Name: "d", Parent: &PathNode{Name: "p"}}
+	if err := DB.Create(&node).Error; err != nil || node.Parent.Path != "/p" || node.Path != "/p/d" {
+		t.Errorf("node path should be computed from the created parent, got %+v, error %v", node, err)
+	}
+
+	var result PathNode
+	if err := DB.First(&result, "name = ?", "a1").Error; err != nil || result.Path != "/root/a/a1" {
+		t.Errorf("computed path should be saved, got %+v, error %v", result, err)
+	}
+}
+
+type upperFoldingDialector struct {
+	gorm.Dialector
+}
+
+func (upperFoldingDialector) FoldIdentifier(name string) string {
+	return strings.ToUpper(name)
+}
+
+func TestCreateWithFoldedReturningColumns(t *testing.T) {
+	type FoldedReturning struct {
+		ID        uint
+		Name      string
+		Code      string `gorm:"default:(lower('GENERATED'))"`
+		CreatedBy string `gorm:"default:system"`
+	}
+
+	DB.Migrator().DropTable(&FoldedReturning{})
+	if err := DB.AutoMigrate(&FoldedReturning{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	if stmt := DB.Session(&gorm.Session{DryRun: true}).Create(&FoldedReturning{}).Statement; !strings.Contains(stmt.SQL.String(), "RETURNING") {
+		t.Skip("RETURNING is not supported")
+	}
+
+	// simulate a dialect returning upper-cased column names
+	upperReturning := clause.Returning{Columns: []clause.Column{
+		{Name: "id", Alias: "ID"}, {Name: "code", Alias: "CODE"}, {Name: "created_by", Alias: "CREATED_BY"},
+	}}
+
+	value := FoldedReturning{Name: "folded_returning"}
+	if err := DB.Clauses(upperReturning).Create(&value).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if value.Code != "" {
+		t.Errorf("upper-cased columns should not be scanned without identifier folding, got %+v", value)
+	}
+
+	foldingDB := DB.Session(&gorm.Session{})
+	foldingDB.Dialector = upperFoldingDialector{Dialector: DB.Dialector}
+
+	values := []FoldedReturning{{Name: "folded_returning_1"}, {Name: "folded_returning_2"}}
+	if err := foldingDB.Clauses(upperReturning).Create(&values).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	for _, v := range values {
+		if v.ID == 0 || v.Code != "generated" || v.CreatedBy != "system" {
+			t.Errorf("upper-cased returning columns should be scanned, got %+v", v)
+		}
+	}
+}
+
+func TestCreateWithoutRowIDCompositeKeys(t *testing.T) {
+	if DB.Dialector.Name() != "sqlite" {
+		t.Skip()
+	}
+
+	type CompositeKeyTranslation struct {
+		ID   uint   `gorm:"primaryKey"`
+		Lang string `gorm:"primaryKey"`
+		Text string
+	}
+
+	DB.Migrator().DropTable(&CompositeKeyTranslation{})
+	if err := DB.Exec("CREATE TABLE composite_key_translations (id integer NOT NULL, lang text NOT NULL, text text, PRIMARY KEY (id, lang)) WITHOUT ROWID").Error; err != nil {
+		t.Fatalf("failed to create table, got error %v", err)
+	}
+
+	// back-fill primary keys with LastInsertId instead of RETURNING
+	db, err := OpenTestConnection(&gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	db.Callback().Create().Replace("gorm:create", callbacks.Create(&callbacks.Config{}))
+
+	// bump last_insert_rowid() of the connection
+	db.Create(GetUser("without_rowid", Config{}))
+
+	values := map[string]interface{}{"id": 1, "lang": "en", "text": "hello"}
+	if err := db.Model(&CompositeKeyTranslation{}).Create(values).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if values["id"] != 1 {
+		t.Errorf("primary key should not be changed, got %v", values["id"])
+	}
+
+	translations := []map[string]interface{}{{"id": 2, "lang": "en", "text": "world"}, {"id": 2, "lang": "fr", "text": "monde"}}
+	if err := db.Model(&CompositeKeyTranslation{}).Create(translations).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if translations[0]["id"] != 2 || translations[1]["id"] != 2 {
+		t.Errorf("primary keys should not be changed, got %v", translations)
+	}
+
+	translation := CompositeKeyTranslation{ID: 3, Lang: "en", Text: "gorm"}
+	if err := db.Create(&translation).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if translation.ID != 3 {
+		t.Errorf("primary key should not be changed, got %v", translation.ID)
+	}
+
+	var count int64
+	if DB.Model(&CompositeKeyTranslation{}).Where("id IN ?", []int{1, 2, 3}).Count(&count); count != 4 {
+		t.Errorf("should create 4 translations, got %v", count)
+	}
+}
+
+func TestCreateWithoutRowIDSinglePrimaryKey(t *testing.T) {
+	if DB.Dialector.Name() != "sqlite" {
+		t.Skip()
+	}
+
+	type WithoutRowIDTag struct {
+		ID   uint
+		Name string
+	}
+
+	DB.Migrator().DropTable(&WithoutRowIDTag{})
+	if err := DB.Exec("CREATE TABLE without_row_id_tags (id integer PRIMARY KEY, name text) WITHOUT ROWID").Error; err != nil {
+		t.Fatalf("failed to create table, got error %v", err)
+	}
+
+	// back-fill primary keys with LastInsertId instead of RETURNING, WITHOUT ROWID is detected from sqlite_master
+	db, err := OpenTestConnection(&gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	db.Callback().Create().Replace("gorm:create", callbacks.Create(&callbacks.Config{}))
+
+	// bump last_insert_rowid() of the connection
+	db.Create(GetUser("without_rowid_single", Config{}))
+
+	values := map[string]interface{}{"id": 10, "name": "map"}
+	if err := db.Model(&WithoutRowIDTag{}).Create(values).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if values["id"] != 10 {
+		t.Errorf("primary key should not be changed, got %v", values["id"])
+	}
+
+	tags := []map[string]interface{}{{"id": 11, "name": "batch_1"}, {"id": 12, "name": "batch_2"}}
+	if err := db.Model(&WithoutRowIDTag{}).Create(tags).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if tags[0]["id"] != 11 || tags[1]["id"] != 12 {
+		t.Errorf("primary keys should not be changed, got %v", tags)
+	}
+
+	var count int64
+	if DB.Model(&WithoutRowIDTag{}).Where("id IN ?", []int{10, 11, 12}).Count(&count); count != 3 {
+		t.Errorf("should create 3 tags, got %v", count)
+	}
+}
+
+func TestCreateWithPrecondition(t *testing.T) {
+	type InsertQuota struct {
+		ID        uint
+		Remaining int
+	}
+
+	DB.Migrator().DropTable(&InsertQuota{})
+	if err := DB.AutoMigrate(&InsertQuota{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	quota := InsertQuota{Remaining: 1}
+	DB.Create(&quota)
+
+	hasQuota := DB.Model(&InsertQuota{}).Select("1").Where("id = ? AND remaining > ?", quota.ID, 0)
+	user := GetUser("precondition_1", Config{})
+	result := DB.Set("gorm:insert_precondition", hasQuota).Create(user)
+	if result.Error != nil || result.RowsAffected != 1 || user.ID == 0 {
+		t.Fatalf("should create when precondition holds, rows affected %v, got error %v", result.RowsAffected, result.Error)
+	}
+
+	DB.Model(&quota).Update("remaining", 0)
+
+	user2 := GetUser("precondition_2", Config{})
+	result = DB.Set("gorm:insert_precondition", hasQuota).Create(user2)
+	if result.Error != nil || result.RowsAffected != 0 || user2.ID != 0 {
+		t.Errorf("should not create when precondition fails, rows affected %v, id %v, got error %v", result.RowsAffected, user2.ID, result.Error)
+	}
+
+	users := []*User{GetUser("precondition_3", Config{}), GetUser("precondition_4", Config{})}
+	result = DB.Set("gorm:insert_precondition", gorm.Expr("? > ?", 2, 1)).Create(&users)
+	if result.Error != nil || result.RowsAffected != 2 {
+		t.Errorf("should create batch when precondition holds, rows affected %v, got error %v", result.RowsAffected, result.Error)
+	}
+
+	var count int64
+	if DB.Model(&User{}).Where("name LIKE ?", "precondition_%").Count(&count); count != 3 {
+		t.Errorf("should create 3 users, got %v", count)
+	}
+
+	// parameters selected with UNION ALL are resolved as text on postgres, batches are casted to the column types
+	db, err := OpenTestConnection(&gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	db.Dialector = postgresNamedDialector{Dialector: db.Dialector}
+
+	batch := []InsertQuota{{Remaining: 1}, {Remaining: 2}}
+	stmt := db.Session(&gorm.Session{DryRun: true}).Set("gorm:insert_precondition", gorm.Expr("? > ?", 2, 1)).Create(&batch).Statement
+	if query, dataType := stmt.SQL.String(), DB.Dialector.DataTypeOf(stmt.Schema.LookUpField("Remaining")); strings.Count(query, "?::"+dataType) != 2 {
+		t.Errorf("batch values should be casted for postgres, got %v", query)
+	}
+
+	stmt = db.Session(&gorm.Session{DryRun: true}).Set("gorm:insert_precondition", gorm.Expr("? > ?", 2, 1)).Create(&InsertQuota{Remaining: 3}).Statement
+	if query := stmt.SQL.String(); strings.Contains(query, "::") {
+		t.Errorf("single row should not be casted, got %v", query)
+	}
+
+	db.Dialector = typeCastDialector{Dialector: DB.Dialector}
+	stmt = db.Session(&gorm.Session{DryRun: true}).Set("gorm:insert_precondition", gorm.Expr("? > ?", 2, 1)).Create(&batch).Statement
+	if query, dataType := stmt.SQL.String(), DB.Dialector.DataTypeOf(stmt.Schema.LookUpField("Remaining")); strings.Count(query, "CAST(? AS "+dataType+")") != 2 {
+		t.Errorf("batch values should be casted for dialectors casting types, got %v", query)
+	}
+}
+
+func TestCreateWithReturningRelations(t *testing.T) {
+	if DB.Dialector.Name() != "sqlite" && DB.Dialector.Name() != "postgres" {
+		t.Skip()
+	}
+
+	company := Company{Name: "returning_relations_company"}
+	DB.Create(&company)
+	manager := GetUser("returning_relations_manager", Config{})
+	DB.Create(manager)
+
+	user := GetUser("returning_relations", Config{})
+	user.CompanyID = &company.ID
+	user.ManagerID = &manager.ID
+	if err := DB.Set("gorm:returning_relations", []string{"Company", "Manager.Name"}).Create(user).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if user.ID == 0 || user.Company.ID != company.ID || user.Company.Name != company.Name {
+		t.Errorf("should populate belongs to relation from RETURNING, got %+v", user.Company)
+	}
+
+	if user.Manager == nil || user.Manager.Name != manager.Name || user.Manager.ID != 0 {
+		t.Errorf("should populate specified field of self referencing relation, got %+v", user.Manager)
+	}
+
+	users := []*User{GetUser("returning_relations_1", Config{}), GetUser("returning_relations_2", Config{})}
+	users[0].CompanyID = &company.ID
+	if err := DB.Set("gorm:returning_relations", "Company.Name").Create(&users).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if users[0].Company.Name != company.Name || users[1].Company.Name != "" {
+		t.Errorf("should populate relation of each created record, got %+v, %+v", users[0].Company, users[1].Company)
+	}
+
+	for _, relations := range [][]string{{"Pets"}, {"Company.Unknown"}, {"Company", "Company.Name"}} {
+		if err := DB.Set("gorm:returning_relations", relations).Create(GetUser("returning_relations_3", Config{})).Error; err == nil {
+			t.Errorf("should return error for returning relations %v", relations)
+		}
+	}
+}
+
+type SealedNote struct {
+	ID     uint
+	Title  string
+	Secret string
+}
+
+var _ callbacks.ValueTransformer = SealedNote{}
+
+func (SealedNote) TransformCreateValue(ctx context.Context, field *schema.Field, value interface{}) (interface{}, error) {
+	if field.Name != "Secret" {
+		return value, nil
+	}
+
+	secret, _ := value.(string)
+	if secret == "invalid" {
+		return nil, errors.New("invalid secret")
+	}
+	return "sealed:" + secret, nil
+}
+
+func TestCreateWithValueTransformer(t *testing.T) {
+	DB.Migrator().DropTable(&SealedNote{})
+	if err := DB.AutoMigrate(&SealedNote{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	note := SealedNote{Title: "note", Secret: "s1"}
+	notes := []*SealedNote{{Title: "note2", Secret: "s2"}, {Title: "note3", Secret: "s3"}}
+	if err := DB.Create(&note).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+	if err := DB.Create(&notes).Error; err != nil {
+		t.Fatalf("failed to create in batch, got error %v", err)
+	}
+
+	var secrets []string
+	DB.Model(&SealedNote{}).Order("id").Pluck("secret", &secrets)
+	if strings.Join(secrets, ",") != "sealed:s1,sealed:s2,sealed:s3" {
+		t.Errorf("secrets should be transformed, got %v", secrets)
+	}
+
+	if note.Secret != "s1" || notes[1].Secret != "s3" {
+		t.Errorf("struct fields should not be changed, got %v, %v", note.Secret, notes[1].Secret)
+	}
+
+	if err := DB.Create(&SealedNote{Title: "note4", Secret: "invalid"}).Error; err == nil || err.Error() != "invalid secret" {
+		t.Errorf("should return error of transformer, got %v", err)
+	}
+}
+
+type lastInsertIDConnPool struct {
+	lastInsertID, rowsAffected int64
+}
+
+func (pool lastInsertIDConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, gorm.ErrNotImplemented
+}
+
+func (pool lastInsertIDConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return pool, nil
+}
+
+func (pool lastInsertIDConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, gorm.ErrNotImplemented
+}
+
+func (pool lastInsertIDConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
+func (pool lastInsertIDConnPool) LastInsertId() (int64, error) { return pool.lastInsertID, nil }
+
+func (pool lastInsertIDConnPool) RowsAffected() (int64, error) { return pool.rowsAffected, nil }
+
+func TestCreateBackfillCompositePrimaryKey(t *testing.T) {
+	type TenantSequence struct {
+		ID   uint `gorm:"primaryKey"`
+		Seq  uint `gorm:"primaryKey;autoIncrement"`
+		Name string
+	}
+
+	db, _ := gorm.Open(DummyDialector{}, &gorm.Config{ConnPool: lastInsertIDConnPool{lastInsertID: 10, rowsAffected: 1}, SkipDefaultTransaction: true})
+	db = db.Set("gorm:skip_returning", true)
+
+	row := TenantSequence{ID: 7, Name: "composite"}
+	if err := db.Create(&row).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if row.ID != 7 || row.Seq != 10 {
+		t.Errorf("should only back-fill the auto-increment member, got %+v", row)
+	}
+
+	// DummyDialector returns the last insert id of the last row
+	db, _ = gorm.Open(DummyDialector{}, &gorm.Config{ConnPool: lastInsertIDConnPool{lastInsertID: 12, rowsAffected: 2}, SkipDefaultTransaction: true})
+	db = db.Set("gorm:skip_returning", true)
+	rows := []TenantSequence{{ID: 7, Name: "composite_1"}, {ID: 8, Name: "composite_2"}}
+	if err := db.Create(&rows).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if rows[0].ID != 7 || rows[0].Seq != 11 || rows[1].ID != 8 || rows[1].Seq != 12 {
+		t.Errorf("should only back-fill the auto-increment member, got %+v", rows)
+	}
+}
+
+type ReturningVoucher struct {
+	Code string `gorm:"primaryKey"`
+	Note string
+}
+
+func TestCreateWithExplicitReturning(t *testing.T) {
+	if DB.Dialector.Name() != "sqlite" && DB.Dialector.Name() != "postgres" {
+		return
+	}
+
+	DB.Migrator().DropTable(&ReturningVoucher{})
+	if err := DB.Exec("CREATE TABLE returning_vouchers (code varchar(100) PRIMARY KEY, note varchar(100) DEFAULT 'fresh')").Error; err != nil {
+		t.Fatalf("failed to create table, got error %v", err)
+	}
+
+	// the model has no fields with default db value, RETURNING is only added explicitly
+	voucher := ReturningVoucher{Code: "v1"}
+	if err := DB.Omit("note").Clauses(clause.Returning{Columns: []clause.Column{{Name: "note"}}}).Create(&voucher).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+	if voucher.Note != "fresh" {
+		t.Errorf("should scan returning columns, got %+v", voucher)
+	}
+
+	vouchers := []ReturningVoucher{{Code: "v2"}, {Code: "v3"}}
+	if err := DB.Omit("note").Clauses(clause.Returning{}).Create(&vouchers).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+	for _, v := range vouchers {
+		if v.Note != "fresh" {
+			t.Errorf("should scan returning columns, got %+v", v)
+		}
+	}
+
+	values := map[string]interface{}{"code": "v4"}
+	if err := DB.Model(&ReturningVoucher{}).Clauses(clause.Returning{Columns: []clause.Column{{Name: "note"}}}).Create(values).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+	if values["note"] != "fresh" {
+		t.Errorf("should scan returning columns into map, got %+v", values)
+	}
+
+	// explicit RETURNING is ignored when the create clauses don't include it, like dialects without RETURNING support
+	db, err := OpenTestConnection(&gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	db.Callback().Create().Replace("gorm:create", callbacks.Create(&callbacks.Config{}))
+
+	voucher = ReturningVoucher{Code: "v5"}
+	if err := db.Clauses(clause.Returning{}).Create(&voucher).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	var count int64
+	DB.Model(&ReturningVoucher{}).Where("code = ?", "v5").Count(&count)
+	if count != 1 || voucher.Note != "" {
+		t.Errorf("should insert without RETURNING, got count %v, voucher %+v", count, voucher)
+	}
+}
+
+type noReturningDialector struct {
+	gorm.Dialector
+}
+
+func (noReturningDialector) SupportReturning() bool {
+	return false
+}
+
+func TestCreateWithDialectorWithoutReturning(t *testing.T) {
+	if name := DB.Dialector.Name(); name == "postgres" || name == "gaussdb" || name == "sqlserver" {
+		t.Skipf("This test case skipped, because the db doesn't support LastInsertId")
+	}
+
+	db, err := OpenTestConnection(&gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	db.Dialector = noReturningDialector{Dialector: db.Dialector}
+
+	user := GetUser("dialector_without_returning", Config{})
+	stmt := db.Session(&gorm.Session{DryRun: true}).Clauses(clause.Returning{}).Create(user).Statement
+	if strings.Contains(stmt.SQL.String(), "RETURNING") {
+		t.Errorf("should not build RETURNING when the dialector does not support it, got %v", stmt.SQL.String())
+	}
+
+	if err := db.Clauses(clause.Returning{}).Create(user).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if user.ID == 0 {
+		t.Errorf("should back-fill primary key with LastInsertId")
+	}
+
+	var result User
+	if err := DB.First(&result, user.ID).Error; err != nil || result.Name != user.Name {
+		t.Errorf("failed to find created user, got error %v, result %+v", err, result)
+	}
+}
+
+type OrderedTicket struct {
+	ID   uint
+	Name string
+}
+
+// reversedReturningConnPool returns the RETURNING rows of the inserts in the reversed order
+type reversedReturningConnPool struct {
+	gorm.ConnPool
+}
+
+func (pool reversedReturningConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if !strings.HasPrefix(query, "INSERT") {
+		return pool.ConnPool.QueryContext(ctx, query, args...)
+	}
+
+	rows, err := pool.ConnPool.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []interface{}
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	stmt := DB.Session(&gorm.Session{DryRun: true}).Raw("SELECT id FROM ordered_tickets WHERE id IN ? ORDER BY id DESC", ids).Statement
+	return pool.ConnPool.QueryContext(ctx, stmt.SQL.String(), stmt.Vars...)
+}
+
+func TestCreateWithOrderedReturning(t *testing.T) {
+	if _, ok := DB.Session(&gorm.Session{DryRun: true}).Create(&OrderedTicket{}).Statement.Clauses["RETURNING"]; !ok {
+		t.Skipf("This test case skipped, because the db doesn't support returning")
+	}
+
+	DB.Migrator().DropTable(&OrderedTicket{})
+	DB.AutoMigrate(&OrderedTicket{})
+
+	db, err := OpenTestConnection(&gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	db.ConnPool = reversedReturningConnPool{ConnPool: db.ConnPool}
+	db.Statement.ConnPool = db.ConnPool
+
+	tickets := []OrderedTicket{{Name: "ticket_1"}, {Name: "ticket_2"}, {Name: "ticket_3"}}
+	if err := db.Set("gorm:ordered_returning", true).Create(&tickets).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	for idx, ticket := range tickets {
+		var result OrderedTicket
+		if err := DB.First(&result, ticket.ID).Error; err != nil || result.Name != ticket.Name {
+			t.Errorf("#%d returned id should be aligned with the inserting row, got %+v, expects %+v", idx, result, ticket)
+		}
+		if idx > 0 && ticket.ID <= tickets[idx-1].ID {
+			t.Errorf("ids should increase with the inserting rows, got %+v", tickets)
+		}
+	}
+
+	unordered := []OrderedTicket{{Name: "ticket_4"}, {Name: "ticket_5"}}
+	if err := db.Create(&unordered).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+	if unordered[0].ID < unordered[1].ID {
+		t.Errorf("should use the driver row order without gorm:ordered_returning, got %+v", unordered)
+	}
+}
+
+func TestCreateWithDefaultValueFunc(t *testing.T) {
+	type Invoice struct {
+		ID     string `gorm:"primaryKey;defaultFunc:uuid"`
+		Number string `gorm:"defaultFunc:invoice_number"`
+		Status string `gorm:"default:draft;defaultFunc:invoice_number"`
+	}
+
+	var sequence int
+	schema.RegisterDefaultValueFunc("invoice_number", func(ctx context.Context) interface{} {
+		sequence++
+		return fmt.Sprintf("INV-%03d", sequence)
+	})
+
+	DB.Migrator().DropTable(&Invoice{})
+	if err := DB.AutoMigrate(&Invoice{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	invoice := Invoice{}
+	if err := DB.Create(&invoice).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if len(invoice.ID) != 36 || invoice.Number != "INV-001" || invoice.Status != "draft" {
+		t.Errorf("default value func should be written back, got %+v", invoice)
+	}
+
+	invoices := []Invoice{{}, {Number: "custom"}, {}}
+	if err := DB.Create(&invoices).Error; err != nil {
+		t.Fatalf("failed to create in batch, got error %v", err)
+	}
+
+	if invoices[0].Number != "INV-002" || invoices[1].Number != "custom" || invoices[2].Number != "INV-003" {
+		t.Errorf("default value func should be called for each zero row, got %+v", invoices)
+	}
+
+	if invoices[0].ID == "" || invoices[0].ID == invoices[2].ID {
+		t.Errorf("uuid should be generated for each row, got %+v", invoices)
+	}
+
+	var result Invoice
+	if err := DB.First(&result, "id = ?", invoices[2].ID).Error; err != nil || result.Number != "INV-003" {
+		t.Errorf("generated values should be inserted, got %+v, error %v", result, err)
+	}
+}
+
+func TestCreateWithSelectedAutoTimestamps(t *testing.T) {
+	columnsOf := func(tx *gorm.DB) string {
+		stmt := tx.Session(&gorm.Session{DryRun: true}).Create(&User{Name: "auto_timestamps", Age: 18}).Statement
+		values, _ := stmt.Clauses["VALUES"].Expression.(clause.Values)
+		names := make([]string, 0, len(values.Columns))
+		for _, column := range values.Columns {
+			names = append(names, column.Name)
+		}
+		return strings.Join(names, ",")
+	}
+
+	results := []struct {
+		Name    string
+		DB      *gorm.DB
+		Columns string
+	}{
+		{Name: "select_name", DB: DB.Select("Name"), Columns: "name"},
+		{Name: "select_created_at", DB: DB.Select("CreatedAt"), Columns: "created_at"},
+		{Name: "select_name_updated_at", DB: DB.Select("Name", "UpdatedAt"), Columns: "updated_at,name"},
+		{Name: "omit_created_at", DB: DB.Omit("CreatedAt"), Columns: "updated_at,deleted_at,name,age,birthday,company_id,manager_id,active"},
+		{Name: "select_all_omit_updated_at", DB: DB.Select("*").Omit("UpdatedAt"), Columns: "created_at,deleted_at,name,age,birthday,company_id,manager_id,active"},
+		{Name: "select_name_omit_created_at", DB: DB.Select("Name", "CreatedAt").Omit("CreatedAt"), Columns: "name"},
+	}
+
+	for _, result := range results {
+		t.Run(result.Name, func(t *testing.T) {
+			if columns := columnsOf(result.DB); columns != result.Columns {
+				t.Errorf("columns should be %v, got %v", result.Columns, columns)
+			}
+		})
+	}
+
+	user := User{Name: "selected_created_at", Age: 18}
+	if err := DB.Select("Name", "CreatedAt").Create(&user).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	var result User
+	DB.First(&result, user.ID)
+	if result.CreatedAt.IsZero() || !result.UpdatedAt.IsZero() || result.Age != 0 {
+		t.Errorf("only selected columns should be inserted, got %+v", result)
+	}
+}
+
+type postgresNamedDialector struct {
+	gorm.Dialector
+}
+
+func (postgresNamedDialector) Name() string {
+	return "postgres"
+}
+
+// typeCastDialector dialector casts untyped parameters without a known dialect name
+type typeCastDialector struct {
+	gorm.Dialector
+}
+
+func (typeCastDialector) CastTo(sql, dataType string) string {
+	return "CAST(" + sql + " AS " + dataType + ")"
+}
+
+func TestCreateWithNullValuesCast(t *testing.T) {
+	type Settlement struct {
+		ID       uint
+		Name     string
+		Amount   *float64 `gorm:"type:numeric(10,2)"`
+		SettleAt sql.NullTime
+	}
+
+	DB.Migrator().DropTable(&Settlement{})
+	if err := DB.AutoMigrate(&Settlement{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	amount := 10.5
+	settlements := []Settlement{{Name: "settlement_1"}, {Name: "settlement_2"}}
+	tx := DB.Set("gorm:cast_null_values", true)
+
+	stmt := tx.Session(&gorm.Session{DryRun: true}).Create(&settlements).Statement
+	if query := stmt.SQL.String(); strings.Count(query, "CAST(NULL AS numeric(10,2))") != 2 || strings.Count(query, "CAST(NULL AS "+DB.Dialector.DataTypeOf(stmt.Schema.LookUpField("SettleAt"))+")") != 2 {
+		t.Errorf("all NULL columns should be casted, got %v", query)
+	}
+
+	if err := tx.Create(&settlements).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	var result Settlement
+	if err := DB.First(&result, settlements[1].ID).Error; err != nil || result.Amount != nil || result.SettleAt.Valid {
+		t.Errorf("NULL values should be inserted, got %+v, error %v", result, err)
+	}
+
+	// columns having any non-NULL value are not casted
+	mixed := []Settlement{{Name: "settlement_3", Amount: &amount}, {Name: "settlement_4"}}
+	stmt = tx.Session(&gorm.Session{DryRun: true}).Create(&mixed).Statement
+	if query := stmt.SQL.String(); strings.Contains(query, "numeric(10,2)") {
+		t.Errorf("columns having values should not be casted, got %v", query)
+	}
+
+	// enabled for bulk inserts of postgres by default
+	db, err := OpenTestConnection(&gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	db.Dialector = postgresNamedDialector{Dialector: db.Dialector}
+
+	stmt = db.Session(&gorm.Session{DryRun: true}).Create(&[]Settlement{{Name: "settlement_5"}, {Name: "settlement_6"}}).Statement
+	if query := stmt.SQL.String(); strings.Count(query, "NULL::numeric(10,2)") != 2 {
+		t.Errorf("all NULL columns should be casted for postgres, got %v", query)
+	}
+
+	stmt = db.Session(&gorm.Session{DryRun: true}).Create(&Settlement{Name: "settlement_7"}).Statement
+	if query := stmt.SQL.String(); strings.Contains(query, "NULL::") {
+		t.Errorf("single row should not be casted by default, got %v", query)
+	}
+
+	// enabled for bulk inserts of dialectors casting types by default, with their cast syntax
+	db.Dialector = typeCastDialector{Dialector: DB.Dialector}
+	stmt = db.Session(&gorm.Session{DryRun: true}).Create(&[]Settlement{{Name: "settlement_8"}, {Name: "settlement_9"}}).Statement
+	if query := stmt.SQL.String(); strings.Count(query, "CAST(NULL AS numeric(10,2))") != 2 {
+		t.Errorf("all NULL columns should be casted for dialectors casting types, got %v", query)
+	}
+}
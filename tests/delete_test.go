@@ -129,6 +129,42 @@ func TestBlockGlobalDelete(t *testing.T) {
 	}
 }
 
+func TestDeleteInBatches(t *testing.T) {
+	users := make([]User, 25)
+	for i := range users {
+		users[i] = *GetUser("delete_in_batches", Config{})
+	}
+	DB.Create(&users)
+	DB.Create(GetUser("delete_in_batches_kept", Config{}))
+
+	if err := DB.DeleteInBatches(&User{}, 10).Error; !errors.Is(err, gorm.ErrMissingWhereClause) {
+		t.Errorf("should returns missing WHERE clause error, got %v", err)
+	}
+
+	result := DB.Where("name = ?", "delete_in_batches").DeleteInBatches(&User{}, 10)
+	if result.Error != nil || result.RowsAffected != 25 {
+		t.Fatalf("failed to delete in batches, rows affected %v, error %v", result.RowsAffected, result.Error)
+	}
+
+	var count int64
+	if DB.Model(&User{}).Where("name = ?", "delete_in_batches").Count(&count); count != 0 {
+		t.Errorf("all matched users should be deleted, but got %v", count)
+	}
+
+	if DB.Model(&User{}).Where("name = ?", "delete_in_batches_kept").Count(&count); count != 1 {
+		t.Errorf("unmatched users should be kept, but got %v", count)
+	}
+
+	result = DB.Unscoped().DeleteInBatches(&User{}, 20, "name = ?", "delete_in_batches")
+	if result.Error != nil || result.RowsAffected != 25 {
+		t.Fatalf("failed to delete in batches permanently, rows affected %v, error %v", result.RowsAffected, result.Error)
+	}
+
+	if DB.Unscoped().Model(&User{}).Where("name = ?", "delete_in_batches").Count(&count); count != 0 {
+		t.Errorf("all matched users should be deleted permanently, but got %v", count)
+	}
+}
+
 func TestDeleteWithAssociations(t *testing.T) {
 	user := GetUser("delete_with_associations", Config{Account: true, Pets: 2, Toys: 4, Company: true, Manager: true, Team: 1, Languages: 1, Friends: 1})
 
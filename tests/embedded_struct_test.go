@@ -282,3 +282,86 @@ func TestEmbeddedTagSetting(t *testing.T) {
 		t.Errorf("embedded struct's primary field should be rewritten")
 	}
 }
+
+func TestCreateEmbeddedStructWithPrefix(t *testing.T) {
+	type Geo struct {
+		Lat  float64
+		Lng  float64
+		Note string `gorm:"default:unknown"`
+	}
+
+	type Address struct {
+		City      string
+		Zip       string
+		Geo       Geo `gorm:"embedded;embeddedPrefix:geo_"`
+		CreatedAt time.Time
+		UpdatedAt int64 `gorm:"autoUpdateTime"`
+	}
+
+	type PrefixedCustomer struct {
+		ID       uint
+		Name     string
+		Home     Address  `gorm:"embedded;embeddedPrefix:home_"`
+		Office   *Address `gorm:"embedded;embeddedPrefix:office_"`
+		Shipping Address  `gorm:"embedded"`
+	}
+
+	DB.Migrator().DropTable(&PrefixedCustomer{})
+	if err := DB.AutoMigrate(&PrefixedCustomer{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	for _, column := range []string{"home_city", "home_zip", "home_geo_lat", "home_geo_note", "home_created_at", "home_updated_at", "office_geo_lng", "city", "geo_lat"} {
+		if !DB.Migrator().HasColumn(&PrefixedCustomer{}, column) {
+			t.Errorf("column %v should exist", column)
+		}
+	}
+
+	customer := PrefixedCustomer{
+		Name:   "embedded_prefix",
+		Home:   Address{City: "Hangzhou", Zip: "310000", Geo: Geo{Lat: 30.27, Lng: 120.15}},
+		Office: &Address{City: "Shanghai", Geo: Geo{Lat: 31.23, Note: "office"}},
+	}
+	if err := DB.Create(&customer).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if customer.Home.CreatedAt.IsZero() || customer.Home.UpdatedAt == 0 || customer.Office.CreatedAt.IsZero() {
+		t.Errorf("auto time fields inside embedded struct should be set, got %+v, %+v", customer.Home, customer.Office)
+	}
+
+	customers := []PrefixedCustomer{
+		{Name: "embedded_prefix_1", Home: Address{City: "Beijing", Geo: Geo{Lng: 116.4}}, Shipping: Address{Zip: "100000"}},
+		{Name: "embedded_prefix_2", Office: &Address{Zip: "518000", Geo: Geo{Note: "shenzhen"}}},
+	}
+	if err := DB.Create(&customers).Error; err != nil {
+		t.Fatalf("failed to create in batch, got error %v", err)
+	}
+
+	var row struct {
+		HomeCity    string
+		HomeZip     string
+		HomeGeoLat  float64
+		HomeGeoNote string
+		OfficeCity  string
+		OfficeNote  string
+	}
+	DB.Model(&PrefixedCustomer{}).Select("home_city, home_zip, home_geo_lat, home_geo_note, office_city, office_geo_note AS office_note").Where("id = ?", customer.ID).Scan(&row)
+	if row.HomeCity != "Hangzhou" || row.HomeZip != "310000" || row.HomeGeoLat != 30.27 || row.HomeGeoNote != "unknown" || row.OfficeCity != "Shanghai" || row.OfficeNote != "office" {
+		t.Errorf("prefixed columns are not inserted correctly, got %+v", row)
+	}
+
+	var results []PrefixedCustomer
+	DB.Where("id IN ?", []uint{customers[0].ID, customers[1].ID}).Order("id").Find(&results)
+	if len(results) != 2 {
+		t.Fatalf("should find 2 customers, got %v", len(results))
+	}
+
+	if results[0].Home.City != "Beijing" || results[0].Home.Geo.Lng != 116.4 || results[0].Shipping.Zip != "100000" || results[0].Home.CreatedAt.IsZero() {
+		t.Errorf("prefixed columns are not inserted correctly in batch, got %+v", results[0])
+	}
+
+	if results[1].Office == nil || results[1].Office.Zip != "518000" || results[1].Office.Geo.Note != "shenzhen" || results[1].Home.Geo.Note != "unknown" {
+		t.Errorf("prefixed columns are not inserted correctly in batch, got %+v", results[1])
+	}
+}
@@ -1,10 +1,17 @@
 package tests_test
 
 import (
+	"context"
+	"database/sql/driver"
 	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
 	"testing"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 	"gorm.io/gorm/utils/tests"
 )
 
@@ -109,3 +116,261 @@ func TestSupportedDialectorWithErrForeignKeyViolated(t *testing.T) {
 		t.Fatalf("expected err: %v got err: %v", gorm.ErrForeignKeyViolated, err)
 	}
 }
+
+func TestErrorWithSQL(t *testing.T) {
+	if err := DB.Exec("SELECT * FROM error_with_sql_missing WHERE name = ?", "secret").Error; err == nil {
+		t.Fatalf("should fail to query missing table")
+	} else if strings.Contains(err.Error(), "SELECT * FROM error_with_sql_missing") {
+		t.Errorf("should not contain SQL in error by default, got %v", err)
+	}
+
+	db, err := OpenTestConnection(&gorm.Config{ErrorWithSQL: true})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	err = db.Exec("SELECT * FROM error_with_sql_missing WHERE name = ?", "secret").Error
+	if err == nil || !strings.Contains(err.Error(), "SELECT * FROM error_with_sql_missing") || !strings.Contains(err.Error(), "secret") {
+		t.Errorf("error should contain the explained SQL, got %v", err)
+	}
+
+	var user tests.User
+	if err := db.First(&user, "name = ?", "error_with_sql_not_found").Error; err == nil || err.Error() != gorm.ErrRecordNotFound.Error() {
+		t.Errorf("record not found error should not be wrapped, got %v", err)
+	}
+
+	db.Logger = logger.New(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{ParameterizedQueries: true, LogLevel: logger.Silent})
+	err = db.Exec("SELECT * FROM error_with_sql_missing WHERE name = ?", "secret").Error
+	if err == nil || !strings.Contains(err.Error(), "SELECT * FROM error_with_sql_missing") || strings.Contains(err.Error(), "secret") {
+		t.Errorf("error should contain the SQL with filtered params, got %v", err)
+	}
+}
+
+type sqlStateError string
+
+func (err sqlStateError) Error() string    { return "sql state " + string(err) }
+func (err sqlStateError) SQLState() string { return string(err) }
+
+type mysqlCodeError uint16
+
+func (err mysqlCodeError) Error() string { return "mysql error" }
+
+type classifyingDialector struct {
+	tests.DummyDialector
+}
+
+func (classifyingDialector) ClassifyError(err error) gorm.ErrorClass {
+	var codeErr mysqlCodeError
+	if errors.As(err, &codeErr) {
+		return gorm.ClassifyErrorCode("mysql", int(codeErr))
+	}
+	return gorm.ErrorClassPermanent
+}
+
+func TestClassifyError(t *testing.T) {
+	db, _ := gorm.Open(tests.DummyDialector{})
+	results := map[error]gorm.ErrorClass{
+		driver.ErrBadConn:      gorm.ErrorClassTransient,
+		sqlStateError("40001"): gorm.ErrorClassSerializationFailure,
+		fmt.Errorf("wrapped: %w", sqlStateError("40P01")): gorm.ErrorClassDeadlock,
+		sqlStateError("08006"):                            gorm.ErrorClassTransient,
+		sqlStateError("23505"):                            gorm.ErrorClassPermanent,
+		context.Canceled:                                  gorm.ErrorClassPermanent,
+		errors.New("some random error"):                   gorm.ErrorClassPermanent,
+		mysqlCodeError(1213):                              gorm.ErrorClassPermanent,
+	}
+
+	for err, class := range results {
+		if got := db.ClassifyError(err); got != class {
+			t.Errorf("error %v should be classified as %v, got %v", err, class, got)
+		}
+	}
+
+	if db.ClassifyError(sqlStateError("23505")).Retryable() || !db.ClassifyError(driver.ErrBadConn).Retryable() {
+		t.Errorf("only transient errors should be retryable")
+	}
+
+	db, _ = gorm.Open(classifyingDialector{})
+	results = map[error]gorm.ErrorClass{
+		mysqlCodeError(1213):   gorm.ErrorClassDeadlock,
+		mysqlCodeError(1205):   gorm.ErrorClassTransient,
+		mysqlCodeError(1062):   gorm.ErrorClassPermanent,
+		sqlStateError("40001"): gorm.ErrorClassPermanent,
+	}
+
+	for err, class := range results {
+		if got := db.ClassifyError(err); got != class {
+			t.Errorf("error %v should be classified by dialector as %v, got %v", err, class, got)
+		}
+	}
+}
+
+// checkConstraintTranslator translate the sqlite check constraint violations into *gorm.ConstraintError
+type checkConstraintTranslator struct {
+	gorm.Dialector
+}
+
+func (checkConstraintTranslator) Translate(err error) error {
+	if _, name, ok := strings.Cut(err.Error(), "CHECK constraint failed: "); ok {
+		return &gorm.ConstraintError{Constraint: name, Err: gorm.ErrCheckConstraintViolated}
+	}
+	return err
+}
+
+func TestConstraintHandlers(t *testing.T) {
+	type Pricing struct {
+		ID     uint
+		Amount int `gorm:"check:chk_pricings_amount,amount >= 0"`
+	}
+
+	if DB.Dialector.Name() != "sqlite" {
+		t.Skip()
+	}
+
+	DB.Migrator().DropTable(&Pricing{})
+	if err := DB.AutoMigrate(&Pricing{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	db, err := OpenTestConnection(&gorm.Config{TranslateError: true})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	db.Dialector = checkConstraintTranslator{Dialector: db.Dialector}
+
+	err = db.Create(&Pricing{Amount: -1}).Error
+	var constraintErr *gorm.ConstraintError
+	if !errors.As(err, &constraintErr) || constraintErr.Constraint != "chk_pricings_amount" || !errors.Is(err, gorm.ErrCheckConstraintViolated) {
+		t.Fatalf("should return constraint error without handler, got %v", err)
+	}
+
+	// map the violation to a domain error
+	errNegativeAmount := errors.New("amount should not be negative")
+	db.ConstraintHandlers["chk_pricings_amount"] = func(stmt *gorm.Statement, err *gorm.ConstraintError) error {
+		return errNegativeAmount
+	}
+
+	if err := db.Create(&Pricing{Amount: -1}).Error; !errors.Is(err, errNegativeAmount) {
+		t.Errorf("should return the error of the handler, got %v", err)
+	}
+
+	// recover by retrying with a different value
+	db.ConstraintHandlers["chk_pricings_amount"] = func(stmt *gorm.Statement, err *gorm.ConstraintError) error {
+		if pricing, ok := stmt.Dest.(*Pricing); ok {
+			pricing.Amount = 0
+			return stmt.DB.Session(&gorm.Session{NewDB: true}).Create(pricing).Error
+		}
+		return err
+	}
+
+	pricing := Pricing{Amount: -1}
+	if err := db.Create(&pricing).Error; err != nil {
+		t.Fatalf("should recover the violation by handler, got %v", err)
+	}
+
+	var result Pricing
+	if err := DB.First(&result, pricing.ID).Error; err != nil || result.Amount != 0 {
+		t.Errorf("should create with the retried value, got %+v, error %v", result, err)
+	}
+
+	// the remaining callbacks of the recovered statement are stopped
+	var handledErrs []error
+	db.Callback().Create().After("gorm:create").Register("test:after_constraint_handled", func(tx *gorm.DB) {
+		handledErrs = append(handledErrs, tx.Error)
+	})
+
+	pricing = Pricing{Amount: -1}
+	if err := db.Create(&pricing).Error; err != nil {
+		t.Fatalf("should recover the violation by handler, got %v", err)
+	}
+	if len(handledErrs) != 2 || handledErrs[0] != nil || handledErrs[1] != gorm.ErrConstraintHandled {
+		t.Errorf("callbacks after the recovered violation should see ErrConstraintHandled, got %v", handledErrs)
+	}
+
+	// violations of the retry are not handled again
+	var calls int
+	db.ConstraintHandlers["chk_pricings_amount"] = func(stmt *gorm.Statement, err *gorm.ConstraintError) error {
+		calls++
+		return stmt.DB.Session(&gorm.Session{NewDB: true}).Create(&Pricing{Amount: -2}).Error
+	}
+
+	err = db.Create(&Pricing{Amount: -1}).Error
+	if !errors.As(err, &constraintErr) || constraintErr.Constraint != "chk_pricings_amount" || calls != 1 {
+		t.Errorf("should return the violation of the retry, calls %v, got %v", calls, err)
+	}
+
+	var count int64
+	if DB.Model(&Pricing{}).Where("amount < ?", 0).Count(&count); count != 0 {
+		t.Errorf("should not create negative amounts, got %v", count)
+	}
+}
+
+type errorTranslatorFunc func(err error) error
+
+func (fc errorTranslatorFunc) Translate(err error) error {
+	return fc(err)
+}
+
+type sliceError []string
+
+func (err sliceError) Error() string { return strings.Join(err, ",") }
+
+func TestErrorTranslatorChain(t *testing.T) {
+	var (
+		customErr   = errors.New("custom driver error")
+		unknownErr  = errors.New("unknown error")
+		listErr     = sliceError{"list", "error"}
+		passThrough = errorTranslatorFunc(func(err error) error { return err })
+		custom      = errorTranslatorFunc(func(err error) error {
+			if errors.Is(err, customErr) {
+				return gorm.ErrCheckConstraintViolated
+			}
+			return err
+		})
+		duplicated = errorTranslatorFunc(func(err error) error {
+			if strings.Contains(err.Error(), "duplicate") {
+				return gorm.ErrDuplicatedKey
+			}
+			return err
+		})
+	)
+
+	chain := gorm.TranslatorChain{passThrough, nil, custom, duplicated}
+	for _, result := range []struct {
+		err, expected error
+	}{
+		{customErr, gorm.ErrCheckConstraintViolated},
+		{errors.New("duplicate entry"), gorm.ErrDuplicatedKey},
+		{fmt.Errorf("wrapped: %w", customErr), gorm.ErrCheckConstraintViolated},
+		{unknownErr, unknownErr},
+		{nil, nil},
+	} {
+		if translated := chain.Translate(result.err); !errors.Is(translated, result.expected) && (result.expected != nil || translated != nil) {
+			t.Errorf("translate %v expects %v, got %v", result.err, result.expected, translated)
+		}
+	}
+
+	if translated := chain.Translate(listErr); fmt.Sprint(translated) != "list,error" {
+		t.Errorf("unrecognized errors should pass through unchanged, got %v", translated)
+	}
+
+	dialectErr := errors.New("dialect error")
+	db, _ := gorm.Open(tests.DummyDialector{TranslatedErr: dialectErr}, &gorm.Config{TranslateError: true, ErrorTranslators: []gorm.ErrorTranslator{custom}})
+	if err := db.Session(&gorm.Session{}).AddError(customErr); !errors.Is(err, gorm.ErrCheckConstraintViolated) {
+		t.Errorf("registered translators should translate before the dialector, got %v", err)
+	}
+
+	if err := db.Session(&gorm.Session{}).AddError(unknownErr); !errors.Is(err, dialectErr) {
+		t.Errorf("errors not recognized by registered translators should be translated by the dialector, got %v", err)
+	}
+
+	db.ErrorTranslators = append(db.ErrorTranslators, duplicated)
+	if err := db.Session(&gorm.Session{}).AddError(errors.New("duplicate entry")); !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Errorf("translators appended to db should be used, got %v", err)
+	}
+
+	db, _ = gorm.Open(tests.DummyDialector{TranslatedErr: dialectErr}, &gorm.Config{ErrorTranslators: []gorm.ErrorTranslator{custom}})
+	if err := db.Session(&gorm.Session{}).AddError(customErr); !errors.Is(err, customErr) {
+		t.Errorf("should not translate errors if TranslateError disabled, got %v", err)
+	}
+}
@@ -609,3 +609,194 @@ func TestPropagateUnscoped(t *testing.T) {
 		t.Fatalf("unscoped did not propagate")
 	}
 }
+
+type Product7 struct {
+	gorm.Model
+	Name   string
+	Price  float64
+	Deltas map[string][2]interface{} `gorm:"-"`
+}
+
+func (p *Product7) AfterUpdate(tx *gorm.DB) error {
+	p.Deltas = map[string][2]interface{}{}
+	if deltas, ok := tx.Get("gorm:field_deltas"); ok {
+		for _, delta := range deltas.([]gorm.FieldDelta) {
+			p.Deltas[delta.Field.Name] = [2]interface{}{delta.Before, delta.After}
+		}
+	}
+	return nil
+}
+
+func TestUpdateWithFieldDeltas(t *testing.T) {
+	DB.Migrator().DropTable(&Product7{})
+	DB.AutoMigrate(&Product7{})
+
+	p := Product7{Name: "field_deltas", Price: 10}
+	DB.Create(&p)
+
+	DB.Model(&p).Updates(map[string]interface{}{"name": "field_deltas_1"})
+	if len(p.Deltas) != 0 {
+		t.Errorf("should not record deltas if not enabled, got %v", p.Deltas)
+	}
+
+	tx := DB.Set("gorm:record_field_deltas", true)
+	if err := tx.Model(&p).Updates(map[string]interface{}{"name": "field_deltas_2", "price": 10}).Error; err != nil {
+		t.Fatalf("failed to update, got error %v", err)
+	}
+
+	if delta, ok := p.Deltas["Name"]; !ok || delta[0] != "field_deltas_1" || delta[1] != "field_deltas_2" {
+		t.Errorf("should record delta of name, got %v", p.Deltas)
+	}
+
+	if _, ok := p.Deltas["Price"]; ok {
+		t.Errorf("should not record delta of unchanged price, got %v", p.Deltas)
+	}
+
+	if _, ok := p.Deltas["UpdatedAt"]; !ok {
+		t.Errorf("should record delta of updated at, got %v", p.Deltas)
+	}
+
+	p.Price = 20
+	if err := tx.Save(&p).Error; err != nil {
+		t.Fatalf("failed to save, got error %v", err)
+	}
+
+	if delta, ok := p.Deltas["Price"]; !ok || delta[0] != float64(10) || delta[1] != float64(20) {
+		t.Errorf("should record delta of price, got %v", p.Deltas)
+	}
+
+	for _, name := range []string{"Name", "CreatedAt", "ID"} {
+		if _, ok := p.Deltas[name]; ok {
+			t.Errorf("should not record delta of unchanged %v, got %v", name, p.Deltas)
+		}
+	}
+}
+
+type Product8 struct {
+	gorm.Model
+	Name    string
+	Created bool `gorm:"-"`
+}
+
+func (p *Product8) BeforeCreate(tx *gorm.DB) error {
+	if strings.HasPrefix(p.Name, "skip") {
+		return gorm.ErrSkipRow
+	}
+	return nil
+}
+
+func (p *Product8) AfterCreate(tx *gorm.DB) error {
+	p.Created = true
+	return nil
+}
+
+func TestBeforeCreateSkipRow(t *testing.T) {
+	DB.Migrator().DropTable(&Product8{})
+	DB.AutoMigrate(&Product8{})
+
+	products := []Product8{{Name: "skip_row_1"}, {Name: "row_2"}, {Name: "skip_row_3"}, {Name: "row_4"}}
+	result := DB.Create(&products)
+	if result.Error != nil {
+		t.Fatalf("failed to create, got error %v", result.Error)
+	}
+
+	if result.RowsAffected != 2 {
+		t.Errorf("rows affected should only count inserted rows, got %v", result.RowsAffected)
+	}
+
+	for _, p := range products {
+		skipped := strings.HasPrefix(p.Name, "skip")
+		if (p.ID == 0) != skipped || p.Created == skipped {
+			t.Errorf("only the rows not skipped should be inserted, got %+v", p)
+		}
+	}
+
+	var names []string
+	DB.Model(&Product8{}).Order("id").Pluck("name", &names)
+	if !reflect.DeepEqual(names, []string{"row_2", "row_4"}) {
+		t.Errorf("skipped rows should not be inserted, got %v", names)
+	}
+
+	p := Product8{Name: "skip_row_5"}
+	if result := DB.Create(&p); result.Error != nil || result.RowsAffected != 0 || p.ID != 0 || p.Created {
+		t.Errorf("skipped row should not be inserted, got %+v, error %v", p, result.Error)
+	}
+
+	all := []*Product8{{Name: "skip_row_6"}, {Name: "skip_row_7"}}
+	if result := DB.Create(&all); result.Error != nil || result.RowsAffected != 0 {
+		t.Errorf("should not insert if all rows are skipped, got rows affected %v, error %v", result.RowsAffected, result.Error)
+	}
+
+	var count int64
+	DB.Model(&Product8{}).Count(&count)
+	if count != 2 {
+		t.Errorf("skipped rows should not be inserted, got %v", count)
+	}
+}
+
+func TestGlobalAfterCreateHook(t *testing.T) {
+	db, err := OpenTestConnection(&gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open test connection, got error %v", err)
+	}
+
+	var (
+		calls   int
+		names   []string
+		created [][]interface{}
+	)
+	if err := db.Callback().RegisterAfterCreate("test:audit", func(tx *gorm.DB, records reflect.Value, primaryKeys [][]interface{}) error {
+		calls++
+		created = append(created, primaryKeys...)
+		if records.Kind() == reflect.Slice {
+			for i := 0; i < records.Len(); i++ {
+				names = append(names, records.Index(i).FieldByName("Name").String())
+			}
+		} else {
+			names = append(names, records.FieldByName("Name").String())
+		}
+
+		if tx.Statement.Table == "pets" && len(primaryKeys) == 1 && records.FieldByName("Name").String() == "failed" {
+			return errors.New("audit failed")
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to register after create hook, got error %v", err)
+	}
+
+	if names := db.Callback().Create().Callbacks(); !strings.Contains(strings.Join(names, ","), "gorm:after_create,test:audit,gorm:commit_or_rollback_transaction") {
+		t.Errorf("hook should run after gorm:after_create in the transaction, got %v", names)
+	}
+
+	users := []User{*GetUser("global_hook_1", Config{}), *GetUser("global_hook_2", Config{})}
+	if err := db.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error %v", err)
+	}
+
+	user := GetUser("global_hook_3", Config{})
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("failed to create user, got error %v", err)
+	}
+
+	if calls != 2 || !reflect.DeepEqual(names, []string{"global_hook_1", "global_hook_2", "global_hook_3"}) {
+		t.Errorf("hook should run once per create, got calls %v, names %v", calls, names)
+	}
+
+	expects := [][]interface{}{{users[0].ID}, {users[1].ID}, {user.ID}}
+	if !reflect.DeepEqual(created, expects) {
+		t.Errorf("hook should receive the inserted primary keys %v, got %v", expects, created)
+	}
+
+	if err := db.Session(&gorm.Session{SkipHooks: true}).Create(GetUser("global_hook_4", Config{})).Error; err != nil || calls != 2 {
+		t.Errorf("hook should be skipped with SkipHooks, got calls %v, error %v", calls, err)
+	}
+
+	pet := Pet{Name: "failed"}
+	if err := db.Create(&pet).Error; err == nil || err.Error() != "audit failed" {
+		t.Errorf("should return the hook error, got %v", err)
+	}
+
+	if err := db.First(&Pet{}, "name = ?", "failed").Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("create should be rolled back when the hook failed, got %v", err)
+	}
+}
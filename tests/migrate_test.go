@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
@@ -16,6 +17,7 @@ import (
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
 	"gorm.io/gorm/migrator"
 	"gorm.io/gorm/schema"
 	"gorm.io/gorm/utils"
@@ -2001,3 +2003,200 @@ func TestAutoMigrateDecimal(t *testing.T) {
 		decimalColumnsTest[MigrateDecimalColumn, MigrateDecimalColumn2](t, expectedSql)
 	}
 }
+
+func TestMigrateUniqueWithPredicate(t *testing.T) {
+	type PartialUniqueUser struct {
+		ID     uint
+		Email  string `gorm:"unique;where:status <> 'archived'"`
+		Status string
+	}
+
+	if DB.Dialector.Name() == "mysql" || DB.Dialector.Name() == "tidb" {
+		writer := &bufferWriter{}
+		db := DB.Session(&gorm.Session{Logger: logger.New(writer, logger.Config{LogLevel: logger.Warn})})
+
+		db.Migrator().DropTable(&PartialUniqueUser{})
+		if err := db.AutoMigrate(&PartialUniqueUser{}); err != nil {
+			t.Fatalf("failed to migrate, got error %v", err)
+		}
+
+		if db.Migrator().HasIndex(&PartialUniqueUser{}, "uni_partial_unique_users_email") {
+			t.Errorf("should skip partial unique index")
+		}
+
+		if !strings.Contains(strings.Join(writer.logs, "\n"), "partial unique constraint uni_partial_unique_users_email is not supported") {
+			t.Errorf("should warn unsupported partial unique index, got %v", writer.logs)
+		}
+		return
+	}
+
+	DB.Migrator().DropTable(&PartialUniqueUser{})
+	if err := DB.AutoMigrate(&PartialUniqueUser{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	if !DB.Migrator().HasIndex(&PartialUniqueUser{}, "uni_partial_unique_users_email") {
+		t.Fatalf("should create partial unique index")
+	}
+
+	if err := DB.AutoMigrate(&PartialUniqueUser{}); err != nil {
+		t.Fatalf("failed to migrate again, got error %v", err)
+	}
+
+	if err := DB.Create([]PartialUniqueUser{
+		{Email: "partial@example.org", Status: "archived"},
+		{Email: "partial@example.org", Status: "archived"},
+		{Email: "partial@example.org", Status: "active"},
+	}).Error; err != nil {
+		t.Fatalf("archived rows should not be unique, got error %v", err)
+	}
+
+	if err := DB.Create(&PartialUniqueUser{Email: "partial@example.org", Status: "active"}).Error; err == nil {
+		t.Errorf("should fail to create duplicated active row")
+	}
+}
+
+func TestMigrateCheckWithBoolLiterals(t *testing.T) {
+	type BoolCheck struct {
+		ID     uint
+		Active int `gorm:"check:chk_bool_checks_active,active IN (TRUE, FALSE)"`
+	}
+
+	DB.Migrator().DropTable(&BoolCheck{})
+	if err := DB.AutoMigrate(&BoolCheck{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	if !DB.Migrator().HasConstraint(&BoolCheck{}, "chk_bool_checks_active") {
+		t.Fatalf("check constraint should be created")
+	}
+
+	if err := DB.Create(&BoolCheck{Active: 1}).Error; err != nil {
+		t.Errorf("failed to create, got error %v", err)
+	}
+
+	if err := DB.Create(&BoolCheck{Active: 2}).Error; err == nil {
+		t.Errorf("should violate check constraint")
+	}
+}
+
+type numericBoolDialector struct {
+	gorm.Dialector
+}
+
+func (numericBoolDialector) SupportBoolLiteral() bool {
+	return false
+}
+
+func TestMigrateCheckWithBoolLiteralsDryRun(t *testing.T) {
+	type BoolCheck struct {
+		ID     uint
+		Active int `gorm:"check:chk_bool_checks_active,active IN (TRUE, FALSE)"`
+	}
+
+	writer := &bufferWriter{}
+	db, err := OpenTestConnection(&gorm.Config{DryRun: true, Logger: logger.New(writer, logger.Config{LogLevel: logger.Info})})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	db.Dialector = numericBoolDialector{Dialector: db.Dialector}
+
+	if err := db.Migrator().CreateTable(&BoolCheck{}); err != nil {
+		t.Fatalf("failed to create table, got error %v", err)
+	}
+
+	if logs := strings.Join(writer.logs, "\n"); !regexp.MustCompile(`CONSTRAINT .chk_bool_checks_active. CHECK \(active IN \(1, 0\)\)`).MatchString(logs) {
+		t.Errorf("check constraint should be created with numeric boolean literals, got %v", writer.logs)
+	}
+}
+
+func TestMigrateUniqueAsIndex(t *testing.T) {
+	type UniqueAsIndexUser struct {
+		ID    uint
+		Email string `gorm:"unique;asIndex:concurrently"`
+	}
+
+	DB.Migrator().DropTable(&UniqueAsIndexUser{})
+	if err := DB.AutoMigrate(&UniqueAsIndexUser{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	if !DB.Migrator().HasIndex(&UniqueAsIndexUser{}, "uni_unique_as_index_users_email") {
+		t.Fatalf("should create unique index")
+	}
+
+	if err := DB.AutoMigrate(&UniqueAsIndexUser{}); err != nil {
+		t.Fatalf("failed to migrate again, got error %v", err)
+	}
+
+	if err := DB.Create(&UniqueAsIndexUser{Email: "as_index@example.org"}).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if err := DB.Create(&UniqueAsIndexUser{Email: "as_index@example.org"}).Error; err == nil {
+		t.Errorf("should fail to create duplicated row")
+	}
+
+	if err := DB.Migrator().DropIndex(&UniqueAsIndexUser{}, "uni_unique_as_index_users_email"); err != nil {
+		t.Fatalf("failed to drop index, got error %v", err)
+	}
+
+	if err := DB.AutoMigrate(&UniqueAsIndexUser{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	if !DB.Migrator().HasIndex(&UniqueAsIndexUser{}, "uni_unique_as_index_users_email") {
+		t.Errorf("should create missing unique index when migrating")
+	}
+}
+
+func TestMigrateUniqueWithStorageOptions(t *testing.T) {
+	if DB.Dialector.Name() == "postgres" {
+		type UniqueStoragePgUser struct {
+			ID    uint
+			Name  string `gorm:"unique;storage:fillfactor=70;tablespace:pg_default"`
+			Email string `gorm:"unique;asIndex;storage:fillfactor=80"`
+		}
+
+		DB.Migrator().DropTable(&UniqueStoragePgUser{})
+		if err := DB.AutoMigrate(&UniqueStoragePgUser{}); err != nil {
+			t.Fatalf("failed to migrate, got error %v", err)
+		}
+
+		for name, param := range map[string]string{"uni_unique_storage_pg_users_name": "fillfactor='70'", "uni_unique_storage_pg_users_email": "fillfactor='80'"} {
+			var definition string
+			DB.Raw("SELECT indexdef FROM pg_indexes WHERE indexname = ?", name).Scan(&definition)
+			if !strings.Contains(definition, param) {
+				t.Errorf("index %v should be created with %v, got %v", name, param, definition)
+			}
+		}
+		return
+	}
+
+	type UniqueStorageUser struct {
+		ID    uint
+		Name  string `gorm:"unique;storage:fillfactor=70;tablespace:fast_ssd"`
+		Email string `gorm:"unique;asIndex;tablespace:fast_ssd"`
+	}
+
+	writer := &bufferWriter{}
+	db := DB.Session(&gorm.Session{Logger: logger.New(writer, logger.Config{LogLevel: logger.Warn})})
+
+	db.Migrator().DropTable(&UniqueStorageUser{})
+	if err := db.AutoMigrate(&UniqueStorageUser{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	if !db.Migrator().HasConstraint(&UniqueStorageUser{}, "uni_unique_storage_users_name") || !db.Migrator().HasIndex(&UniqueStorageUser{}, "uni_unique_storage_users_email") {
+		t.Errorf("should create unique constraints ignoring storage options")
+	}
+
+	if !strings.Contains(strings.Join(writer.logs, "\n"), "storage parameters and tablespace of uni_unique_storage_users_name are not supported") {
+		t.Errorf("should warn unsupported storage options, got %v", writer.logs)
+	}
+
+	indexStorage := db.Migrator().(interface{ IndexStorageOptions(*schema.Index) string })
+	if options := indexStorage.IndexStorageOptions(&schema.Index{Name: "idx_storage", Tablespace: "fast_ssd"}); options != "" {
+		t.Errorf("unsupported storage options should be ignored, got %v", options)
+	}
+}
@@ -0,0 +1,84 @@
+package tests_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+	. "gorm.io/gorm/utils/tests"
+)
+
+type pipelineConnPool struct {
+	*sql.DB
+	flushed [][]gorm.PipelineQuery
+}
+
+func (p *pipelineConnPool) ExecPipeline(ctx context.Context, queries []gorm.PipelineQuery) []gorm.PipelineResult {
+	p.flushed = append(p.flushed, queries)
+	results := make([]gorm.PipelineResult, len(queries))
+	for idx, query := range queries {
+		if result, err := p.DB.ExecContext(ctx, query.SQL, query.Vars...); err != nil {
+			results[idx].Error = err
+		} else {
+			results[idx].RowsAffected, _ = result.RowsAffected()
+		}
+	}
+	return results
+}
+
+func TestPipeline(t *testing.T) {
+	user1, user2 := GetUser("pipeline_1", Config{}), GetUser("pipeline_2", Config{})
+	results := DB.Pipeline().Create(user1).Create(&[]User{}).Create(user2).Flush()
+
+	if len(results) != 3 {
+		t.Fatalf("should return 3 results, got %v", len(results))
+	}
+
+	if results[0].Error != nil || results[0].RowsAffected != 1 || results[2].Error != nil || results[2].RowsAffected != 1 {
+		t.Errorf("failed to create users in pipeline, got %+v", results)
+	}
+
+	if !errors.Is(results[1].Error, gorm.ErrEmptySlice) {
+		t.Errorf("should return build error of the operation, got %v", results[1].Error)
+	}
+
+	var count int64
+	if DB.Model(&User{}).Where("name IN ?", []string{"pipeline_1", "pipeline_2"}).Count(&count); count != 2 {
+		t.Errorf("should create 2 users, got %v", count)
+	}
+
+	sqlDB, err := DB.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql.DB, got error %v", err)
+	}
+
+	pool := &pipelineConnPool{DB: sqlDB}
+	db := DB.Session(&gorm.Session{Context: context.Background()})
+	db.Statement.ConnPool = pool
+
+	results = db.Pipeline().Create(GetUser("pipeline_3", Config{})).Create(GetUser("pipeline_4", Config{})).Flush()
+	if len(pool.flushed) != 1 || len(pool.flushed[0]) != 2 {
+		t.Fatalf("should flush queries in one round-trip, got %+v", pool.flushed)
+	}
+
+	for _, result := range results {
+		if result.Error != nil || result.RowsAffected != 1 {
+			t.Errorf("failed to create users in pipeline, got %+v", result)
+		}
+	}
+
+	if DB.Model(&User{}).Where("name IN ?", []string{"pipeline_3", "pipeline_4"}).Count(&count); count != 2 {
+		t.Errorf("should create 2 users, got %v", count)
+	}
+
+	user5 := GetUser("pipeline_5", Config{Pets: 2})
+	if results = DB.Pipeline().Create(user5).Flush(); results[0].Error != nil {
+		t.Fatalf("failed to create user in pipeline, got error %v", results[0].Error)
+	}
+
+	if DB.Model(&Pet{}).Where("name LIKE ?", "pipeline_5_pet%").Count(&count); count != 0 {
+		t.Errorf("associations should not be saved in pipeline, got %v pets", count)
+	}
+}
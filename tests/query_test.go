@@ -3,6 +3,7 @@ package tests_test
 import (
 	"database/sql"
 	"database/sql/driver"
+	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -1453,3 +1454,288 @@ func TestQueryScanToArray(t *testing.T) {
 		t.Error("users[1] should be empty")
 	}
 }
+
+func TestQueryWithCollate(t *testing.T) {
+	user := GetUser("query_with_collate", Config{})
+	DB.Create(user)
+
+	name, collation := "QUERY_WITH_COLLATE", "NOCASE"
+	switch DB.Dialector.Name() {
+	case "postgres":
+		name, collation = user.Name, "C"
+	case "mysql":
+		collation = "utf8mb4_general_ci"
+	case "sqlserver":
+		collation = "Latin1_General_CI_AS"
+	}
+
+	var result User
+	if err := DB.Where(clause.Eq{Column: "name", Value: name}.Collate(collation)).First(&result).Error; err != nil {
+		t.Fatalf("failed to query with collate, got error %v", err)
+	} else if result.ID != user.ID {
+		t.Errorf("should find user with collation, got %v", result.ID)
+	}
+}
+
+func TestQueryWithNullsOrder(t *testing.T) {
+	stmt := DB.Session(&gorm.Session{DryRun: true}).Set("gorm:nulls_order", clause.NullsLast).
+		Order("age desc").Order(clause.OrderByColumn{Column: clause.Column{Name: "name"}, Nulls: clause.NullsFirst}).
+		Find(&[]User{}).Statement
+
+	switch DB.Dialector.Name() {
+	case "postgres", "sqlite":
+		if !regexp.MustCompile(`ORDER BY age desc NULLS LAST,.name. NULLS FIRST$`).MatchString(stmt.SQL.String()) {
+			t.Errorf("default nulls order should be applied, got %v", stmt.SQL.String())
+		}
+	case "mysql", "sqlserver":
+		if !regexp.MustCompile(`ORDER BY age desc,CASE WHEN .name. IS NULL THEN 0 ELSE 1 END,.name.`).MatchString(stmt.SQL.String()) {
+			t.Errorf("nulls order should be emulated, got %v", stmt.SQL.String())
+		}
+	}
+
+	stmt = DB.Session(&gorm.Session{DryRun: true}).Order("age desc").Find(&[]User{}).Statement
+	if strings.Contains(stmt.SQL.String(), "NULLS") {
+		t.Errorf("nulls order should not be applied by default, got %v", stmt.SQL.String())
+	}
+}
+
+func TestQueryExists(t *testing.T) {
+	users := []User{*GetUser("exists_1", Config{}), *GetUser("exists_2", Config{})}
+	DB.Create(&users)
+	DB.Delete(&users[1])
+
+	if exists, err := DB.Model(&User{}).Exists("name = ?", "exists_1"); err != nil || !exists {
+		t.Errorf("user should exist, got %v, error %v", exists, err)
+	}
+
+	if exists, err := DB.Model(&User{}).Where("name = ?", "exists_2").Exists(); err != nil || exists {
+		t.Errorf("soft deleted user should not exist, got %v, error %v", exists, err)
+	}
+
+	if exists, err := DB.Unscoped().Model(&User{}).Exists(map[string]interface{}{"name": "exists_2"}); err != nil || !exists {
+		t.Errorf("soft deleted user should exist when unscoped, got %v, error %v", exists, err)
+	}
+
+	nameScope := func(name string) func(*gorm.DB) *gorm.DB {
+		return func(db *gorm.DB) *gorm.DB {
+			return db.Where("name = ?", name)
+		}
+	}
+
+	if exists, err := DB.Model(&User{}).Scopes(nameScope("exists_1")).Exists(); err != nil || !exists {
+		t.Errorf("user should exist with scopes, got %v, error %v", exists, err)
+	}
+
+	if exists, err := DB.Model(&User{}).Scopes(nameScope("exists_3")).Exists(); err != nil || exists {
+		t.Errorf("user should not exist with scopes, got %v, error %v", exists, err)
+	}
+}
+
+func TestFindEach(t *testing.T) {
+	users := []User{*GetUser("find_each_1", Config{Pets: 2}), *GetUser("find_each_2", Config{Pets: 1}), *GetUser("find_each_3", Config{})}
+	DB.Create(&users)
+	DB.Delete(&users[2])
+
+	var (
+		user  User
+		names []string
+		pets  []int
+	)
+	result := DB.Preload("Pets").Where("name LIKE ?", "find_each_%").Order("name").FindEach(&user, func(tx *gorm.DB) error {
+		names = append(names, user.Name)
+		pets = append(pets, len(user.Pets))
+		return nil
+	})
+	if result.Error != nil {
+		t.Fatalf("failed to find each, got error %v", result.Error)
+	}
+
+	if strings.Join(names, ",") != "find_each_1,find_each_2" || fmt.Sprint(pets) != "[2 1]" || result.RowsAffected != 2 {
+		t.Errorf("should find each not deleted users with preloaded pets, got %v, %v, rows %v", names, pets, result.RowsAffected)
+	}
+
+	var (
+		calls     int
+		errStop   = errors.New("stop")
+		otherUser User
+	)
+	result = DB.Where("name LIKE ?", "find_each_%").FindEach(&otherUser, func(tx *gorm.DB) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(result.Error, errStop) || calls != 1 {
+		t.Errorf("should stop when fc returns error, got calls %v, error %v", calls, result.Error)
+	}
+
+	if err := DB.Where("name LIKE ?", "find_each_%").FindEach(&[]User{}, func(tx *gorm.DB) error { return nil }).Error; !errors.Is(err, gorm.ErrInvalidValue) {
+		t.Errorf("should return error when dest is not a struct, got %v", err)
+	}
+
+	DB.Create(&Product{Code: "find_each", Price: 100})
+	var product Product
+	if err := DB.Where("code = ?", "find_each").FindEach(&product, func(tx *gorm.DB) error {
+		if product.AfterFindCallTimes != 1 {
+			t.Errorf("AfterFind should be called for each record, called %v", product.AfterFindCallTimes)
+		}
+		return nil
+	}).Error; err != nil {
+		t.Errorf("failed to find each product, got error %v", err)
+	}
+}
+
+func TestLazyColumns(t *testing.T) {
+	type LazyDocument struct {
+		ID      uint
+		Title   string
+		Content string `gorm:"lazy"`
+		Raw     []byte `gorm:"select:false"`
+	}
+
+	DB.Migrator().DropTable(&LazyDocument{})
+	if err := DB.AutoMigrate(&LazyDocument{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	doc := LazyDocument{Title: "lazy", Content: "large content", Raw: []byte("raw")}
+	if err := DB.Create(&doc).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	stmt := DB.Session(&gorm.Session{DryRun: true}).Find(&[]LazyDocument{}).Statement
+	if sql := stmt.SQL.String(); strings.Contains(sql, "content") || strings.Contains(sql, "raw") || !strings.Contains(sql, "title") {
+		t.Errorf("lazy columns should not be selected by default, got %v", sql)
+	}
+
+	var result LazyDocument
+	if err := DB.First(&result, doc.ID).Error; err != nil || result.Title != "lazy" || result.Content != "" || result.Raw != nil {
+		t.Errorf("lazy columns should not be loaded by default, got %+v, error %v", result, err)
+	}
+
+	var results []LazyDocument
+	if err := DB.Omit("title").Find(&results, doc.ID).Error; err != nil || len(results) != 1 || results[0].Content != "" {
+		t.Errorf("lazy columns should not be loaded with omits, got %+v, error %v", results, err)
+	}
+
+	if err := DB.Select("id", "content").First(&result, doc.ID).Error; err != nil || result.Content != "large content" || result.Raw != nil {
+		t.Errorf("selected lazy column should be loaded, got %+v, error %v", result, err)
+	}
+
+	result = LazyDocument{}
+	if err := DB.Select("*").First(&result, doc.ID).Error; err != nil || result.Content != "large content" || string(result.Raw) != "raw" {
+		t.Errorf("lazy columns should be loaded with *, got %+v, error %v", result, err)
+	}
+
+	if err := DB.Model(&result).Update("content", "updated content").Error; err != nil {
+		t.Fatalf("failed to update lazy column, got error %v", err)
+	}
+
+	var content string
+	if err := DB.Model(&LazyDocument{}).Where("id = ?", doc.ID).Pluck("content", &content).Error; err != nil || content != "updated content" {
+		t.Errorf("lazy column should be updated, got %v, error %v", content, err)
+	}
+}
+
+type qualifyDialector struct {
+	gorm.Dialector
+}
+
+func (qualifyDialector) SupportQualify() bool {
+	return true
+}
+
+func TestQueryWithQualify(t *testing.T) {
+	users := []User{
+		*GetUser("qualify_1", Config{}), *GetUser("qualify_2", Config{}), *GetUser("qualify_3", Config{}),
+	}
+	users[0].Age, users[1].Age, users[2].Age = 10, 10, 20
+	DB.Create(&users)
+
+	qualify := clause.Qualify{Exprs: []clause.Expression{clause.Eq{Column: "rn", Value: 1}}}
+	query := DB.Select("*, row_number() OVER (PARTITION BY age ORDER BY id DESC) AS rn").
+		Where("name LIKE ?", "qualify%").Clauses(qualify).Order("age")
+
+	// sqlite doesn't support QUALIFY, the query is wrapped in a subquery
+	stmt := query.Session(&gorm.Session{DryRun: true}).Find(&[]User{}).Statement
+	if !regexp.MustCompile(`^SELECT \* FROM \( SELECT .+ \) AS .users. WHERE .rn. = \? ORDER BY age$`).MatchString(stmt.SQL.String()) {
+		t.Errorf("should wrap query in subquery, got %v", stmt.SQL.String())
+	}
+
+	var results []User
+	if err := query.Find(&results).Error; err != nil {
+		t.Fatalf("failed to query with qualify, got error %v", err)
+	}
+
+	if len(results) != 2 || results[0].Name != "qualify_2" || results[1].Name != "qualify_3" {
+		t.Errorf("should find latest user of each age, got %+v", results)
+	}
+
+	db, err := OpenTestConnection(&gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	db.Dialector = qualifyDialector{Dialector: db.Dialector}
+
+	stmt = db.Session(&gorm.Session{DryRun: true}).Model(&User{}).Clauses(qualify).Order("age").Find(&[]User{}).Statement
+	if !regexp.MustCompile(`^SELECT \* FROM .users. WHERE .users.\..deleted_at. IS NULL QUALIFY .rn. = \? ORDER BY age$`).MatchString(stmt.SQL.String()) {
+		t.Errorf("should build QUALIFY when the dialector supports it, got %v", stmt.SQL.String())
+	}
+}
+
+type limitRewriteDialector struct {
+	gorm.Dialector
+}
+
+// RewriteLimit only rewrites queries with offset, e.g: ROW_NUMBER pagination, sqlite LIMIT -1 is used as no limit
+func (limitRewriteDialector) RewriteLimit(stmt *gorm.Statement, limit, offset *int) clause.Expression {
+	if offset == nil {
+		return nil
+	}
+
+	count := -1
+	if limit != nil {
+		count = *limit
+	}
+	return clause.Expr{SQL: "LIMIT ? OFFSET ? /* rewritten */", Vars: []interface{}{count, *offset}}
+}
+
+func TestQueryWithLimitRewriter(t *testing.T) {
+	if DB.Dialector.Name() != "sqlite" {
+		t.Skipf("This test case skipped, because LIMIT -1 means no limit only in sqlite")
+	}
+
+	users := []User{
+		*GetUser("limit_rewrite_1", Config{}), *GetUser("limit_rewrite_2", Config{}), *GetUser("limit_rewrite_3", Config{}),
+	}
+	DB.Create(&users)
+
+	db, err := OpenTestConnection(&gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	db.Dialector = limitRewriteDialector{Dialector: db.Dialector}
+	delete(db.ClauseBuilders, "LIMIT")
+
+	query := db.Where("name LIKE ?", "limit_rewrite%").Order("id").Session(&gorm.Session{})
+	stmt := query.Session(&gorm.Session{DryRun: true}).Limit(2).Offset(1).Find(&[]User{}).Statement
+	if !regexp.MustCompile(`ORDER BY id LIMIT \? OFFSET \? /\* rewritten \*/$`).MatchString(stmt.SQL.String()) || !reflect.DeepEqual(stmt.Vars[len(stmt.Vars)-2:], []interface{}{2, 1}) {
+		t.Errorf("should use the limit rewritten by dialector, got %v, vars %v", stmt.SQL.String(), stmt.Vars)
+	}
+
+	if c, ok := stmt.Clauses["LIMIT"]; !ok || c.Expression.(clause.Limit).Offset != 1 {
+		t.Errorf("LIMIT clause should be restored after building, got %#v", c)
+	}
+
+	var results []User
+	if err := query.Offset(1).Find(&results).Error; err != nil || len(results) != 2 || results[0].Name != "limit_rewrite_2" {
+		t.Errorf("should find users with the rewritten limit, got %+v, error %v", results, err)
+	}
+
+	stmt = query.Session(&gorm.Session{DryRun: true}).Limit(2).Find(&[]User{}).Statement
+	if !regexp.MustCompile(`ORDER BY id LIMIT \?$`).MatchString(stmt.SQL.String()) {
+		t.Errorf("should fall back to the standard LIMIT clause, got %v", stmt.SQL.String())
+	}
+
+	if err := query.Limit(1).Find(&results).Error; err != nil || len(results) != 1 || results[0].Name != "limit_rewrite_1" {
+		t.Errorf("should find users with the standard limit, got %+v, error %v", results, err)
+	}
+}
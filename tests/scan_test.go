@@ -1,6 +1,7 @@
 package tests_test
 
 import (
+	"errors"
 	"reflect"
 	"sort"
 	"strings"
@@ -339,3 +340,41 @@ func TestScanToEmbedded(t *testing.T) {
 	err := DB.Raw("SELECT * FROM users INNER JOIN users Manager ON users.manager_id = Manager.id WHERE users.id = ?", user.ID).Scan(&user2).Error
 	AssertEqual(t, err, nil)
 }
+
+func TestScanScalar(t *testing.T) {
+	users := []User{*GetUser("scan_scalar", Config{}), *GetUser("scan_scalar", Config{})}
+	DB.Create(&users)
+
+	var count int64
+	if err := DB.Raw("SELECT count(*) FROM users WHERE name = ?", "scan_scalar").ScanScalar(&count).Error; err != nil {
+		t.Fatalf("failed to scan scalar, got error %v", err)
+	} else if count != 2 {
+		t.Errorf("count should be 2, got %v", count)
+	}
+
+	var name string
+	if err := DB.Model(&User{}).Select("name").Where("id = ?", users[0].ID).ScanScalar(&name).Error; err != nil || name != "scan_scalar" {
+		t.Errorf("failed to scan scalar name, got %v, error %v", name, err)
+	}
+
+	var exists bool
+	if err := DB.Raw("SELECT EXISTS(SELECT 1 FROM users WHERE name = ?)", "scan_scalar").ScanScalar(&exists).Error; err != nil || !exists {
+		t.Errorf("failed to scan scalar bool, got %v, error %v", exists, err)
+	}
+
+	if err := DB.Raw("SELECT name, age FROM users WHERE id = ?", users[0].ID).ScanScalar(&name).Error; !errors.Is(err, gorm.ErrNotScalarResult) {
+		t.Errorf("should return ErrNotScalarResult for multiple columns, got %v", err)
+	}
+
+	if err := DB.Raw("SELECT name FROM users WHERE name = ?", "scan_scalar").ScanScalar(&name).Error; !errors.Is(err, gorm.ErrNotScalarResult) {
+		t.Errorf("should return ErrNotScalarResult for multiple rows, got %v", err)
+	}
+
+	if err := DB.Raw("SELECT name FROM users WHERE name = ?", "scan_scalar_not_found").ScanScalar(&name).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("should return ErrRecordNotFound for empty result, got %v", err)
+	}
+
+	if err := DB.Raw("SELECT count(*) FROM users").ScanScalar(count).Error; !errors.Is(err, gorm.ErrInvalidValue) {
+		t.Errorf("should return ErrInvalidValue for non-pointer dest, got %v", err)
+	}
+}
@@ -127,3 +127,25 @@ func TestComplexScopes(t *testing.T) {
 		})
 	}
 }
+
+func TestScopesWithDuplicateConditions(t *testing.T) {
+	active := func(d *gorm.DB) *gorm.DB { return d.Where("active = ?", true) }
+	named := func(d *gorm.DB) *gorm.DB { return d.Where(map[string]interface{}{"name": "jinzhu"}) }
+
+	queryFn := func(tx *gorm.DB) *gorm.DB {
+		return tx.Scopes(active, named, NameIn1And2, active, named, NameIn2And3, NameIn1And2).Where("active = ?", false).Find(&Language{})
+	}
+
+	assertEqualSQL(t, `SELECT * FROM "languages" WHERE active = false AND active = true AND "languages"."name" = "jinzhu" AND name in ("ScopeUser1","ScopeUser2") AND active = true AND "languages"."name" = "jinzhu" AND name in ("ScopeUser2","ScopeUser3") AND name in ("ScopeUser1","ScopeUser2")`, DB.ToSQL(queryFn))
+
+	db, err := OpenTestConnection(&gorm.Config{DedupConditions: true})
+	if err != nil {
+		t.Fatalf("failed to open test connection, got error %v", err)
+	}
+
+	assertEqualSQL(t, `SELECT * FROM "languages" WHERE active = false AND active = true AND "languages"."name" = "jinzhu" AND name in ("ScopeUser1","ScopeUser2") AND name in ("ScopeUser2","ScopeUser3")`, db.ToSQL(queryFn))
+
+	assertEqualSQL(t, `SELECT * FROM "languages" WHERE a = 1 OR b = 2 AND a = 1`, db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("a = 1").Or("b = 2").Where("a = 1").Find(&Language{})
+	}))
+}
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"regexp"
+	"sync"
 	"testing"
 
 	"github.com/jinzhu/now"
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 	. "gorm.io/gorm/utils/tests"
 )
 
@@ -167,3 +169,67 @@ func TestSoftDeleteZeroValue(t *testing.T) {
 		t.Errorf("Can't find permanently deleted record")
 	}
 }
+
+func TestSoftDeleteFlag(t *testing.T) {
+	type FlagTask struct {
+		ID        uint
+		Name      string
+		IsDeleted int `gorm:"softDelete:flag"`
+	}
+
+	DB.Migrator().DropTable(&FlagTask{})
+	if err := DB.AutoMigrate(&FlagTask{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	tasks := []FlagTask{{Name: "flag_1"}, {Name: "flag_2"}, {Name: "flag_3"}}
+	DB.Create(&tasks)
+
+	result := DB.Delete(&tasks[0])
+	if result.Error != nil || result.RowsAffected != 1 {
+		t.Fatalf("failed to soft delete, got rows affected %v, error %v", result.RowsAffected, result.Error)
+	}
+
+	if sql := DB.Session(&gorm.Session{DryRun: true}).Delete(&tasks[1]).Statement.SQL.String(); !regexp.MustCompile(`UPDATE .flag_tasks. SET .is_deleted.=.+ WHERE .flag_tasks.\..id. = .+ AND .flag_tasks.\..is_deleted. = .+`).MatchString(sql) {
+		t.Errorf("should update the flag when deleting, got %v", sql)
+	}
+
+	var isDeleted int
+	DB.Unscoped().Model(&FlagTask{}).Where("id = ?", tasks[0].ID).Pluck("is_deleted", &isDeleted)
+	if isDeleted != 1 {
+		t.Errorf("flag should be set to 1, got %v", isDeleted)
+	}
+
+	var count int64
+	if DB.Model(&FlagTask{}).Where("name LIKE ?", "flag%").Count(&count); count != 2 {
+		t.Errorf("deleted task should not be found, got %v", count)
+	}
+
+	if err := DB.First(&FlagTask{}, tasks[0].ID).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("should not find the deleted task, got error %v", err)
+	}
+
+	if DB.Model(&FlagTask{}).Where("id = ?", tasks[0].ID).Update("name", "flag_updated"); DB.Unscoped().First(&FlagTask{}, "name = ?", "flag_updated").Error == nil {
+		t.Errorf("should not update the deleted task")
+	}
+
+	// unscoped bypass the soft delete
+	if DB.Unscoped().Model(&FlagTask{}).Count(&count); count != 3 {
+		t.Errorf("should find deleted task with unscoped, got %v", count)
+	}
+
+	if err := DB.Unscoped().Delete(&tasks[0]).Error; err != nil {
+		t.Fatalf("failed to delete with unscoped, got error %v", err)
+	}
+
+	if DB.Unscoped().Model(&FlagTask{}).Count(&count); count != 2 {
+		t.Errorf("should permanently delete with unscoped, got %v", count)
+	}
+
+	if _, err := schema.Parse(&struct {
+		ID        uint
+		IsDeleted int `gorm:"softDelete:unknown"`
+	}{}, &sync.Map{}, schema.NamingStrategy{}); err == nil {
+		t.Errorf("should return error for unknown soft delete")
+	}
+}
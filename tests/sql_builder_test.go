@@ -1,13 +1,20 @@
 package tests_test
 
 import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
 	. "gorm.io/gorm/utils/tests"
 )
 
@@ -497,3 +504,257 @@ func replaceQuoteInSQL(sql string) string {
 
 	return sql
 }
+
+func TestJSONAggregation(t *testing.T) {
+	users := []User{*GetUser("json_agg_1", Config{}), *GetUser("json_agg_2", Config{})}
+	users[1].Age = 20
+	DB.Create(&users)
+
+	if name := DB.Dialector.Name(); name == "sqlserver" || name == "tidb" {
+		t.Skip("json aggregation is not supported")
+	}
+
+	var result string
+	if err := DB.Model(&User{}).Select("?", gorm.JSONArrayAgg(gorm.JSONObject(
+		"name", clause.Column{Name: "name"}, "age", clause.Column{Name: "age"}, "it's", "value",
+	))).Where("name IN ?", []string{"json_agg_1", "json_agg_2"}).ScanScalar(&result).Error; err != nil {
+		t.Fatalf("failed to query json aggregation, got error %v", err)
+	}
+
+	var objects []map[string]interface{}
+	if err := json.Unmarshal([]byte(result), &objects); err != nil {
+		t.Fatalf("failed to unmarshal %v, got error %v", result, err)
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return fmt.Sprint(objects[i]["name"]) < fmt.Sprint(objects[j]["name"]) })
+	if len(objects) != 2 || objects[0]["name"] != "json_agg_1" || objects[1]["age"] != float64(20) || objects[0]["it's"] != "value" {
+		t.Errorf("unexpected json aggregation result %v", result)
+	}
+
+	dryDB := DB.Session(&gorm.Session{DryRun: true})
+	dryDB.Dialector = DummyDialector{}
+	if err := dryDB.Model(&User{}).Select("?", gorm.JSONArrayAgg(clause.Column{Name: "name"})).Find(&[]User{}).Error; !errors.Is(err, gorm.ErrUnsupportedDriver) {
+		t.Errorf("should return error for dialect without json aggregation, got %v", err)
+	}
+
+	dryDB.Dialector = jsonAggDialector{}
+	stmt := dryDB.Model(&User{}).Select("?", gorm.JSONArrayAgg(gorm.JSONObject("name", clause.Column{Name: "name"}))).Find(&[]User{}).Statement
+	if sql := stmt.SQL.String(); stmt.Error != nil || !strings.Contains(sql, "JSON_AGG(JSON_BUILD_OBJECT('name',`name`))") {
+		t.Errorf("should use json aggregation functions of the dialector, got %v, error %v", sql, stmt.Error)
+	}
+}
+
+// jsonAggDialector dialector supplies json aggregation functions without a known dialect name
+type jsonAggDialector struct {
+	DummyDialector
+}
+
+func (jsonAggDialector) JSONAggFunctions() (arrayAgg, object string) {
+	return "JSON_AGG", "JSON_BUILD_OBJECT"
+}
+
+func TestValidateIdentifiers(t *testing.T) {
+	db, _ := OpenTestConnection(&gorm.Config{ValidateIdentifiers: true})
+	db = db.Session(&gorm.Session{DryRun: true})
+
+	var users []User
+	if err := db.Table("users").Select("name", "age").Order("users.age desc").Find(&users).Error; err != nil {
+		t.Errorf("safe identifiers should be allowed, got error %v", err)
+	}
+
+	if err := db.Table("public.users").Find(&users).Error; err != nil {
+		t.Errorf("schema prefixed table should be allowed, got error %v", err)
+	}
+
+	if err := db.Table("users;DROP_TABLE_users").Find(&users).Error; !errors.Is(err, gorm.ErrInvalidIdentifier) {
+		t.Errorf("should returns ErrInvalidIdentifier for unsafe table, got %v", err)
+	}
+
+	if err := db.Model(&User{}).Order("age; DROP TABLE users").Find(&users).Error; !errors.Is(err, gorm.ErrInvalidIdentifier) {
+		t.Errorf("should returns ErrInvalidIdentifier for unsafe raw column, got %v", err)
+	}
+
+	if err := DB.Session(&gorm.Session{DryRun: true}).Model(&User{}).Order("age; DROP TABLE users").Find(&users).Error; err != nil {
+		t.Errorf("identifiers should not be validated by default, got %v", err)
+	}
+}
+
+func TestRecursiveTree(t *testing.T) {
+	type TreeCategory struct {
+		ID       uint
+		ParentID *uint
+		Name     string
+	}
+
+	DB.Migrator().DropTable(&TreeCategory{})
+	if err := DB.AutoMigrate(&TreeCategory{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	parent := func(id uint) *uint { return &id }
+	DB.Create(&[]TreeCategory{
+		{ID: 1, Name: "root"}, {ID: 2, ParentID: parent(1), Name: "child"}, {ID: 3, ParentID: parent(2), Name: "grandchild"},
+		{ID: 4, Name: "other_root"}, {ID: 5, ParentID: parent(4), Name: "other_child"},
+	})
+
+	var categories []TreeCategory
+	tree := clause.RecursiveTree("tree_categories", "id", "parent_id", clause.Eq{Column: "id", Value: 1})
+	if err := DB.Table("(?) AS tree_categories", tree).Order("id").Find(&categories).Error; err != nil {
+		t.Fatalf("failed to query tree, got error %v", err)
+	}
+
+	var names []string
+	for _, category := range categories {
+		names = append(names, category.Name)
+	}
+
+	if strings.Join(names, ",") != "root,child,grandchild" {
+		t.Errorf("should find the whole tree, got %v", names)
+	}
+}
+
+func TestWithCTE(t *testing.T) {
+	users := []User{*GetUser("cte_1", Config{}), *GetUser("cte_2", Config{}), *GetUser("cte_3", Config{})}
+	users[2].Age = 5
+	DB.Create(&users)
+
+	with := clause.With{CTEs: []clause.CTE{{
+		Name:       "cte_users",
+		Expression: clause.Expr{SQL: "?", Vars: []interface{}{DB.Model(&User{}).Select("id").Where("name LIKE ? AND age > ?", "cte_%", 10)}},
+	}}}
+	inCTE := "id IN (SELECT id FROM cte_users)"
+
+	var results []User
+	if err := DB.Clauses(with).Where(inCTE).Order("id").Find(&results).Error; err != nil {
+		t.Fatalf("failed to query with CTE, got error %v", err)
+	}
+	if len(results) != 2 || results[0].Name != "cte_1" || results[1].Name != "cte_2" {
+		t.Errorf("should find users in the CTE, got %+v", results)
+	}
+
+	if result := DB.Clauses(with).Model(&User{}).Where(inCTE).Update("age", 99); result.Error != nil || result.RowsAffected != 2 {
+		t.Fatalf("failed to update with CTE, got error %v, rows affected %v", result.Error, result.RowsAffected)
+	}
+
+	if result := DB.Clauses(with).Where(inCTE).Delete(&User{}); result.Error != nil || result.RowsAffected != 2 {
+		t.Fatalf("failed to delete with CTE, got error %v, rows affected %v", result.Error, result.RowsAffected)
+	}
+
+	var count int64
+	if DB.Model(&User{}).Where("name LIKE ?", "cte_%").Count(&count); count != 1 {
+		t.Errorf("should delete users in the CTE, got %v left", count)
+	}
+}
+
+func TestDryRunWithPlaceholder(t *testing.T) {
+	user := *GetUser("placeholder", Config{})
+	dryRunDB := DB.Session(&gorm.Session{DryRun: true})
+
+	stmt := dryRunDB.Set("gorm:placeholder", gorm.PlaceholderDollar).Where("name = ? AND age > ?", "jinzhu", 18).Find(&[]User{}).Statement
+	if sql := stmt.SQL.String(); !regexp.MustCompile(`\(name = \$1 AND age > \$2\) AND .users.\..deleted_at. IS NULL$`).MatchString(sql) {
+		t.Errorf("should render with dollar placeholders, got %v", sql)
+	}
+
+	stmt = dryRunDB.Set("gorm:placeholder", "@p").Create(&user).Statement
+	if sql := stmt.SQL.String(); !strings.Contains(sql, "VALUES (@p1,@p2,@p3,") {
+		t.Errorf("should render with @p placeholders, got %v", sql)
+	}
+
+	sql := DB.Set("gorm:placeholder", gorm.PlaceholderColon).ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&User{}).Where("name = ?", "jinzhu").Limit(10).Find(&[]User{})
+	})
+	if !regexp.MustCompile(`name = .jinzhu. AND .users.\..deleted_at. IS NULL LIMIT 10$`).MatchString(sql) {
+		t.Errorf("should explain vars with overridden placeholders, got %v", sql)
+	}
+
+	// executed statements always use the dialector's placeholders
+	if err := DB.Set("gorm:placeholder", gorm.PlaceholderDollar).Where("name = ?", "jinzhu").Find(&[]User{}).Error; err != nil {
+		t.Errorf("placeholder style should not affect execution, got error %v", err)
+	}
+}
+
+func TestCreateTableAs(t *testing.T) {
+	users := []User{{Name: "ctas_user1", Age: 18}, {Name: "ctas_user2", Age: 20}, {Name: "ctas_user3", Age: 30}}
+	DB.Create(&users)
+
+	DB.Migrator().DropTable("user_snapshots")
+	defer DB.Migrator().DropTable("user_snapshots")
+
+	query := DB.Model(&User{}).Select("id", "name", "age").Where("name LIKE ? AND age >= ?", "ctas_user%", 20)
+	if err := DB.Exec("?", clause.CreateTableAs{Table: clause.Table{Name: "user_snapshots"}, Query: query}).Error; err != nil {
+		t.Fatalf("failed to create table as, got error %v", err)
+	}
+
+	var names []string
+	if err := DB.Table("user_snapshots").Order("age").Pluck("name", &names).Error; err != nil {
+		t.Fatalf("failed to query snapshot table, got error %v", err)
+	}
+
+	if strings.Join(names, ",") != "ctas_user2,ctas_user3" {
+		t.Errorf("snapshot table should contain matched users, got %v", names)
+	}
+}
+
+type bindVarDialector struct {
+	gorm.Dialector
+	bindVarTo func(writer clause.Writer, stmt *gorm.Statement, v interface{})
+}
+
+func (d bindVarDialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+	d.bindVarTo(writer, stmt, v)
+}
+
+func TestCheckBindVars(t *testing.T) {
+	writer := &bufferWriter{}
+	db, err := OpenTestConnection(&gorm.Config{
+		CheckBindVars: true,
+		Logger:        logger.New(writer, logger.Config{LogLevel: logger.Error}),
+	})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	user := *GetUser("check_bind_vars", Config{Account: true, Pets: 2})
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("failed to create user, got error %v", err)
+	}
+
+	var users []User
+	subQuery := db.Model(&Pet{}).Select("user_id").Where("name LIKE ?", "check_bind_vars%")
+	if err := db.Where("id IN (?) AND age = ?", subQuery, user.Age).Or("name = @name", sql.Named("name", user.Name)).Find(&users).Error; err != nil {
+		t.Fatalf("failed to query users, got error %v", err)
+	}
+	db.Model(&user).Update("age", 20)
+	db.Raw("SELECT * FROM users WHERE name = ? AND id IN ?", user.Name, []uint{user.ID}).Scan(&users)
+
+	if len(writer.logs) > 0 {
+		t.Fatalf("should not log bind vars errors for consistent placeholders, got %v", writer.logs)
+	}
+
+	dryRunDB := db.Session(&gorm.Session{DryRun: true})
+	dryRunDB.Dialector = bindVarDialector{Dialector: db.Dialector, bindVarTo: DollarBindVarTo}
+	dryRunDB.Where("name = ? AND age IN ?", "jinzhu", []int{18, 20}).Find(&users)
+	dryRunDB.Set("gorm:placeholder", gorm.PlaceholderAtP).Create(&User{Name: "check_bind_vars"})
+	if len(writer.logs) > 0 {
+		t.Fatalf("should not log bind vars errors for numbered placeholders, got %v", writer.logs)
+	}
+
+	dryRunDB.Dialector = bindVarDialector{Dialector: db.Dialector, bindVarTo: func(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+		writer.WriteString("$" + strconv.Itoa(len(stmt.Vars)+1))
+	}}
+	dryRunDB.Where("name = ? AND age = ?", "jinzhu", 18).Find(&users)
+	if len(writer.logs) != 1 || !strings.Contains(writer.logs[0], "placeholder $2 written for var #1") || !strings.Contains(writer.logs[0], "name = $2 AND age = $3") {
+		t.Errorf("should log misnumbered placeholders with the SQL, got %v", writer.logs)
+	}
+
+	writer.logs = nil
+	dryRunDB.Dialector = bindVarDialector{Dialector: db.Dialector, bindVarTo: func(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+		if len(stmt.Vars) > 1 {
+			writer.WriteByte('?')
+		}
+	}}
+	dryRunDB.Where("name = ? AND age = ?", "jinzhu", 18).Find(&users)
+	if len(writer.logs) != 1 || !strings.Contains(writer.logs[0], "1 placeholders written for 2 vars") || !strings.Contains(writer.logs[0], "no placeholder written for var #1") {
+		t.Errorf("should log missing placeholders, got %v", writer.logs)
+	}
+}
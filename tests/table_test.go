@@ -259,3 +259,92 @@ type mockUniqueNamingStrategy struct {
 func (a mockUniqueNamingStrategy) UniqueName(table, column string) string {
 	return a.UName
 }
+
+func TestTableWithSessionNamingStrategy(t *testing.T) {
+	namingDB := DB.Session(&gorm.Session{DryRun: true, NamingStrategy: schema.NamingStrategy{TablePrefix: "legacy_", SingularTable: true}})
+
+	r := namingDB.Find(&User{}).Statement
+	if !regexp.MustCompile("SELECT \\* FROM .legacy_user. WHERE .legacy_user.\\..deleted_at. IS NULL").MatchString(r.Statement.SQL.String()) {
+		t.Errorf("Table with session naming strategy, got %v", r.Statement.SQL.String())
+	}
+
+	r = DB.Session(&gorm.Session{DryRun: true}).Find(&User{}).Statement
+	if !regexp.MustCompile("SELECT \\* FROM .users. WHERE .users.\\..deleted_at. IS NULL").MatchString(r.Statement.SQL.String()) {
+		t.Errorf("Session naming strategy should not leak into cached schemas, got %v", r.Statement.SQL.String())
+	}
+
+	namer := schema.NamingStrategy{TablePrefix: "legacy_", SingularTable: true}
+	first := DB.Session(&gorm.Session{DryRun: true, NamingStrategy: namer}).Model(&User{}).Find(&User{}).Statement.Schema
+	second := DB.Session(&gorm.Session{DryRun: true, NamingStrategy: namer}).Model(&User{}).Find(&User{}).Statement.Schema
+	if first != second {
+		t.Errorf("Sessions with the same naming strategy should share cached schemas")
+	}
+}
+
+type ShardedEvent struct {
+	ID    uint
+	Name  string
+	Shard string `gorm:"-"`
+}
+
+func (e ShardedEvent) TableName() string {
+	return "sharded_events"
+}
+
+func (e ShardedEvent) DynamicTableName() string {
+	if e.Shard == "" {
+		return e.TableName()
+	}
+	return "sharded_events_" + e.Shard
+}
+
+// StaticShardedEvent TableName depends on the instance without implementing schema.DynamicTabler
+type StaticShardedEvent struct {
+	ID    uint
+	Shard string `gorm:"-"`
+}
+
+func (e StaticShardedEvent) TableName() string {
+	if e.Shard == "" {
+		return "static_sharded_events"
+	}
+	return "static_sharded_events_" + e.Shard
+}
+
+func TestTableWithDynamicTableName(t *testing.T) {
+	for _, shard := range []string{"a", "b"} {
+		event := ShardedEvent{Shard: shard}
+		DB.Migrator().DropTable(&event)
+		if err := DB.AutoMigrate(&event); err != nil {
+			t.Fatalf("failed to migrate %v, got error %v", event.DynamicTableName(), err)
+		}
+	}
+
+	for _, event := range []*ShardedEvent{{Shard: "a", Name: "event_a"}, {Shard: "b", Name: "event_b"}, {Shard: "b", Name: "event_b2"}} {
+		if err := DB.Create(event).Error; err != nil {
+			t.Fatalf("failed to create event in shard %v, got error %v", event.Shard, err)
+		}
+	}
+
+	var countA, countB int64
+	DB.Model(&ShardedEvent{Shard: "a"}).Count(&countA)
+	DB.Model(&ShardedEvent{Shard: "b"}).Count(&countB)
+	if countA != 1 || countB != 2 {
+		t.Errorf("events should be created in its own table, got %v, %v", countA, countB)
+	}
+
+	stmt := DB.Session(&gorm.Session{DryRun: true}).Find(&ShardedEvent{Shard: "b"}).Statement
+	if !regexp.MustCompile("SELECT \\* FROM .sharded_events_b.").MatchString(stmt.SQL.String()) || stmt.Schema.Table != "sharded_events_b" {
+		t.Errorf("should use dynamic table name, got %v, %v", stmt.SQL.String(), stmt.Schema.Table)
+	}
+
+	stmt = DB.Session(&gorm.Session{DryRun: true}).Find(&ShardedEvent{Shard: "a"}).Statement
+	if stmt.Schema.Table != "sharded_events_a" {
+		t.Errorf("schemas of tables should not collide, got %v", stmt.Schema.Table)
+	}
+
+	stmt = DB.Session(&gorm.Session{DryRun: true}).Find(&StaticShardedEvent{Shard: "a"}).Statement
+	if stmt.Schema.Table != "static_sharded_events" {
+		t.Errorf("table name should be static without DynamicTableName, got %v", stmt.Schema.Table)
+	}
+}
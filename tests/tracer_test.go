@@ -2,9 +2,14 @@ package tests_test
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"testing"
 	"time"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	. "gorm.io/gorm/utils/tests"
 )
 
 type Tracer struct {
@@ -32,3 +37,40 @@ func (S Tracer) Trace(ctx context.Context, begin time.Time, fc func() (sql strin
 	S.Logger.Trace(ctx, begin, fc, err)
 	S.Test(ctx, begin, fc, err)
 }
+
+type bufferWriter struct {
+	logs []string
+}
+
+func (w *bufferWriter) Printf(format string, args ...interface{}) {
+	w.logs = append(w.logs, fmt.Sprintf(format, args...))
+}
+
+func TestWithOperation(t *testing.T) {
+	writer := &bufferWriter{}
+	var operation string
+	db := DB.Session(&gorm.Session{Logger: Tracer{
+		Logger: logger.New(writer, logger.Config{LogLevel: logger.Info}),
+		Test: func(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+			operation = logger.OperationFromContext(ctx)
+		},
+	}})
+
+	if err := db.WithOperation("CreateOrder").Create(GetUser("with_operation", Config{})).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	if operation != "CreateOrder" {
+		t.Errorf("operation should be available in context, got %v", operation)
+	}
+
+	if len(writer.logs) == 0 || !strings.Contains(writer.logs[len(writer.logs)-1], "[op:CreateOrder]") {
+		t.Errorf("trace log should include operation, got %v", writer.logs)
+	}
+
+	writer.logs = nil
+	db.Where("name = ?", "with_operation").First(&User{})
+	if operation != "" || len(writer.logs) == 0 || strings.Contains(writer.logs[0], "[op:") {
+		t.Errorf("operation should not leak to other operations, got %v, %v", operation, writer.logs)
+	}
+}
@@ -2,6 +2,7 @@ package tests_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -490,3 +491,140 @@ func TestTransactionWithDefaultTimeout(t *testing.T) {
 		t.Errorf("should return error when transaction timeout, got error %v", err)
 	}
 }
+
+type isolationRecorder struct {
+	*sql.DB
+	opts *sql.TxOptions
+}
+
+func (r *isolationRecorder) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	r.opts = opts
+	return r.DB.BeginTx(ctx, &sql.TxOptions{})
+}
+
+func TestTransactionWithIsolation(t *testing.T) {
+	sqlDB, err := DB.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql.DB, got error %v", err)
+	}
+
+	recorder := &isolationRecorder{DB: sqlDB}
+	db := DB.Session(&gorm.Session{Context: context.Background()})
+	db.Statement.ConnPool = recorder
+
+	if err := db.Isolation(sql.LevelSerializable).Transaction(func(tx *gorm.DB) error {
+		return tx.First(&User{}).Error
+	}); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("failed to run transaction, got error %v", err)
+	}
+
+	if recorder.opts == nil || recorder.opts.Isolation != sql.LevelSerializable {
+		t.Errorf("isolation level should be serializable, got %+v", recorder.opts)
+	}
+
+	recorder.opts = nil
+	if err := db.Isolation(sql.LevelSerializable).Transaction(func(tx *gorm.DB) error {
+		return nil
+	}, &sql.TxOptions{ReadOnly: true}); err != nil {
+		t.Fatalf("failed to run transaction, got error %v", err)
+	}
+
+	if recorder.opts == nil || recorder.opts.Isolation != sql.LevelSerializable || !recorder.opts.ReadOnly {
+		t.Errorf("isolation level should be merged into tx options, got %+v", recorder.opts)
+	}
+
+	recorder.opts = nil
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to run transaction, got error %v", err)
+	}
+
+	if recorder.opts != nil {
+		t.Errorf("isolation level should not leak into other transactions, got %+v", recorder.opts)
+	}
+}
+
+func TestReadOnlyTransaction(t *testing.T) {
+	sqlDB, err := DB.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql.DB, got error %v", err)
+	}
+
+	user := *GetUser("read_only_transaction", Config{})
+	DB.Create(&user)
+
+	recorder := &isolationRecorder{DB: sqlDB}
+	db := DB.Session(&gorm.Session{Context: context.Background()})
+	db.Statement.ConnPool = recorder
+
+	if err := db.ReadOnlyTransaction(func(tx *gorm.DB) error {
+		var result User
+		if err := tx.First(&result, user.ID).Error; err != nil {
+			t.Errorf("should be able to query in read-only transaction, got error %v", err)
+		}
+
+		if err := tx.Create(GetUser("read_only_transaction_create", Config{})).Error; !errors.Is(err, gorm.ErrReadOnlyTransaction) {
+			t.Errorf("create should fail in read-only transaction, got error %v", err)
+		}
+
+		if err := tx.Model(&result).Update("name", "read_only_transaction_update").Error; !errors.Is(err, gorm.ErrReadOnlyTransaction) {
+			t.Errorf("update should fail in read-only transaction, got error %v", err)
+		}
+
+		if err := tx.Delete(&result).Error; !errors.Is(err, gorm.ErrReadOnlyTransaction) {
+			t.Errorf("delete should fail in read-only transaction, got error %v", err)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to run read-only transaction, got error %v", err)
+	}
+
+	if recorder.opts == nil || !recorder.opts.ReadOnly {
+		t.Errorf("transaction should be begun with read-only option, got %+v", recorder.opts)
+	}
+
+	var result User
+	if err := DB.First(&result, user.ID).Error; err != nil || result.Name != user.Name {
+		t.Errorf("user should not be changed, got %+v, error %v", result, err)
+	}
+
+	if err := DB.Model(&result).Update("name", "read_only_transaction_after").Error; err != nil {
+		t.Errorf("read-only should not leak out of the transaction, got error %v", err)
+	}
+
+	if err := DB.Transaction(func(tx *gorm.DB) error {
+		return tx.ReadOnlyTransaction(func(tx *gorm.DB) error {
+			return tx.Create(GetUser("read_only_transaction_nested", Config{})).Error
+		})
+	}); !errors.Is(err, gorm.ErrReadOnlyTransaction) {
+		t.Errorf("create should fail in nested read-only transaction, got error %v", err)
+	}
+
+	recorder = &isolationRecorder{DB: sqlDB}
+	db = DB.Session(&gorm.Session{Context: context.Background()})
+	db.Statement.ConnPool = recorder
+	db.Dialector = readOnlyOptionlessDialector{Dialector: DB.Dialector}
+	if err := db.ReadOnlyTransaction(func(tx *gorm.DB) error {
+		return tx.First(&User{}, user.ID).Error
+	}); err != nil {
+		t.Fatalf("failed to run read-only transaction, got error %v", err)
+	}
+
+	if recorder.opts != nil && recorder.opts.ReadOnly {
+		t.Errorf("transaction should not be begun with read-only option if the dialector rejects it, got %+v", recorder.opts)
+	}
+}
+
+// readOnlyOptionlessDialector dialector whose driver rejects sql.TxOptions with ReadOnly
+type readOnlyOptionlessDialector struct {
+	gorm.Dialector
+}
+
+func (readOnlyOptionlessDialector) SupportReadOnlyTxOption() bool {
+	return false
+}
+
+func (readOnlyOptionlessDialector) ReadOnlyTransactionSQL() string {
+	return ""
+}
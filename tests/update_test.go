@@ -931,3 +931,111 @@ func TestUpdateFrom(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateWithOnUpdateExpression(t *testing.T) {
+	type OnUpdateUser struct {
+		ID       uint
+		Name     string
+		HitCount int `gorm:"onUpdate:hit_count + 1"`
+	}
+
+	DB.Migrator().DropTable(&OnUpdateUser{})
+	if err := DB.AutoMigrate(&OnUpdateUser{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	user := OnUpdateUser{Name: "on_update"}
+	DB.Create(&user)
+
+	DB.Model(&user).Update("name", "on_update_1")
+	DB.Model(&user).Updates(OnUpdateUser{Name: "on_update_2"})
+	DB.Model(&user).Updates(map[string]interface{}{"name": "on_update_3"})
+	DB.Save(&user)
+
+	var result OnUpdateUser
+	DB.First(&result, user.ID)
+	if result.HitCount != 4 {
+		t.Errorf("hit count should be increased on every update, got %v", result.HitCount)
+	}
+
+	DB.Model(&user).Updates(map[string]interface{}{"hit_count": 10})
+	DB.Model(&user).UpdateColumn("name", "on_update_4")
+	DB.Model(&user).Omit("hit_count").Update("name", "on_update_5")
+	DB.First(&result, user.ID)
+	if result.HitCount != 10 || result.Name != "on_update_5" {
+		t.Errorf("hit count should be the explicitly updated value, got %+v", result)
+	}
+}
+
+func TestUpdatesWithAssignmentIfNull(t *testing.T) {
+	users := []*User{GetUser("update_if_null_1", Config{}), GetUser("update_if_null_2", Config{})}
+	users[1].Age = 20
+	DB.Create(&users)
+	DB.Model(users[0]).Update("age", nil)
+
+	result := DB.Model(&User{}).Where("name IN ?", []string{"update_if_null_1", "update_if_null_2"}).Updates(clause.AssignmentsIfNull(map[string]interface{}{"age": 18}))
+	if result.Error != nil || result.RowsAffected != 2 {
+		t.Fatalf("failed to update, rows affected %v, got error %v", result.RowsAffected, result.Error)
+	}
+
+	var results []User
+	DB.Where("name IN ?", []string{"update_if_null_1", "update_if_null_2"}).Order("name").Find(&results)
+	if len(results) != 2 || results[0].Age != 18 || results[1].Age != 20 {
+		t.Errorf("should only fill in the NULL column, got %+v", results)
+	}
+
+	if results[0].UpdatedAt.Before(users[0].UpdatedAt) {
+		t.Errorf("should update UpdatedAt, got %v", results[0].UpdatedAt)
+	}
+
+	DB.Model(results[1]).Update("active", nil)
+	DB.Model(results[1]).Updates(clause.Set{clause.AssignmentIfNull("active", true), clause.AssignmentIfNull("age", 30)})
+
+	var user User
+	DB.First(&user, results[1].ID)
+	if !user.Active || user.Age != 20 {
+		t.Errorf("should only fill in the NULL column, got %+v", user)
+	}
+}
+
+func TestUpdateInBulk(t *testing.T) {
+	users := []*User{GetUser("update_in_bulk_1", Config{}), GetUser("update_in_bulk_2", Config{}), GetUser("update_in_bulk_3", Config{})}
+	users[1].Age = 20
+	users[2].Age = 30
+	DB.Create(&users)
+
+	result := DB.Model(&User{}).UpdateInBulk([]clause.KeyedAssignments{
+		{Key: users[0].ID, Values: map[string]interface{}{"name": "update_in_bulk_1_new", "age": 11}},
+		{Key: users[1].ID, Values: map[string]interface{}{"name": "update_in_bulk_2_new"}},
+	})
+	if result.Error != nil || result.RowsAffected != 2 {
+		t.Fatalf("failed to update in bulk, rows affected %v, got error %v", result.RowsAffected, result.Error)
+	}
+
+	var results []User
+	DB.Where("id IN ?", []uint{users[0].ID, users[1].ID, users[2].ID}).Order("id").Find(&results)
+	if len(results) != 3 || results[0].Name != "update_in_bulk_1_new" || results[0].Age != 11 ||
+		results[1].Name != "update_in_bulk_2_new" || results[1].Age != 20 || results[2].Name != "update_in_bulk_3" || results[2].Age != 30 {
+		t.Errorf("should update rows with their own values, got %+v", results)
+	}
+
+	stmt := DB.Session(&gorm.Session{DryRun: true}).Model(&User{}).UpdateInBulk([]clause.KeyedAssignments{
+		{Key: 1, Values: map[string]interface{}{"name": "a"}},
+		{Key: 2, Values: map[string]interface{}{"name": "b"}},
+	}).Statement
+	if !regexp.MustCompile(`.name.=CASE .users.\..id. WHEN .+ THEN .+ WHEN .+ THEN .+ ELSE .name. END`).MatchString(stmt.SQL.String()) {
+		t.Errorf("should build CASE based bulk update, got %v", stmt.SQL.String())
+	}
+
+	if len(stmt.Vars) < 6 {
+		t.Errorf("all values should be parameterized, got %v", stmt.Vars)
+	}
+
+	if err := DB.Model(&User{}).UpdateInBulk(nil).Error; !errors.Is(err, gorm.ErrEmptySlice) {
+		t.Errorf("should return error for empty input, got %v", err)
+	}
+
+	if err := DB.Table("users").UpdateInBulk([]clause.KeyedAssignments{{Key: 1, Values: map[string]interface{}{"name": "a"}}}).Error; !errors.Is(err, gorm.ErrModelValueRequired) {
+		t.Errorf("should return error without model, got %v", err)
+	}
+}
@@ -1,7 +1,10 @@
 package tests_test
 
 import (
+	"errors"
+	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -326,3 +329,475 @@ func TestUpdateWithMissWhere(t *testing.T) {
 		t.Fatalf("invalid updating SQL, got %v", tx.Statement.SQL.String())
 	}
 }
+
+type mergeDialector struct {
+	DummyDialector
+}
+
+func (mergeDialector) SupportMerge() bool {
+	return true
+}
+
+type noDoNothingMergeDialector struct {
+	mergeDialector
+}
+
+func (noDoNothingMergeDialector) SupportMergeDoNothing() bool {
+	return false
+}
+
+func TestUpsertWithMerge(t *testing.T) {
+	lang := Language{Code: "upsert_merge", Name: "Merge"}
+	if err := DB.Clauses(clause.Merge{}).Create(&lang).Error; err != nil {
+		t.Fatalf("failed to upsert with merge, got %v", err)
+	}
+
+	lang.Name = "Merge-Newname"
+	if err := DB.Clauses(clause.Merge{On: []clause.Column{{Name: "code"}}}).Create(&lang).Error; err != nil {
+		t.Fatalf("failed to upsert with merge, got %v", err)
+	}
+
+	var langs []Language
+	if err := DB.Find(&langs, "code = ?", lang.Code).Error; err != nil {
+		t.Errorf("no error should happen when find languages with code, but got %v", err)
+	} else if len(langs) != 1 || langs[0].Name != lang.Name {
+		t.Errorf("should update name when merge fall back to on conflict, but got %+v", langs)
+	}
+
+	r := DB.Session(&gorm.Session{DryRun: true}).Clauses(clause.Merge{
+		On:   []clause.Column{{Name: "code"}},
+		When: []clause.MergeWhen{{Matched: true, DoNothing: true}, {}},
+	}).Create(&Language{Code: "upsert_merge", Name: "Merge"})
+	if !regexp.MustCompile(`INTO .languages. .*\(.code.,.name.\) .* DO NOTHING`).MatchString(r.Statement.SQL.String()) {
+		t.Errorf("merge should fall back to on conflict, got %v", r.Statement.SQL.String())
+	}
+
+	mergeDB, err := gorm.Open(mergeDialector{}, &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("failed to open merge db, got %v", err)
+	}
+
+	r = mergeDB.Clauses(clause.Merge{On: []clause.Column{{Name: "code"}}}).Create(&Language{Code: "upsert_merge", Name: "Merge"})
+	if r.Error != nil {
+		t.Fatalf("failed to build merge, got %v", r.Error)
+	}
+
+	expected := "MERGE INTO `languages` USING (VALUES (?,?)) AS `excluded` (`code`,`name`) ON `languages`.`code` = `excluded`.`code` WHEN MATCHED THEN UPDATE SET `name`=`excluded`.`name` WHEN NOT MATCHED THEN INSERT (`code`,`name`) VALUES (`excluded`.`code`,`excluded`.`name`)"
+	if sql := r.Statement.SQL.String(); sql != expected {
+		t.Errorf("expects merge sql %v, got %v", expected, sql)
+	}
+
+	// the source alias is rewritten to excluded when falling back to on conflict
+	r = DB.Session(&gorm.Session{DryRun: true}).Clauses(clause.Merge{
+		Alias: "src",
+		On:    []clause.Column{{Name: "code"}},
+		When: []clause.MergeWhen{{
+			Matched:   true,
+			Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "? <> ?", Vars: []interface{}{clause.Column{Table: "src", Name: "name"}, ""}}}},
+			DoUpdates: clause.Set{{Column: clause.Column{Name: "name"}, Value: clause.Column{Table: "src", Name: "name"}}},
+		}, {}},
+	}).Create(&Language{Code: "upsert_merge", Name: "Merge"})
+	if sql := r.Statement.SQL.String(); strings.Contains(sql, "src") || !regexp.MustCompile(`DO UPDATE SET .name.=.excluded.\..name. WHERE .excluded.\..name. <> `).MatchString(sql) {
+		t.Errorf("source alias should be rewritten to excluded, got %v", sql)
+	}
+
+	noDoNothingDB, err := gorm.Open(noDoNothingMergeDialector{}, &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("failed to open merge db, got %v", err)
+	}
+
+	r = noDoNothingDB.Clauses(clause.Merge{
+		On:   []clause.Column{{Name: "code"}},
+		When: []clause.MergeWhen{{Matched: true, DoNothing: true}, {}},
+	}).Create(&Language{Code: "upsert_merge", Name: "Merge"})
+	if !errors.Is(r.Error, gorm.ErrUnsupportedDriver) {
+		t.Errorf("DO NOTHING should be rejected if the dialector doesn't support it, got %v", r.Error)
+	}
+}
+
+func TestUpsertWithUpdateProvided(t *testing.T) {
+	type ProvidedLanguage struct {
+		Code string `gorm:"primarykey"`
+		Name string
+		Rank int
+	}
+
+	r := DB.Session(&gorm.Session{DryRun: true}).Clauses(clause.OnConflict{UpdateProvided: true}).Create(&ProvidedLanguage{Code: "provided", Name: "Provided"})
+	if !regexp.MustCompile(`INTO .provided_languages. .*\(.code.,.name.,.rank.\) .* (SET|UPDATE) .name.=.*.name.\W*$`).MatchString(r.Statement.SQL.String()) {
+		t.Errorf("should only update provided columns, got %v", r.Statement.SQL.String())
+	}
+
+	r = DB.Session(&gorm.Session{DryRun: true}).Clauses(clause.OnConflict{UpdateProvided: true}).Create(&[]ProvidedLanguage{{Code: "provided", Name: "Provided"}, {Code: "provided2", Rank: 1}})
+	if !regexp.MustCompile(`(SET|UPDATE) .name.=.*.name.,.rank.=.*.rank.\W*$`).MatchString(r.Statement.SQL.String()) {
+		t.Errorf("should update columns provided in any rows, got %v", r.Statement.SQL.String())
+	}
+
+	r = DB.Session(&gorm.Session{DryRun: true}).Clauses(clause.OnConflict{UpdateProvided: true}).Create(&ProvidedLanguage{Code: "provided"})
+	if !regexp.MustCompile(`DO NOTHING`).MatchString(r.Statement.SQL.String()) {
+		t.Errorf("should do nothing when no columns provided, got %v", r.Statement.SQL.String())
+	}
+
+	r = DB.Session(&gorm.Session{DryRun: true}).Clauses(clause.OnConflict{UpdateProvided: true}).Model(&ProvidedLanguage{}).Create(map[string]interface{}{"code": "provided", "rank": 0})
+	if !regexp.MustCompile(`(SET|UPDATE) .rank.=.*.rank.\W*$`).MatchString(r.Statement.SQL.String()) {
+		t.Errorf("should update all columns of map, got %v", r.Statement.SQL.String())
+	}
+
+	DB.Migrator().DropTable(&ProvidedLanguage{})
+	if err := DB.AutoMigrate(&ProvidedLanguage{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	DB.Create(&ProvidedLanguage{Code: "provided", Name: "Provided", Rank: 3})
+	if err := DB.Clauses(clause.OnConflict{UpdateProvided: true}).Create(&ProvidedLanguage{Code: "provided", Name: "Provided-New"}).Error; err != nil {
+		t.Fatalf("failed to upsert, got error %v", err)
+	}
+
+	var result ProvidedLanguage
+	if err := DB.First(&result, "code = ?", "provided").Error; err != nil || result.Name != "Provided-New" || result.Rank != 3 {
+		t.Errorf("should keep columns not provided, got %+v, error %v", result, err)
+	}
+}
+
+func TestUpsertWithOmit(t *testing.T) {
+	type OmitLanguage struct {
+		Code      string `gorm:"primarykey"`
+		Name      string
+		Rank      int
+		CreatedBy string
+	}
+
+	r := DB.Session(&gorm.Session{DryRun: true}).Clauses(clause.OnConflict{UpdateAll: true, Omit: []string{"created_by", "Rank"}}).Create(&OmitLanguage{Code: "omit", Name: "Omit", Rank: 1, CreatedBy: "jinzhu"})
+	if !regexp.MustCompile(`(SET|UPDATE) .name.=.*.name.\W*$`).MatchString(r.Statement.SQL.String()) {
+		t.Errorf("should not update omitted columns, got %v", r.Statement.SQL.String())
+	}
+
+	DB.Migrator().DropTable(&OmitLanguage{})
+	if err := DB.AutoMigrate(&OmitLanguage{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	DB.Create(&OmitLanguage{Code: "omit", Name: "Omit", Rank: 1, CreatedBy: "jinzhu"})
+	if err := DB.Clauses(clause.OnConflict{UpdateAll: true, Omit: []string{"created_by", "Rank"}}).Create(&OmitLanguage{Code: "omit", Name: "Omit-New", Rank: 2, CreatedBy: "someone"}).Error; err != nil {
+		t.Fatalf("failed to upsert, got error %v", err)
+	}
+
+	var result OmitLanguage
+	if err := DB.First(&result, "code = ?", "omit").Error; err != nil || result.Name != "Omit-New" || result.Rank != 1 || result.CreatedBy != "jinzhu" {
+		t.Errorf("omitted columns should stay untouched, got %+v, error %v", result, err)
+	}
+
+	r = DB.Session(&gorm.Session{DryRun: true}).Clauses(clause.OnConflict{UpdateAll: true, Omit: []string{"name", "rank", "created_by"}}).Create(&OmitLanguage{Code: "omit"})
+	if !regexp.MustCompile(`DO NOTHING`).MatchString(r.Statement.SQL.String()) {
+		t.Errorf("should do nothing when all columns omitted, got %v", r.Statement.SQL.String())
+	}
+}
+
+func TestUpsertRecordSkippedRows(t *testing.T) {
+	if name := DB.Dialector.Name(); name != "sqlite" && name != "postgres" {
+		t.Skip("skip as returning is not supported")
+	}
+
+	type SkippedLanguage struct {
+		ID   uint
+		Code string `gorm:"unique"`
+		Name string
+	}
+
+	DB.Migrator().DropTable(&SkippedLanguage{})
+	if err := DB.AutoMigrate(&SkippedLanguage{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	if err := DB.Create(&SkippedLanguage{Code: "skipped-b", Name: "B"}).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	langs := []SkippedLanguage{{Code: "skipped-a", Name: "A"}, {Code: "skipped-b", Name: "B2"}, {Code: "skipped-c", Name: "C"}, {Code: "skipped-c", Name: "C2"}}
+	onConflict := clause.OnConflict{Columns: []clause.Column{{Name: "code"}}, DoNothing: true}
+
+	r := DB.Session(&gorm.Session{DryRun: true}).Set("gorm:record_skipped_rows", true).Clauses(onConflict).Create(&[]SkippedLanguage{{Code: "dry-run"}})
+	if !regexp.MustCompile(`RETURNING .id.,.code.$`).MatchString(r.Statement.SQL.String()) {
+		t.Errorf("conflict columns should be returned, got %v", r.Statement.SQL.String())
+	}
+
+	tx := DB.Set("gorm:record_skipped_rows", true).Clauses(onConflict).Create(&langs)
+	if tx.Error != nil {
+		t.Fatalf("failed to create, got error %v", tx.Error)
+	}
+
+	if tx.RowsAffected != 2 {
+		t.Errorf("rows affected should be 2, got %v", tx.RowsAffected)
+	}
+
+	if langs[0].ID == 0 || langs[2].ID == 0 || langs[1].ID != 0 || langs[3].ID != 0 {
+		t.Errorf("returned ids should be assigned to the inserted rows, got %+v", langs)
+	}
+
+	skipped, ok := tx.Get("gorm:skipped_rows")
+	if !ok {
+		t.Fatalf("skipped rows should be recorded")
+	}
+
+	if rows, ok := skipped.([]SkippedLanguage); !ok || len(rows) != 2 || rows[0].Name != "B2" || rows[1].Name != "C2" {
+		t.Errorf("skipped rows should be B2 and C2, got %#v", skipped)
+	}
+
+	lang := SkippedLanguage{Code: "skipped-a", Name: "A2"}
+	tx = DB.Set("gorm:record_skipped_rows", true).Clauses(onConflict).Create(&lang)
+	if skipped, _ := tx.Get("gorm:skipped_rows"); tx.Error != nil || lang.ID != 0 || len(skipped.([]SkippedLanguage)) != 1 {
+		t.Errorf("the struct should be skipped, got %#v, error %v", skipped, tx.Error)
+	}
+}
+
+func TestUpsertWithResolve(t *testing.T) {
+	type ResolvedLanguage struct {
+		Code    string `gorm:"primarykey"`
+		Name    string
+		Version int
+	}
+
+	DB.Migrator().DropTable(&ResolvedLanguage{})
+	if err := DB.AutoMigrate(&ResolvedLanguage{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	DB.Create(&[]ResolvedLanguage{{Code: "resolve_1", Name: "Old1", Version: 5}, {Code: "resolve_2", Name: "Old2", Version: 1}})
+
+	var resolved []string
+	// last writer wins with higher version
+	onConflict := clause.OnConflict{Resolve: func(incoming, existing interface{}) error {
+		in, ex := incoming.(*ResolvedLanguage), existing.(*ResolvedLanguage)
+		resolved = append(resolved, in.Code)
+		if ex.Version > in.Version {
+			*in = *ex
+		}
+		return nil
+	}}
+
+	langs := []ResolvedLanguage{{Code: "resolve_1", Name: "New1", Version: 3}, {Code: "resolve_2", Name: "New2", Version: 2}, {Code: "resolve_3", Name: "New3", Version: 1}}
+	if err := DB.Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(onConflict).Create(&langs).Error
+	}); err != nil {
+		t.Fatalf("failed to upsert with resolve, got error %v", err)
+	}
+
+	if len(resolved) != 2 {
+		t.Errorf("should resolve 2 conflicting rows, got %v", resolved)
+	}
+
+	var results []ResolvedLanguage
+	DB.Order("code").Find(&results)
+	expects := []ResolvedLanguage{{Code: "resolve_1", Name: "Old1", Version: 5}, {Code: "resolve_2", Name: "New2", Version: 2}, {Code: "resolve_3", Name: "New3", Version: 1}}
+	if !reflect.DeepEqual(results, expects) {
+		t.Errorf("expects %+v, got %+v", expects, results)
+	}
+
+	lang := ResolvedLanguage{Code: "resolve_3", Name: "New3", Version: 0}
+	err := DB.Clauses(clause.OnConflict{Resolve: func(incoming, existing interface{}) error {
+		return errors.New("conflicts")
+	}}).Create(&lang).Error
+	if err == nil || err.Error() != "conflicts" {
+		t.Errorf("should return resolver's error, got %v", err)
+	}
+}
+
+func TestCreateOrGet(t *testing.T) {
+	type CreateOrGetUser struct {
+		ID    uint
+		Email string `gorm:"unique"`
+		Name  string
+	}
+
+	DB.Migrator().DropTable(&CreateOrGetUser{})
+	if err := DB.AutoMigrate(&CreateOrGetUser{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	user := CreateOrGetUser{Email: "create_or_get@example.org", Name: "first"}
+	if result := DB.CreateOrGet(&user); result.Error != nil || result.RowsAffected != 1 || user.ID == 0 {
+		t.Fatalf("failed to create, got error %v, rows affected %v, id %v", result.Error, result.RowsAffected, user.ID)
+	}
+
+	existing := CreateOrGetUser{Email: "create_or_get@example.org", Name: "second"}
+	if result := DB.CreateOrGet(&existing); result.Error != nil || result.RowsAffected != 0 {
+		t.Fatalf("failed to get existing row, got error %v, rows affected %v", result.Error, result.RowsAffected)
+	}
+
+	if !reflect.DeepEqual(existing, user) {
+		t.Errorf("should load existing row, expects %+v, got %+v", user, existing)
+	}
+
+	existing = CreateOrGetUser{Email: "create_or_get@example.org", Name: "third"}
+	if err := DB.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "email"}}}).CreateOrGet(&existing).Error; err != nil || existing.ID != user.ID || existing.Name != "first" {
+		t.Errorf("should load existing row with conflict columns, got error %v, row %+v", err, existing)
+	}
+
+	var count int64
+	if DB.Model(&CreateOrGetUser{}).Count(&count); count != 1 {
+		t.Errorf("should only create one row, got %v", count)
+	}
+}
+
+func TestCreateOrGetByKeys(t *testing.T) {
+	type MultiKeyUser struct {
+		ID       uint
+		Email    string `gorm:"unique"`
+		TenantID uint   `gorm:"uniqueIndex:idx_multi_key_users_tenant_username"`
+		Username string `gorm:"uniqueIndex:idx_multi_key_users_tenant_username;size:64"`
+		Name     string
+	}
+
+	DB.Migrator().DropTable(&MultiKeyUser{})
+	if err := DB.AutoMigrate(&MultiKeyUser{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	user := MultiKeyUser{Email: "multi_key@example.org", TenantID: 1, Username: "multi_key", Name: "first"}
+	if result := DB.CreateOrGetByKeys(&user); result.Error != nil || result.RowsAffected != 1 || user.ID == 0 {
+		t.Fatalf("failed to create, got error %v, rows affected %v, id %v", result.Error, result.RowsAffected, user.ID)
+	}
+
+	// conflicts on the tenant and username, but not the email
+	existing := MultiKeyUser{Email: "multi_key_new@example.org", TenantID: 1, Username: "multi_key", Name: "second"}
+	if result := DB.CreateOrGetByKeys(&existing); result.Error != nil || result.RowsAffected != 0 {
+		t.Fatalf("failed to get existing row, got error %v, rows affected %v", result.Error, result.RowsAffected)
+	}
+
+	if !reflect.DeepEqual(existing, user) {
+		t.Errorf("should load existing row, expects %+v, got %+v", user, existing)
+	}
+
+	existing = MultiKeyUser{Email: "multi_key@example.org", TenantID: 2, Username: "multi_key", Name: "third"}
+	if err := DB.CreateOrGetByKeys(&existing, []string{"email"}, []string{"TenantID", "Username"}).Error; err != nil || existing.ID != user.ID || existing.Name != "first" {
+		t.Errorf("should load existing row with keys, got error %v, row %+v", err, existing)
+	}
+
+	another := MultiKeyUser{Email: "multi_key_another@example.org", TenantID: 2, Username: "multi_key", Name: "another"}
+	if result := DB.CreateOrGetByKeys(&another); result.Error != nil || result.RowsAffected != 1 || another.ID == 0 {
+		t.Fatalf("failed to create another row, got error %v, rows affected %v", result.Error, result.RowsAffected)
+	}
+
+	ambiguous := MultiKeyUser{Email: "multi_key@example.org", TenantID: 2, Username: "multi_key"}
+	if err := DB.CreateOrGetByKeys(&ambiguous).Error; !errors.Is(err, gorm.ErrInvalidData) {
+		t.Errorf("should return error when rows of different keys found, got %v", err)
+	}
+
+	if err := DB.CreateOrGetByKeys(&MultiKeyUser{Email: "multi_key_unknown@example.org"}, []string{"unknown"}).Error; !errors.Is(err, gorm.ErrInvalidField) {
+		t.Errorf("should return error for unknown key, got %v", err)
+	}
+
+	var count int64
+	if DB.Model(&MultiKeyUser{}).Count(&count); count != 2 {
+		t.Errorf("should only create two rows, got %v", count)
+	}
+}
+
+func TestUpsertWithGreatestAndLeast(t *testing.T) {
+	type HighWaterMark struct {
+		ID      uint
+		Name    string `gorm:"unique;size:64"`
+		Highest int
+		Lowest  int
+	}
+
+	DB.Migrator().DropTable(&HighWaterMark{})
+	if err := DB.AutoMigrate(&HighWaterMark{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.Set{clause.AssignmentGreatest("highest"), clause.AssignmentLeast("lowest")},
+	}
+
+	for _, value := range []int{10, 5, 20, 15} {
+		if err := DB.Clauses(onConflict).Create(&HighWaterMark{Name: "greatest_least", Highest: value, Lowest: value}).Error; err != nil {
+			t.Fatalf("failed to upsert, got error %v", err)
+		}
+	}
+
+	var result HighWaterMark
+	if err := DB.First(&result, "name = ?", "greatest_least").Error; err != nil || result.Highest != 20 || result.Lowest != 5 {
+		t.Errorf("should keep the greatest and least values, got %+v, error %v", result, err)
+	}
+}
+
+func TestUpsertKeepExistingOnNull(t *testing.T) {
+	type Contact struct {
+		ID    uint
+		Email string `gorm:"unique;size:64"`
+		Phone *string
+		Note  *string `gorm:"not null;default:''"`
+	}
+
+	DB.Migrator().DropTable(&Contact{})
+	if err := DB.AutoMigrate(&Contact{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	phone, note := "123", "vip"
+	if err := DB.Create(&Contact{Email: "keep@on.null", Phone: &phone, Note: &note}).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	emptyNote := ""
+	onConflict := clause.OnConflict{Columns: []clause.Column{{Name: "email"}}, UpdateAll: true, KeepExistingOnNull: true}
+	stmt := DB.Session(&gorm.Session{DryRun: true}).Clauses(onConflict).Create(&Contact{Email: "keep@on.null", Note: &emptyNote}).Statement
+	excludedPhone, excludedNote := `.excluded.\..phone.`, `.excluded.\..note.`
+	if isMysql() {
+		excludedPhone, excludedNote = `VALUES\(.phone.\)`, `VALUES\(.note.\)`
+	}
+	if sql := stmt.SQL.String(); !regexp.MustCompile(`.phone.=COALESCE\(`+excludedPhone+`,.contacts.\..phone.\)`).MatchString(sql) ||
+		!regexp.MustCompile(`.note.=`+excludedNote).MatchString(sql) {
+		t.Errorf("nullable columns should keep existing values, got %v", sql)
+	}
+
+	if err := DB.Clauses(onConflict).Create(&Contact{Email: "keep@on.null", Note: &emptyNote}).Error; err != nil {
+		t.Fatalf("failed to upsert, got error %v", err)
+	}
+
+	var result Contact
+	if err := DB.First(&result, "email = ?", "keep@on.null").Error; err != nil || result.Phone == nil || *result.Phone != "123" {
+		t.Errorf("phone should be kept, got %+v, error %v", result, err)
+	} else if result.Note == nil || *result.Note != "" {
+		t.Errorf("note should be overwritten, got %+v", result.Note)
+	}
+}
+
+func TestUpsertWithWhere(t *testing.T) {
+	type VersionedDoc struct {
+		ID      uint
+		Title   string
+		Version int
+	}
+
+	DB.Migrator().DropTable(&VersionedDoc{})
+	if err := DB.AutoMigrate(&VersionedDoc{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	doc := VersionedDoc{Title: "v2", Version: 2}
+	if err := DB.Create(&doc).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+
+	onConflict := clause.OnConflict{UpdateAll: true, Where: clause.Where{Exprs: []clause.Expression{
+		clause.Expr{SQL: "? > ?", Vars: []interface{}{clause.Column{Table: "excluded", Name: "version"}, clause.Column{Table: clause.CurrentTable, Name: "version"}}},
+	}}}
+
+	stmt := DB.Session(&gorm.Session{DryRun: true}).Clauses(onConflict).Create(&VersionedDoc{ID: doc.ID, Title: "v1", Version: 1}).Statement
+	if sql := stmt.SQL.String(); !regexp.MustCompile(`ON CONFLICT \(.id.\) DO UPDATE SET .* WHERE .excluded.\..version. > .versioned_docs.\..version.`).MatchString(sql) {
+		t.Errorf("should build ON CONFLICT with WHERE and primary key columns, got %v", sql)
+	}
+
+	for _, v := range []VersionedDoc{{ID: doc.ID, Title: "v1", Version: 1}, {ID: doc.ID, Title: "v3", Version: 3}} {
+		if err := DB.Clauses(onConflict).Create(&v).Error; err != nil {
+			t.Fatalf("failed to upsert, got error %v", err)
+		}
+
+		var result VersionedDoc
+		if err := DB.First(&result, doc.ID).Error; err != nil || (v.Version > 2 && result.Title != v.Title) || (v.Version < 2 && result.Title != "v2") {
+			t.Errorf("should only update newer versions, got %+v, error %v", result, err)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MockDialector dialector with configurable name, quoting, bind vars and explain, used to test clause builders without database
+//
+//	stmt := tests.NewStatement(tests.MockDialector{DialectName: "postgres", QuoteToFunc: tests.DoubleQuoteTo, BindVarToFunc: tests.DollarBindVarTo})
+//	clause.Eq{Column: "name", Value: "jinzhu"}.Build(stmt)
+//	// stmt.SQL.String() -> "name" = $1
+type MockDialector struct {
+	DummyDialector
+	DialectName   string
+	QuoteToFunc   func(writer clause.Writer, str string)
+	BindVarToFunc func(writer clause.Writer, stmt *gorm.Statement, v interface{})
+	ExplainFunc   func(sql string, vars ...interface{}) string
+}
+
+func (d MockDialector) Name() string {
+	if d.DialectName != "" {
+		return d.DialectName
+	}
+	return d.DummyDialector.Name()
+}
+
+func (d MockDialector) QuoteTo(writer clause.Writer, str string) {
+	if d.QuoteToFunc != nil {
+		d.QuoteToFunc(writer, str)
+	} else {
+		d.DummyDialector.QuoteTo(writer, str)
+	}
+}
+
+func (d MockDialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+	if d.BindVarToFunc != nil {
+		d.BindVarToFunc(writer, stmt, v)
+	} else {
+		d.DummyDialector.BindVarTo(writer, stmt, v)
+	}
+}
+
+func (d MockDialector) Explain(sql string, vars ...interface{}) string {
+	if d.ExplainFunc != nil {
+		return d.ExplainFunc(sql, vars...)
+	}
+	return d.DummyDialector.Explain(sql, vars...)
+}
+
+// DoubleQuoteTo quote identifiers with double quotes like postgres and sqlite
+func DoubleQuoteTo(writer clause.Writer, str string) {
+	for idx, name := range strings.Split(str, ".") {
+		if idx > 0 {
+			writer.WriteByte('.')
+		}
+		writer.WriteByte('"')
+		writer.WriteString(strings.ReplaceAll(name, `"`, `""`))
+		writer.WriteByte('"')
+	}
+}
+
+// DollarBindVarTo write bind vars as $1, $2 like postgres
+func DollarBindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+	writer.WriteByte('$')
+	writer.WriteString(strconv.Itoa(len(stmt.Vars)))
+}
+
+// NewStatement returns a statement of the dialector, which could be used as the clause builder
+func NewStatement(dialector gorm.Dialector) *gorm.Statement {
+	db, err := gorm.Open(dialector, &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		panic(err)
+	}
+
+	db.Statement = &gorm.Statement{DB: db, Context: context.Background(), Clauses: map[string]clause.Clause{}}
+	return db.Statement
+}
+
+// BuildExpression build the expression with the dialector, returns the SQL, vars and error
+func BuildExpression(dialector gorm.Dialector, expr clause.Expression) (string, []interface{}, error) {
+	stmt := NewStatement(dialector)
+	expr.Build(stmt)
+	return stmt.SQL.String(), stmt.Vars, stmt.Error
+}